@@ -7,23 +7,48 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"text/tabwriter"
 	"time"
 
 	"github.com/dockscope/dockscope/internal/infrastructure/api"
 	"github.com/dockscope/dockscope/internal/infrastructure/docker"
+	"github.com/dockscope/dockscope/internal/infrastructure/notify"
+	"github.com/dockscope/dockscope/internal/infrastructure/osv"
+	"github.com/dockscope/dockscope/internal/infrastructure/registry"
+	"github.com/dockscope/dockscope/internal/infrastructure/sbom"
+	"github.com/dockscope/dockscope/internal/infrastructure/store"
 	"github.com/dockscope/dockscope/internal/usecase"
 )
 
 const (
 	defaultAPIAddr = ":8080"
+	defaultDataDir = "data"
+	// defaultVolumeHelperImage only needs to exist: helper containers are
+	// never started.
+	defaultVolumeHelperImage = "busybox:latest"
+	// envCredentialsKey holds the credential store key inline.
+	envCredentialsKey = "DOCKSCOPE_CREDENTIALS_KEY"
 )
 
 func main() {
 	cliMode := flag.Bool("cli", false, "listar containers no terminal e sair (não inicia a API)")
 	allContainers := flag.Bool("all", false, "em modo CLI: incluir containers parados")
+	volumeSizes := flag.Bool("volume-sizes", true, "em modo CLI: mostrar o tamanho dos volumes (--volume-sizes=false evita o cálculo, lento em volumes grandes)")
 	apiAddr := flag.String("addr", defaultAPIAddr, "endereço HTTP da API (ex: :8080)")
+	dataDir := flag.String("data-dir", defaultDataDir, "diretório onde a configuração persistente é salva")
+	crashLoopRestarts := flag.Int("crashloop-restarts", usecase.DefaultCrashLoopPolicy.Restarts, "reinícios dentro da janela que caracterizam um crash loop (0 desativa)")
+	crashLoopWindow := flag.Duration("crashloop-window", usecase.DefaultCrashLoopPolicy.Window, "janela de detecção de crash loop")
+	autoHealAll := flag.Bool("autoheal", false, "reiniciar qualquer container unhealthy (sem isto, só os que têm a label de auto-heal)")
+	autoHealLabel := flag.String("autoheal-label", usecase.DefaultAutoHealPolicy.Label, "label (=true) que ativa o auto-heal em um container")
+	autoHealMaxAttempts := flag.Int("autoheal-max-attempts", usecase.DefaultAutoHealPolicy.MaxAttempts, "máximo de reinícios automáticos por container dentro da janela")
+	autoHealWindow := flag.Duration("autoheal-window", usecase.DefaultAutoHealPolicy.Window, "janela do limite de reinícios automáticos")
+	credentialsKeyFile := flag.String("credentials-key-file", "", "arquivo com a chave (32 bytes, base64 ou hex) que criptografa as credenciais de registries; por padrão "+envCredentialsKey+" ou <data-dir>/credentials.key, gerada na primeira inicialização")
+	osvDir := flag.String("osv-dir", "", "diretório com advisories OSV (arquivos JSON ou zips exportados do osv.dev) para a análise de vulnerabilidades; por padrão <data-dir>/osv")
+	volumeBackupDir := flag.String("volume-backup-dir", "", "diretório onde os backups de volumes são salvos; por padrão <data-dir>/volume-backups")
+	volumeHelperImage := flag.String("volume-helper-image", defaultVolumeHelperImage, "imagem dos containers auxiliares usados para acessar os arquivos dos volumes (baixada se faltar)")
+	dockerConfig := flag.String("docker-config", defaultDockerConfigPath(), "config.json do Docker de onde importar credenciais de registries")
 	verbose := flag.Bool("v", false, "logs verbosos (debug)")
 	flag.Parse()
 
@@ -44,22 +69,265 @@ func main() {
 	statsStreamer := docker.NewStatsStreamer(dockerCli, log)
 	logsStreamer := docker.NewLogsStreamer(dockerCli, log)
 	containerController := docker.NewContainerController(dockerCli, log)
+	imageManager := docker.NewImageManager(dockerCli, log)
+	volumeManager := docker.NewVolumeManager(dockerCli, log)
+	volumeFiles := docker.NewVolumeFiles(dockerCli, *volumeHelperImage, log)
 	sysInfo := docker.NewSystemInfoProvider(dockerCli, log)
+	eventWatcher := docker.NewEventWatcher(dockerCli, log)
 
-	listContainers := usecase.NewListContainers(containerRepo, log)
-	listImages := usecase.NewListImages(imageRepo, log)
-	listVolumes := usecase.NewListVolumes(volumeRepo, log)
-	getSystemSummary := usecase.NewGetSystemSummary(containerRepo, imageRepo, volumeRepo, statsStreamer, sysInfo, log)
+	listContainers := usecase.NewListContainers(containerRepo, imageManager, sysInfo, log)
+	listImages := usecase.NewListImages(imageRepo, containerRepo, imageManager, imageManager, log)
+	listVolumes := usecase.NewListVolumes(volumeRepo, volumeRepo, containerRepo, log)
+	if *cliMode {
+		runCLI(ctx, log, listContainers, listImages, listVolumes, *allContainers, *volumeSizes)
+		return
+	}
+
+	credentialsKey, generated, err := store.LoadCredentialKey(os.Getenv(envCredentialsKey), *credentialsKeyFile, *dataDir)
+	if err != nil {
+		log.Error("chave das credenciais inválida", "error", err)
+		os.Exit(1)
+	}
+	if generated {
+		log.Warn("chave das credenciais gerada no data-dir, ao lado das credenciais que criptografa; mova-a e use "+envCredentialsKey+" ou --credentials-key-file",
+			"path", store.DefaultCredentialKeyPath(*dataDir))
+	}
+	channelStore := store.NewNotificationChannelStore(*dataDir)
+	alertStore := store.NewAlertStore(*dataDir)
+	silenceStore := store.NewSilenceStore(*dataDir)
+	windowStore := store.NewMaintenanceWindowStore(*dataDir)
+	retentionPolicyStore := store.NewRetentionPolicyStore(*dataDir)
+	retentionRunStore := store.NewRetentionRunStore(*dataDir)
+	registryStore, err := store.NewRegistryStore(*dataDir, credentialsKey)
+	if err != nil {
+		log.Error("cofre de credenciais indisponível", "error", err)
+		os.Exit(1)
+	}
+	registryClient := registry.NewClient(log)
+	credentialStore, err := store.NewCredentialStore(*dataDir, credentialsKey)
+	if err != nil {
+		log.Error("cofre de credenciais indisponível", "error", err)
+		os.Exit(1)
+	}
+	if *osvDir == "" {
+		*osvDir = filepath.Join(*dataDir, "osv")
+	}
+	vulnerabilityDB := osv.NewDatabase(*osvDir, log)
+	// Exportações grandes demoram a ler; a API arranca entretanto.
+	go func() {
+		if _, err := vulnerabilityDB.Reload(ctx); err != nil {
+			log.Warn("banco de dados de vulnerabilidades não carregado", "dir", *osvDir, "error", err)
+		}
+	}()
+	if *volumeBackupDir == "" {
+		*volumeBackupDir = filepath.Join(*dataDir, "volume-backups")
+	}
+	volumeBackups := store.NewVolumeBackupDir(*volumeBackupDir)
+	volumeBackupScheduleStore := store.NewVolumeBackupScheduleStore(*dataDir)
+	lifecycleHistory := store.NewLifecycleHistory()
+	autoHealAudit := store.NewAutoHealAuditStore(*dataDir)
+	imagePolicyStore := store.NewImagePolicyStore(*dataDir)
+	imagePolicyDecisionStore := store.NewImagePolicyDecisionStore(*dataDir)
+	crashLoopPolicy := usecase.CrashLoopPolicy{Restarts: *crashLoopRestarts, Window: *crashLoopWindow}
+	notifier := notify.NewSender(log)
+
+	listUnusedImages := usecase.NewListUnusedImages(imageRepo, containerRepo, imageManager, log)
+	listOrphanVolumes := usecase.NewListOrphanVolumes(volumeRepo, volumeRepo, containerRepo, log)
+	createVolume := usecase.NewCreateVolume(volumeManager, log)
+	removeVolume := usecase.NewRemoveVolume(volumeManager, log)
+	pruneVolumes := usecase.NewPruneVolumes(volumeManager, log)
+	browseVolumeFiles := usecase.NewBrowseVolumeFiles(volumeFiles, log)
+	downloadVolumeFiles := usecase.NewDownloadVolumeFiles(volumeFiles, log)
+	uploadVolumeFiles := usecase.NewUploadVolumeFiles(volumeFiles, log)
+	cloneVolume := usecase.NewCloneVolume(volumeRepo, volumeRepo, containerRepo, volumeManager, volumeFiles, log)
+	backupVolume := usecase.NewBackupVolume(volumeFiles, volumeBackups, containerRepo, containerController, log)
+	streamVolumeBackup := usecase.NewStreamVolumeBackup(volumeFiles, containerRepo, containerController, log)
+	restoreVolume := usecase.NewRestoreVolume(volumeFiles, volumeBackups, volumeManager, containerRepo, containerController, log)
+	listVolumeBackups := usecase.NewListVolumeBackups(volumeBackups, log)
+	openVolumeBackup := usecase.NewOpenVolumeBackup(volumeBackups, log)
+	deleteVolumeBackup := usecase.NewDeleteVolumeBackup(volumeBackups, log)
+	listVolumeBackupSchedules := usecase.NewListVolumeBackupSchedules(volumeBackupScheduleStore, log)
+	saveVolumeBackupSchedule := usecase.NewSaveVolumeBackupSchedule(volumeBackupScheduleStore, log)
+	deleteVolumeBackupSchedule := usecase.NewDeleteVolumeBackupSchedule(volumeBackupScheduleStore, log)
+	runVolumeBackupSchedules := usecase.NewRunVolumeBackupSchedules(volumeBackupScheduleStore, volumeBackups, backupVolume, log)
+	pullImage := usecase.NewPullImage(imageManager, credentialStore, log)
+	pushImage := usecase.NewPushImage(imageManager, credentialStore, log)
+	removeImage := usecase.NewRemoveImage(imageManager, log)
+	tagImage := usecase.NewTagImage(imageManager, log)
+	untagImage := usecase.NewUntagImage(imageManager, log)
+	getImageHistory := usecase.NewGetImageHistory(imageManager, log)
+	getImagePlatforms := usecase.NewGetImagePlatforms(imageManager, imageManager, sysInfo, credentialStore, log)
+	exploreImageFiles := usecase.NewExploreImageFiles(imageManager, imageManager, log)
+	generateImageSBOM := usecase.NewGenerateImageSBOM(imageManager, imageManager, sbom.NewCataloger(), sbom.NewEncoder(), log)
+	scanImageVulnerabilities := usecase.NewScanImageVulnerabilities(generateImageSBOM, vulnerabilityDB, containerRepo, log)
+	listVulnerableContainers := usecase.NewListVulnerableContainers(generateImageSBOM, vulnerabilityDB, containerRepo, log)
+	getVulnerabilityDBStatus := usecase.NewGetVulnerabilityDBStatus(vulnerabilityDB, log)
+	reloadVulnerabilityDB := usecase.NewReloadVulnerabilityDB(vulnerabilityDB, log)
+	buildJobs := usecase.NewBuildJobs()
+	buildImage := usecase.NewBuildImage(imageManager, credentialStore, buildJobs, log)
+	listBuilds := usecase.NewListBuilds(buildJobs, log)
+	getBuild := usecase.NewGetBuild(buildJobs, log)
+	cancelBuild := usecase.NewCancelBuild(buildJobs, log)
+	followBuild := usecase.NewFollowBuild(buildJobs, log)
+	exportImages := usecase.NewExportImages(imageManager, log)
+	loadImages := usecase.NewLoadImages(imageManager, log)
+	importImage := usecase.NewImportImage(imageManager, log)
+	listRetentionPolicies := usecase.NewListRetentionPolicies(retentionPolicyStore, log)
+	saveRetentionPolicy := usecase.NewSaveRetentionPolicy(retentionPolicyStore, log)
+	deleteRetentionPolicy := usecase.NewDeleteRetentionPolicy(retentionPolicyStore, log)
+	previewRetention := usecase.NewPreviewRetention(retentionPolicyStore, imageRepo, containerRepo, imageManager, log)
+	runRetention := usecase.NewRunRetention(retentionPolicyStore, imageRepo, containerRepo, imageManager, imageManager, retentionRunStore, log)
+	listRetentionRuns := usecase.NewListRetentionRuns(retentionRunStore, log)
+	listRegistries := usecase.NewListRegistries(registryStore, log)
+	saveRegistry := usecase.NewSaveRegistry(registryStore, log)
+	deleteRegistry := usecase.NewDeleteRegistry(registryStore, log)
+	listRegistryRepositories := usecase.NewListRegistryRepositories(registryStore, credentialStore, registryClient, log)
+	listRegistryTags := usecase.NewListRegistryTags(registryStore, credentialStore, registryClient, log)
+	getRegistryManifest := usecase.NewGetRegistryManifest(registryStore, credentialStore, registryClient, log)
+	pullRegistryImage := usecase.NewPullRegistryImage(registryStore, credentialStore, imageManager, log)
+	listRegistryCredentials := usecase.NewListRegistryCredentials(credentialStore, log)
+	saveRegistryCredential := usecase.NewSaveRegistryCredential(credentialStore, log)
+	deleteRegistryCredential := usecase.NewDeleteRegistryCredential(credentialStore, log)
+	importRegistryCredentials := usecase.NewImportRegistryCredentials(credentialStore, *dockerConfig, log)
+	getSystemSummary := usecase.NewGetSystemSummary(containerRepo, imageRepo, volumeRepo, statsStreamer, sysInfo, lifecycleHistory, crashLoopPolicy, log)
 	streamContainerStats := usecase.NewStreamContainerStats(statsStreamer, log)
 	streamContainerLogs := usecase.NewStreamContainerLogs(logsStreamer, log)
-	executeContainerAction := usecase.NewExecuteContainerAction(containerController, log)
+	enforceImagePolicy := usecase.NewEnforceImagePolicy(imagePolicyStore, imagePolicyDecisionStore, containerRepo, imageManager, log)
+	executeContainerAction := usecase.NewExecuteContainerAction(containerController, enforceImagePolicy, log)
+	getImagePolicy := usecase.NewGetImagePolicy(imagePolicyStore, log)
+	saveImagePolicy := usecase.NewSaveImagePolicy(imagePolicyStore, log)
+	evaluateImagePolicy := usecase.NewEvaluateImagePolicy(imagePolicyStore, imageManager, log)
+	listImagePolicyDecisions := usecase.NewListImagePolicyDecisions(imagePolicyDecisionStore, log)
+	listNotificationChannels := usecase.NewListNotificationChannels(channelStore, log)
+	saveNotificationChannel := usecase.NewSaveNotificationChannel(channelStore, log)
+	deleteNotificationChannel := usecase.NewDeleteNotificationChannel(channelStore, log)
+	testNotificationChannel := usecase.NewTestNotificationChannel(channelStore, notifier, log)
+	dispatchNotification := usecase.NewDispatchNotification(channelStore, notifier, log)
+	fireAlert := usecase.NewFireAlert(alertStore, silenceStore, windowStore, dispatchNotification, log)
+	watchContainerAlerts := usecase.NewWatchContainerAlerts(eventWatcher, fireAlert, log)
+	trackContainerLifecycle := usecase.NewTrackContainerLifecycle(eventWatcher, lifecycleHistory, fireAlert, crashLoopPolicy, log)
+	getContainerLifecycle := usecase.NewGetContainerLifecycle(containerRepo, lifecycleHistory, crashLoopPolicy, log)
+	getContainerHealth := usecase.NewGetContainerHealth(containerRepo, log)
+	autoHealPolicy := usecase.DefaultAutoHealPolicy
+	autoHealPolicy.All = *autoHealAll
+	autoHealPolicy.Label = *autoHealLabel
+	autoHealPolicy.MaxAttempts = *autoHealMaxAttempts
+	autoHealPolicy.Window = *autoHealWindow
+	autoHeal := usecase.NewAutoHeal(eventWatcher, containerRepo, containerRepo, containerController, autoHealAudit, fireAlert, autoHealPolicy, log)
+	listAutoHealActions := usecase.NewListAutoHealActions(autoHealAudit, log)
+	listAlerts := usecase.NewListAlerts(alertStore, log)
+	listSilences := usecase.NewListSilences(silenceStore, log)
+	createSilence := usecase.NewCreateSilence(silenceStore, log)
+	deleteSilence := usecase.NewDeleteSilence(silenceStore, log)
+	listMaintenanceWindows := usecase.NewListMaintenanceWindows(windowStore, log)
+	saveMaintenanceWindow := usecase.NewSaveMaintenanceWindow(windowStore, log)
+	deleteMaintenanceWindow := usecase.NewDeleteMaintenanceWindow(windowStore, log)
 
-	if *cliMode {
-		runCLI(ctx, log, listContainers, listImages, listVolumes, *allContainers)
-		return
+	go func() {
+		if err := watchContainerAlerts.Execute(ctx); err != nil && ctx.Err() == nil {
+			log.Error("monitoramento de alertas encerrado", "error", err)
+		}
+	}()
+	go func() {
+		if err := trackContainerLifecycle.Execute(ctx); err != nil && ctx.Err() == nil {
+			log.Error("monitoramento do ciclo de vida encerrado", "error", err)
+		}
+	}()
+	go func() {
+		if err := autoHeal.Execute(ctx); err != nil && ctx.Err() == nil {
+			log.Error("auto-heal encerrado", "error", err)
+		}
+	}()
+	go runRetention.Run(ctx)
+	go runVolumeBackupSchedules.Run(ctx)
+	if err := volumeFiles.CleanupHelpers(ctx); err != nil {
+		log.Warn("limpeza de containers auxiliares de volumes falhou", "error", err)
 	}
 
-	srv := api.NewServer(listContainers, listImages, listVolumes, getSystemSummary, streamContainerStats, streamContainerLogs, executeContainerAction, log)
+	srv := api.NewServer(api.UseCases{
+		ListContainers:             listContainers,
+		ListImages:                 listImages,
+		ListUnusedImages:           listUnusedImages,
+		ListVolumes:                listVolumes,
+		ListOrphanVolumes:          listOrphanVolumes,
+		CreateVolume:               createVolume,
+		RemoveVolume:               removeVolume,
+		PruneVolumes:               pruneVolumes,
+		BrowseVolumeFiles:          browseVolumeFiles,
+		DownloadVolumeFiles:        downloadVolumeFiles,
+		UploadVolumeFiles:          uploadVolumeFiles,
+		CloneVolume:                cloneVolume,
+		BackupVolume:               backupVolume,
+		StreamVolumeBackup:         streamVolumeBackup,
+		RestoreVolume:              restoreVolume,
+		ListVolumeBackups:          listVolumeBackups,
+		OpenVolumeBackup:           openVolumeBackup,
+		DeleteVolumeBackup:         deleteVolumeBackup,
+		ListVolumeBackupSchedules:  listVolumeBackupSchedules,
+		SaveVolumeBackupSchedule:   saveVolumeBackupSchedule,
+		DeleteVolumeBackupSchedule: deleteVolumeBackupSchedule,
+		RunVolumeBackupSchedules:   runVolumeBackupSchedules,
+		GetSystemSummary:           getSystemSummary,
+		StreamContainerStats:       streamContainerStats,
+		StreamContainerLogs:        streamContainerLogs,
+		ExecuteContainerAction:     executeContainerAction,
+		GetImagePolicy:             getImagePolicy,
+		SaveImagePolicy:            saveImagePolicy,
+		EvaluateImagePolicy:        evaluateImagePolicy,
+		ListImagePolicyDecisions:   listImagePolicyDecisions,
+		ListNotificationChannels:   listNotificationChannels,
+		SaveNotificationChannel:    saveNotificationChannel,
+		DeleteNotificationChannel:  deleteNotificationChannel,
+		TestNotificationChannel:    testNotificationChannel,
+		ListAlerts:                 listAlerts,
+		ListSilences:               listSilences,
+		CreateSilence:              createSilence,
+		DeleteSilence:              deleteSilence,
+		ListMaintenanceWindows:     listMaintenanceWindows,
+		SaveMaintenanceWindow:      saveMaintenanceWindow,
+		DeleteMaintenanceWindow:    deleteMaintenanceWindow,
+		GetContainerLifecycle:      getContainerLifecycle,
+		GetContainerHealth:         getContainerHealth,
+		ListAutoHealActions:        listAutoHealActions,
+		PullImage:                  pullImage,
+		PushImage:                  pushImage,
+		RemoveImage:                removeImage,
+		TagImage:                   tagImage,
+		UntagImage:                 untagImage,
+		GetImageHistory:            getImageHistory,
+		GetImagePlatforms:          getImagePlatforms,
+		ExploreImageFiles:          exploreImageFiles,
+		GenerateImageSBOM:          generateImageSBOM,
+		ScanImageVulnerabilities:   scanImageVulnerabilities,
+		ListVulnerableContainers:   listVulnerableContainers,
+		GetVulnerabilityDBStatus:   getVulnerabilityDBStatus,
+		ReloadVulnerabilityDB:      reloadVulnerabilityDB,
+		BuildImage:                 buildImage,
+		ListBuilds:                 listBuilds,
+		GetBuild:                   getBuild,
+		CancelBuild:                cancelBuild,
+		FollowBuild:                followBuild,
+		ExportImages:               exportImages,
+		LoadImages:                 loadImages,
+		ImportImage:                importImage,
+		ListRetentionPolicies:      listRetentionPolicies,
+		SaveRetentionPolicy:        saveRetentionPolicy,
+		DeleteRetentionPolicy:      deleteRetentionPolicy,
+		PreviewRetention:           previewRetention,
+		RunRetention:               runRetention,
+		ListRetentionRuns:          listRetentionRuns,
+		ListRegistries:             listRegistries,
+		SaveRegistry:               saveRegistry,
+		DeleteRegistry:             deleteRegistry,
+		ListRegistryRepositories:   listRegistryRepositories,
+		ListRegistryTags:           listRegistryTags,
+		GetRegistryManifest:        getRegistryManifest,
+		PullRegistryImage:          pullRegistryImage,
+		ListRegistryCredentials:    listRegistryCredentials,
+		SaveRegistryCredential:     saveRegistryCredential,
+		DeleteRegistryCredential:   deleteRegistryCredential,
+		ImportRegistryCredentials:  importRegistryCredentials,
+	}, log)
 	if err := srv.ListenAndServe(ctx, *apiAddr); err != nil && ctx.Err() == nil {
 		log.Error("servidor API encerrado com erro", "error", err)
 		os.Exit(1)
@@ -74,6 +342,7 @@ func runCLI(
 	listImages *usecase.ListImages,
 	listVolumes *usecase.ListVolumes,
 	all bool,
+	volumeSizes bool,
 ) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
@@ -90,7 +359,7 @@ func runCLI(
 		os.Exit(1)
 	}
 
-	volumes, err := listVolumes.Execute(ctx)
+	volumes, err := listVolumes.Execute(ctx, usecase.ListVolumesInput{SkipSize: !volumeSizes})
 	if err != nil {
 		log.Error("listar volumes falhou", "error", err)
 		os.Exit(1)
@@ -100,31 +369,47 @@ func runCLI(
 	defer tw.Flush()
 
 	fmt.Fprintln(tw, "--- Containers ---")
-	fmt.Fprintf(tw, "ID\tNAMES\tIMAGE\tSTATUS\n")
+	fmt.Fprintf(tw, "ID\tNAMES\tIMAGE\tPLATFORM\tSTATUS\n")
 	for _, c := range containers {
 		names := ""
 		if len(c.Names) > 0 {
 			names = c.Names[0]
 		}
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", shortID(c.ID), names, c.Image, c.Status)
+		platform := ""
+		if c.Platform != nil {
+			platform = c.Platform.String()
+		}
+		if c.PlatformWarning != "" {
+			// Emulada: a arquitetura da imagem não é a do daemon.
+			platform += " (!)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", shortID(c.ID), names, c.Image, platform, c.Status)
 	}
 	fmt.Fprintln(tw, "")
 
 	fmt.Fprintln(tw, "--- Imagens ---")
-	fmt.Fprintf(tw, "ID\tREPO TAGS\tSIZE\n")
+	fmt.Fprintf(tw, "ID\tREPO TAGS\tPLATFORM\tSIZE\n")
 	for _, img := range images {
 		tags := ""
 		if len(img.RepoTags) > 0 {
 			tags = img.RepoTags[0]
 		}
-		fmt.Fprintf(tw, "%s\t%s\t%d\n", shortID(img.ID), tags, img.Size)
+		platform := ""
+		if img.Platform != nil {
+			platform = img.Platform.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", shortID(img.ID), tags, platform, img.Size)
 	}
 	fmt.Fprintln(tw, "")
 
 	fmt.Fprintln(tw, "--- Volumes ---")
-	fmt.Fprintf(tw, "NAME\tDRIVER\n")
+	fmt.Fprintf(tw, "NAME\tDRIVER\tSIZE\tCONTAINERS\n")
 	for _, v := range volumes {
-		fmt.Fprintf(tw, "%s\t%s\n", v.Name, v.Driver)
+		size := "-"
+		if v.UsageData != nil && v.UsageData.Size >= 0 {
+			size = fmt.Sprint(v.UsageData.Size)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", v.Name, v.Driver, size, len(v.Containers))
 	}
 
 	fmt.Fprintf(os.Stderr, "\nContainers: %d | Imagens: %d | Volumes: %d\n", len(containers), len(images), len(volumes))
@@ -144,3 +429,16 @@ func newLogger(verbose bool) *slog.Logger {
 	}
 	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
 }
+
+// defaultDockerConfigPath follows the docker CLI: $DOCKER_CONFIG/config.json,
+// else ~/.docker/config.json.
+func defaultDockerConfigPath() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".docker", "config.json")
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"path"
+	"time"
+)
+
+const (
+	RuleContainerDied  = "container_died"
+	RuleContainerOOM   = "container_oom"
+	RuleCrashLoop      = "container_crash_loop"
+	RuleUnhealthy      = "container_unhealthy"
+	RuleAutoHealGaveUp = "autoheal_gave_up"
+)
+
+type Alert struct {
+	ID            string            `json:"id"`
+	Rule          string            `json:"rule"`
+	Severity      string            `json:"severity"`
+	Title         string            `json:"title"`
+	Message       string            `json:"message"`
+	ContainerID   string            `json:"container_id,omitempty"`
+	ContainerName string            `json:"container_name,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	FiredAt       time.Time         `json:"fired_at"`
+	// SuppressedBy holds the silence or maintenance window that kept the
+	// alert from being notified.
+	SuppressedBy string `json:"suppressed_by,omitempty"`
+}
+
+func (a *Alert) Notification() *Notification {
+	return &Notification{
+		Title:         a.Title,
+		Message:       a.Message,
+		Severity:      a.Severity,
+		Rule:          a.Rule,
+		ContainerID:   a.ContainerID,
+		ContainerName: a.ContainerName,
+		Labels:        a.Labels,
+		Timestamp:     a.FiredAt,
+	}
+}
+
+const (
+	MatchContainer = "container"
+	MatchLabel     = "label"
+	MatchRule      = "rule"
+)
+
+// Matcher selects alerts by container name, label or rule. Value accepts
+// shell-style wildcards (path.Match).
+type Matcher struct {
+	Field string `json:"field"`
+	Label string `json:"label,omitempty"`
+	Value string `json:"value"`
+}
+
+func (m Matcher) Matches(a *Alert) bool {
+	switch m.Field {
+	case MatchContainer:
+		return globMatch(m.Value, a.ContainerName)
+	case MatchRule:
+		return globMatch(m.Value, a.Rule)
+	case MatchLabel:
+		v, ok := a.Labels[m.Label]
+		return ok && globMatch(m.Value, v)
+	default:
+		return false
+	}
+}
+
+type Silence struct {
+	ID        string    `json:"id"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *Silence) ActiveAt(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// Matches reports whether every matcher selects the alert.
+func (s *Silence) Matches(a *Alert) bool {
+	if len(s.Matchers) == 0 {
+		return false
+	}
+	for _, m := range s.Matchers {
+		if !m.Matches(a) {
+			return false
+		}
+	}
+	return true
+}
+
+// MaintenanceWindow suppresses notifications for containers whose labels
+// match Labels during DurationMinutes after every Schedule (cron) tick.
+type MaintenanceWindow struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Schedule        string            `json:"schedule"`
+	DurationMinutes int               `json:"duration_minutes"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Enabled         bool              `json:"enabled"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+func (w *MaintenanceWindow) ActiveAt(t time.Time) bool {
+	if !w.Enabled || w.DurationMinutes <= 0 {
+		return false
+	}
+	sched, err := ParseCron(w.Schedule)
+	if err != nil {
+		return false
+	}
+	return sched.ActiveWithin(t, time.Duration(w.DurationMinutes)*time.Minute)
+}
+
+// Covers reports whether every label of the window matches the alert. A
+// window without labels covers nothing.
+func (w *MaintenanceWindow) Covers(a *Alert) bool {
+	if len(w.Labels) == 0 {
+		return false
+	}
+	for k, v := range w.Labels {
+		got, ok := a.Labels[k]
+		if !ok || !globMatch(v, got) {
+			return false
+		}
+	}
+	return true
+}
+
+func globMatch(pattern, s string) bool {
+	ok, err := path.Match(pattern, s)
+	return err == nil && ok
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+const (
+	AutoHealRestarted   = "restarted"
+	AutoHealFailed      = "failed"
+	AutoHealCircuitOpen = "circuit_open"
+)
+
+// AutoHealAction is an audit entry for something the auto-heal controller
+// did (or refused to do) on its own.
+type AutoHealAction struct {
+	ID            string    `json:"id"`
+	ContainerID   string    `json:"container_id"`
+	ContainerName string    `json:"container_name"`
+	Action        string    `json:"action"`
+	Result        string    `json:"result"`
+	Attempt       int       `json:"attempt"`
+	Error         string    `json:"error,omitempty"`
+	Time          time.Time `json:"time"`
+}
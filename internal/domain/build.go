@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+const (
+	BuildRunning   = "running"
+	BuildSucceeded = "succeeded"
+	BuildFailed    = "failed"
+	BuildCancelled = "cancelled"
+)
+
+type BuildOptions struct {
+	Tags      []string          `json:"tags"`
+	BuildArgs map[string]string `json:"build_args,omitempty"`
+	Target    string            `json:"target,omitempty"`
+	// Dockerfile is relative to the context root; empty means "Dockerfile".
+	Dockerfile string `json:"dockerfile,omitempty"`
+	NoCache    bool   `json:"no_cache"`
+	Pull       bool   `json:"pull"`
+	// Credentials are offered to the daemon for pulling base images.
+	Credentials []RegistryCredential `json:"-"`
+}
+
+// Redacted masks the build arg values, which often carry tokens; only the
+// names are kept.
+func (o BuildOptions) Redacted() BuildOptions {
+	out := o
+	if len(o.BuildArgs) > 0 {
+		out.BuildArgs = make(map[string]string, len(o.BuildArgs))
+		for k := range o.BuildArgs {
+			out.BuildArgs[k] = RedactedSecret
+		}
+	}
+	return out
+}
+
+type BuildResult struct {
+	ImageID string `json:"image_id"`
+}
+
+type BuildJob struct {
+	ID         string       `json:"id"`
+	Status     string       `json:"status"`
+	Options    BuildOptions `json:"options"`
+	ImageID    string       `json:"image_id,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt time.Time    `json:"finished_at,omitempty"`
+	Output     []string     `json:"output,omitempty"`
+}
@@ -9,11 +9,17 @@ type Container struct {
 	ImageID    string
 	Status     string
 	State      string
+	Health     string
 	CreatedAt  time.Time
 	Labels     map[string]string
 	Ports      []PortBinding
 	Mounts     []Mount
 	HostConfig *HostConfig
+	// Platform is the platform of the container's image. PlatformWarning
+	// is set when it differs from the daemon's, i.e. the container runs
+	// under emulation.
+	Platform        *Platform
+	PlatformWarning string
 }
 
 type PortBinding struct {
@@ -24,9 +30,12 @@ type PortBinding struct {
 }
 
 type Mount struct {
-	Type   string
+	Type string
+	// Name is set for volume mounts.
+	Name   string
 	Source string
 	Target string
+	RW     bool
 }
 
 type HostConfig struct {
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DockerHubHost is how Docker Hub appears in normalized registry hosts.
+const DockerHubHost = "docker.io"
+
+// StoredRegistryCredential is a RegistryCredential as kept in the credential
+// store, one per registry host.
+type StoredRegistryCredential struct {
+	RegistryCredential
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *StoredRegistryCredential) Redacted() *StoredRegistryCredential {
+	out := *c
+	if out.Password != "" {
+		out.Password = RedactedSecret
+	}
+	if out.IdentityToken != "" {
+		out.IdentityToken = RedactedSecret
+	}
+	return &out
+}
+
+// NormalizeRegistryHost reduces a registry address as written in docker
+// config files or by users ("https://index.docker.io/v1/",
+// "registry.local:5000/") to its host, with Docker Hub's aliases folded into
+// DockerHubHost.
+func NormalizeRegistryHost(addr string) string {
+	h := strings.ToLower(strings.TrimSpace(addr))
+	h = strings.TrimPrefix(strings.TrimPrefix(h, "https://"), "http://")
+	h, _, _ = strings.Cut(h, "/")
+	switch h {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return DockerHubHost
+	}
+	return h
+}
+
+// ImageRegistryHost returns the registry host of an image reference:
+// its first path component when it looks like a host, Docker Hub otherwise.
+func ImageRegistryHost(ref string) string {
+	first, _, ok := strings.Cut(ref, "/")
+	if !ok || !(strings.ContainsAny(first, ".:") || first == "localhost") {
+		return DockerHubHost
+	}
+	return NormalizeRegistryHost(first)
+}
+
+// CredentialImportResult lists the registries imported from a docker config
+// file and the ones left out, with the reason.
+type CredentialImportResult struct {
+	Imported []string            `json:"imported"`
+	Skipped  []CredentialSkipped `json:"skipped"`
+}
+
+type CredentialSkipped struct {
+	Registry string `json:"registry"`
+	Reason   string `json:"reason"`
+}
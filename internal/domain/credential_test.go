@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestImageRegistryHost(t *testing.T) {
+	for ref, want := range map[string]string{
+		"nginx":                                   DockerHubHost,
+		"library/nginx:1.27":                      DockerHubHost,
+		"docker.io/library/nginx":                 DockerHubHost,
+		"index.docker.io/bitnami/redis":           DockerHubHost,
+		"localhost/app":                           "localhost",
+		"localhost:5000/app:v1":                   "localhost:5000",
+		"Registry.Local:5000/team/app@sha256:abc": "registry.local:5000",
+		"ghcr.io/org/tool":                        "ghcr.io",
+	} {
+		if got := ImageRegistryHost(ref); got != want {
+			t.Errorf("ImageRegistryHost(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestNormalizeRegistryHost(t *testing.T) {
+	for in, want := range map[string]string{
+		"https://index.docker.io/v1/": DockerHubHost,
+		"registry-1.docker.io":        DockerHubHost,
+		"http://registry.local:5000/": "registry.local:5000",
+		" ghcr.io ":                   "ghcr.io",
+	} {
+		if got := NormalizeRegistryHost(in); got != want {
+			t.Errorf("NormalizeRegistryHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
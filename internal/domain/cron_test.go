@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Matches(t *testing.T) {
+	cases := []struct {
+		expr string
+		at   time.Time
+		want bool
+	}{
+		{"30 2 * * *", time.Date(2026, 3, 4, 2, 30, 0, 0, time.UTC), true},
+		{"30 2 * * *", time.Date(2026, 3, 4, 2, 31, 0, 0, time.UTC), false},
+		{"*/15 * * * *", time.Date(2026, 3, 4, 9, 45, 0, 0, time.UTC), true},
+		{"0 22 * * 1-5", time.Date(2026, 3, 7, 22, 0, 0, 0, time.UTC), false}, // Saturday
+		{"0 22 * * 1-5", time.Date(2026, 3, 6, 22, 0, 0, 0, time.UTC), true},  // Friday
+		{"0 0 * * 7", time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), true},      // Sunday as 7
+		{"0 0 1 * 1", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), true},      // Monday, dom or dow
+	}
+	for _, c := range cases {
+		s, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("%q: %v", c.expr, err)
+		}
+		if got := s.Matches(c.at); got != c.want {
+			t.Errorf("%q at %s: got %v", c.expr, c.at, got)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	s, _ := ParseCron("0 3 * * 0")
+	got := s.Next(time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC))
+	want := time.Date(2026, 3, 8, 3, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestCronSchedule_ActiveWithin(t *testing.T) {
+	s, _ := ParseCron("0 2 * * *")
+	if !s.ActiveWithin(time.Date(2026, 3, 4, 2, 59, 0, 0, time.UTC), time.Hour) {
+		t.Error("expected active 59 minutes into a 1h window")
+	}
+	if s.ActiveWithin(time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC), time.Hour) {
+		t.Error("expected inactive once the window ends")
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrUnauthorized and ErrUnavailable report a remote service (e.g. a
+	// registry) rejecting our credentials or not answering.
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnavailable  = errors.New("unavailable")
+	// ErrForbidden reports an action refused by a local policy.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// InUseError reports a resource that cannot be removed, or safely copied,
+// because containers still reference it.
+type InUseError struct {
+	Resource   string
+	Containers []string
+}
+
+func (e *InUseError) Error() string {
+	return fmt.Sprintf("%s is in use by container(s) %s", e.Resource, strings.Join(e.Containers, ", "))
+}
+
+func (e *InUseError) Unwrap() error { return ErrConflict }
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	EventStart   = "start"
+	EventDie     = "die"
+	EventOOM     = "oom"
+	EventRestart = "restart"
+	EventDestroy = "destroy"
+	// EventHealthStatus carries the new status in ContainerEvent.Health.
+	EventHealthStatus = "health_status"
+)
+
+type ContainerEvent struct {
+	ContainerID   string
+	ContainerName string
+	Image         string
+	Action        string
+	ExitCode      int
+	Health        string
+	Labels        map[string]string
+	Time          time.Time
+}
@@ -12,4 +12,145 @@ type Image struct {
 	VirtualSize int64
 	Labels      map[string]string
 	ParentID    string
+	// Containers lists the containers, running or stopped, created from the
+	// image. UniqueSize is what removing the image would free: Size minus
+	// the layers other images also use (SharedSize).
+	Containers []string
+	Dangling   bool
+	UniqueSize int64
+	// Platform is nil when the image could not be inspected.
+	Platform *Platform
+}
+
+const (
+	PhaseWaiting     = "waiting"
+	PhaseDownloading = "downloading"
+	PhaseExtracting  = "extracting"
+	PhaseUploading   = "uploading"
+	PhaseExists      = "exists"
+	PhaseComplete    = "complete"
+	PhaseOther       = "other"
+)
+
+// LayerProgress is one progress update for a layer (or the whole operation
+// when LayerID is empty) reported by the daemon during pulls and pushes.
+type LayerProgress struct {
+	LayerID string `json:"layer_id,omitempty"`
+	Phase   string `json:"phase"`
+	Status  string `json:"status"`
+	Current int64  `json:"current,omitempty"`
+	Total   int64  `json:"total,omitempty"`
+}
+
+type ImageDeleteResult struct {
+	Untagged []string `json:"untagged"`
+	Deleted  []string `json:"deleted"`
+}
+
+type PullResult struct {
+	Ref    string `json:"ref"`
+	Digest string `json:"digest,omitempty"`
+	Status string `json:"status"`
+}
+
+type PushResult struct {
+	Ref    string `json:"ref"`
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+const (
+	PushErrorAuthDenied  = "auth_denied"
+	PushErrorUnknownBlob = "unknown_blob"
+	PushErrorUnreachable = "registry_unreachable"
+	PushErrorNotFound    = "image_not_found"
+	PushErrorFailed      = "push_failed"
+)
+
+// PushError classifies a failed push so clients can tell a credential
+// problem from a registry that is down.
+type PushError struct {
+	Code    string
+	Message string
+}
+
+func (e *PushError) Error() string { return e.Message }
+
+func (e *PushError) Unwrap() error {
+	switch e.Code {
+	case PushErrorAuthDenied:
+		return ErrUnauthorized
+	case PushErrorUnreachable:
+		return ErrUnavailable
+	case PushErrorNotFound:
+		return ErrNotFound
+	}
+	return nil
+}
+
+type RegistryCredential struct {
+	Registry      string `json:"registry"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identity_token,omitempty"`
+}
+
+// ImageDetails holds the inspect-level data that the list endpoint does not
+// return.
+type ImageDetails struct {
+	ID           string       `json:"id"`
+	RepoTags     []string     `json:"repo_tags"`
+	RepoDigests  []string     `json:"repo_digests"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Size         int64        `json:"size"`
+	Author       string       `json:"author,omitempty"`
+	Architecture string       `json:"architecture"`
+	Variant      string       `json:"variant,omitempty"`
+	OS           string       `json:"os"`
+	Config       ImageConfig  `json:"config"`
+	Layers       []ImageLayer `json:"layers"`
+}
+
+type ImageConfig struct {
+	Env          []string          `json:"env"`
+	Entrypoint   []string          `json:"entrypoint"`
+	Cmd          []string          `json:"cmd"`
+	WorkingDir   string            `json:"working_dir,omitempty"`
+	User         string            `json:"user,omitempty"`
+	ExposedPorts []string          `json:"exposed_ports"`
+	Labels       map[string]string `json:"labels"`
+}
+
+// ImageLayer is one history entry, oldest first. Entries created by metadata
+// instructions (ENV, CMD, ...) have Empty set and no DiffID. Shared reports
+// whether another local image uses the same layer, so removing this image
+// would not free its space.
+type ImageLayer struct {
+	DiffID    string    `json:"diff_id,omitempty"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+	Comment   string    `json:"comment,omitempty"`
+	Empty     bool      `json:"empty"`
+	Shared    bool      `json:"shared"`
+}
+
+type LoadResult struct {
+	// Images lists the tags, or the IDs of untagged images, that were loaded.
+	Images []string `json:"images"`
+}
+
+type ImportOptions struct {
+	Ref     string
+	Message string
+	// Changes are Dockerfile instructions applied to the imported image,
+	// e.g. `CMD ["/bin/sh"]`.
+	Changes []string
+}
+
+// LayerRef identifies a filesystem layer as stored by the daemon: by chain
+// ID, so the same diff on a different parent is a different layer.
+type LayerRef struct {
+	ChainID string
+	Size    int64
 }
@@ -0,0 +1,316 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	FileTypeFile     = "file"
+	FileTypeDir      = "dir"
+	FileTypeSymlink  = "symlink"
+	FileTypeHardlink = "hardlink"
+	FileTypeOther    = "other"
+)
+
+const (
+	ChangeAdded     = "added"
+	ChangeModified  = "modified"
+	ChangeDeleted   = "deleted"
+	ChangeUnchanged = "unchanged"
+)
+
+// LayerFile is one entry of a layer tarball, with Path relative to the root
+// and without a leading slash. Whiteout marks Path as deleted from the layers
+// below; Opaque marks the directory Path as hiding everything below it.
+type LayerFile struct {
+	Path       string
+	Type       string
+	Size       int64
+	LinkTarget string
+	Executable bool
+	Whiteout   bool
+	Opaque     bool
+}
+
+type LayerContents struct {
+	Files []LayerFile
+}
+
+type LayerFileStats struct {
+	Index    int   `json:"index"`
+	Size     int64 `json:"size"`
+	Added    int   `json:"added"`
+	Modified int   `json:"modified"`
+	Deleted  int   `json:"deleted"`
+}
+
+// WastedFile is a path whose content in some layer is never visible in the
+// final image because a later layer overwrote or deleted it.
+type WastedFile struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+	Count int    `json:"count"`
+}
+
+type FileTreeEntry struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	LinkTarget string `json:"link_target,omitempty"`
+	Change     string `json:"change"`
+}
+
+type fileVersion struct {
+	layer  int
+	change string
+	typ    string
+	size   int64
+	link   string
+}
+
+// ImageFileTree is the merged filesystem of an image's layers, keeping every
+// version of every path so the tree can be viewed as of any layer.
+type ImageFileTree struct {
+	versions map[string][]fileVersion
+	children map[string]map[string]struct{}
+	wasted   map[string]*WastedFile
+
+	Layers      []LayerFileStats
+	TotalBytes  int64
+	WastedBytes int64
+}
+
+// BuildImageFileTree applies layers in order, bottom first.
+func BuildImageFileTree(layers []LayerContents) *ImageFileTree {
+	t := &ImageFileTree{
+		versions: make(map[string][]fileVersion),
+		children: make(map[string]map[string]struct{}),
+		wasted:   make(map[string]*WastedFile),
+		Layers:   make([]LayerFileStats, len(layers)),
+	}
+	for i, l := range layers {
+		st := &t.Layers[i]
+		st.Index = i
+		// Deletions only hide lower layers, so apply them before the
+		// layer's own files regardless of tar order.
+		for _, f := range l.Files {
+			switch {
+			case f.Opaque:
+				for name := range t.children[f.Path] {
+					t.remove(join(f.Path, name), i, st)
+				}
+			case f.Whiteout:
+				t.remove(f.Path, i, st)
+			}
+		}
+		for _, f := range l.Files {
+			if !f.Whiteout && !f.Opaque && f.Path != "" {
+				t.add(f, i, st)
+			}
+		}
+	}
+	return t
+}
+
+func (t *ImageFileTree) add(f LayerFile, layer int, st *LayerFileStats) {
+	t.ensureParents(f.Path, layer, st)
+	prev := t.live(f.Path, layer)
+	if f.Type == FileTypeDir && prev != nil && prev.typ == FileTypeDir {
+		// Directories reappear in every layer that touches their children.
+		return
+	}
+	v := fileVersion{layer: layer, change: ChangeAdded, typ: f.Type, size: f.Size, link: f.LinkTarget}
+	if vs := t.versions[f.Path]; prev == nil && len(vs) > 0 && vs[len(vs)-1].layer == layer {
+		// Hidden by an opaque directory and recreated in the same layer.
+		if vs[len(vs)-1].typ != FileTypeDir {
+			st.Deleted--
+		}
+		t.versions[f.Path] = vs[:len(vs)-1]
+		v.change = ChangeModified
+	}
+	if prev != nil {
+		if prev.layer == layer {
+			return
+		}
+		v.change = ChangeModified
+		if prev.typ == FileTypeDir {
+			for name := range t.children[f.Path] {
+				t.remove(join(f.Path, name), layer, st)
+			}
+		} else {
+			t.waste(f.Path, prev.size)
+		}
+	}
+	t.versions[f.Path] = append(t.versions[f.Path], v)
+	if f.Type == FileTypeDir && v.change == ChangeModified {
+		return
+	}
+	if f.Type != FileTypeDir {
+		t.TotalBytes += f.Size
+		st.Size += f.Size
+		if v.change == ChangeAdded {
+			st.Added++
+		} else {
+			st.Modified++
+		}
+	}
+}
+
+// remove deletes p and everything below it, returning the bytes removed.
+func (t *ImageFileTree) remove(p string, layer int, st *LayerFileStats) int64 {
+	prev := t.live(p, layer)
+	if prev == nil || prev.layer == layer {
+		return 0
+	}
+	size := prev.size
+	if prev.typ == FileTypeDir {
+		size = 0
+		for name := range t.children[p] {
+			size += t.remove(join(p, name), layer, st)
+		}
+	} else {
+		t.waste(p, prev.size)
+		st.Deleted++
+	}
+	t.versions[p] = append(t.versions[p], fileVersion{layer: layer, change: ChangeDeleted, typ: prev.typ, size: size})
+	return size
+}
+
+// ensureParents registers p with its parent and creates parent directories
+// that the tarball does not list explicitly.
+func (t *ImageFileTree) ensureParents(p string, layer int, st *LayerFileStats) {
+	dir, name := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	if t.children[dir] == nil {
+		t.children[dir] = make(map[string]struct{})
+	}
+	t.children[dir][name] = struct{}{}
+	if dir != "" {
+		if v := t.live(dir, layer); v == nil || v.typ != FileTypeDir {
+			t.add(LayerFile{Path: dir, Type: FileTypeDir}, layer, st)
+		}
+	}
+}
+
+func (t *ImageFileTree) waste(p string, size int64) {
+	t.WastedBytes += size
+	w := t.wasted[p]
+	if w == nil {
+		w = &WastedFile{Path: "/" + p}
+		t.wasted[p] = w
+	}
+	w.Bytes += size
+	w.Count++
+}
+
+// at returns the newest version of p as of layer, or nil if p did not exist
+// yet.
+func (t *ImageFileTree) at(p string, layer int) *fileVersion {
+	vs := t.versions[p]
+	for i := len(vs) - 1; i >= 0; i-- {
+		if vs[i].layer <= layer {
+			return &vs[i]
+		}
+	}
+	return nil
+}
+
+// live is like at but also returns nil for deleted paths.
+func (t *ImageFileTree) live(p string, layer int) *fileVersion {
+	v := t.at(p, layer)
+	if v == nil || v.change == ChangeDeleted {
+		return nil
+	}
+	return v
+}
+
+// List returns the entries of directory dir as of layer, including the ones
+// deleted by that layer. Directory sizes are the total of their live files,
+// and a directory counts as modified when the layer changed anything below
+// it.
+func (t *ImageFileTree) List(layer int, dir string) ([]FileTreeEntry, error) {
+	if layer < 0 || layer >= len(t.Layers) {
+		return nil, fmt.Errorf("layer %d out of range: %w", layer, ErrInvalidArgument)
+	}
+	dir = strings.Trim(path.Clean("/"+dir), "/")
+	if dir != "" {
+		if v := t.live(dir, layer); v == nil || v.typ != FileTypeDir {
+			return nil, fmt.Errorf("directory /%s: %w", dir, ErrNotFound)
+		}
+	}
+	out := []FileTreeEntry{}
+	for name := range t.children[dir] {
+		p := join(dir, name)
+		v := t.at(p, layer)
+		if v == nil || (v.change == ChangeDeleted && v.layer != layer) {
+			continue
+		}
+		e := FileTreeEntry{Name: name, Path: "/" + p, Type: v.typ, Size: v.size, LinkTarget: v.link, Change: ChangeUnchanged}
+		if v.layer == layer {
+			e.Change = v.change
+		}
+		if v.typ == FileTypeDir && v.change != ChangeDeleted {
+			size, changed := t.summarize(p, layer)
+			e.Size = size
+			if changed && e.Change == ChangeUnchanged {
+				e.Change = ChangeModified
+			}
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (t *ImageFileTree) summarize(dir string, layer int) (size int64, changed bool) {
+	for name := range t.children[dir] {
+		p := join(dir, name)
+		v := t.at(p, layer)
+		if v == nil {
+			continue
+		}
+		if v.layer == layer {
+			changed = true
+		}
+		if v.change == ChangeDeleted {
+			continue
+		}
+		if v.typ == FileTypeDir {
+			s, c := t.summarize(p, layer)
+			size += s
+			changed = changed || c
+		} else {
+			size += v.size
+		}
+	}
+	return size, changed
+}
+
+// TopWasted returns the paths wasting the most bytes, largest first.
+func (t *ImageFileTree) TopWasted(limit int) []WastedFile {
+	out := make([]WastedFile, 0, len(t.wasted))
+	for _, w := range t.wasted {
+		out = append(out, *w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Path < out[j].Path
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func join(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
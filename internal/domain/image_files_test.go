@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func testImageFileTree() *ImageFileTree {
+	return BuildImageFileTree([]LayerContents{
+		{Files: []LayerFile{
+			{Path: "etc", Type: FileTypeDir},
+			{Path: "etc/app.conf", Type: FileTypeFile, Size: 10},
+			{Path: "var/cache/apt/pkgs.bin", Type: FileTypeFile, Size: 500},
+			{Path: "var/cache/apt/index", Type: FileTypeFile, Size: 50},
+			{Path: "bin/sh", Type: FileTypeSymlink, LinkTarget: "busybox"},
+		}},
+		{Files: []LayerFile{
+			{Path: "etc/app.conf", Type: FileTypeFile, Size: 12},
+			{Path: "var/cache/apt", Whiteout: true},
+			{Path: "srv/data", Type: FileTypeFile, Size: 100},
+		}},
+		{Files: []LayerFile{
+			{Path: "srv", Opaque: true},
+			{Path: "srv/new", Type: FileTypeFile, Size: 7},
+		}},
+	})
+}
+
+func entryByName(entries []FileTreeEntry, name string) *FileTreeEntry {
+	for i := range entries {
+		if entries[i].Name == name {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func TestImageFileTree_Wasted(t *testing.T) {
+	tree := testImageFileTree()
+	// app.conf overwritten (10), apt cache deleted (550), srv/data hidden (100).
+	if tree.WastedBytes != 660 {
+		t.Errorf("wasted = %d, want 660", tree.WastedBytes)
+	}
+	if tree.TotalBytes != 679 {
+		t.Errorf("total = %d, want 679", tree.TotalBytes)
+	}
+	top := tree.TopWasted(1)
+	if len(top) != 1 || top[0].Path != "/var/cache/apt/pkgs.bin" || top[0].Bytes != 500 {
+		t.Errorf("unexpected top wasted: %+v", top)
+	}
+	want := []LayerFileStats{
+		{Index: 0, Size: 560, Added: 4},
+		{Index: 1, Size: 112, Added: 1, Modified: 1, Deleted: 2},
+		{Index: 2, Size: 7, Added: 1, Deleted: 1},
+	}
+	for i, w := range want {
+		if tree.Layers[i] != w {
+			t.Errorf("layer %d stats = %+v, want %+v", i, tree.Layers[i], w)
+		}
+	}
+}
+
+func TestImageFileTree_List(t *testing.T) {
+	tree := testImageFileTree()
+
+	root, err := tree.List(0, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := entryByName(root, "var"); e == nil || e.Size != 550 || e.Change != ChangeAdded {
+		t.Errorf("layer 0 /var = %+v", e)
+	}
+
+	cache, err := tree.List(1, "/var/cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := entryByName(cache, "apt"); e == nil || e.Change != ChangeDeleted {
+		t.Errorf("layer 1 /var/cache/apt = %+v, want deleted", e)
+	}
+	root, _ = tree.List(1, "")
+	if e := entryByName(root, "etc"); e == nil || e.Change != ChangeModified || e.Size != 12 {
+		t.Errorf("layer 1 /etc = %+v", e)
+	}
+	if e := entryByName(root, "bin"); e == nil || e.Change != ChangeUnchanged {
+		t.Errorf("layer 1 /bin = %+v", e)
+	}
+
+	srv, err := tree.List(2, "srv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := entryByName(srv, "data"); e == nil || e.Change != ChangeDeleted {
+		t.Errorf("layer 2 /srv/data = %+v, want deleted", e)
+	}
+	if e := entryByName(srv, "new"); e == nil || e.Change != ChangeAdded {
+		t.Errorf("layer 2 /srv/new = %+v, want added", e)
+	}
+
+	if _, err := tree.List(2, "/var/cache/apt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for deleted dir, got %v", err)
+	}
+	if _, err := tree.List(3, "/"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for bad layer, got %v", err)
+	}
+}
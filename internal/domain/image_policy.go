@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// ImagePolicyOff skips evaluation, ImagePolicyAudit evaluates and records
+	// violations without blocking, ImagePolicyEnforce blocks them.
+	ImagePolicyOff     = "off"
+	ImagePolicyAudit   = "audit"
+	ImagePolicyEnforce = "enforce"
+)
+
+const (
+	PolicyRuleAllowedRegistries = "allowed_registries"
+	PolicyRuleRequireDigest     = "require_digest"
+	PolicyRuleDeniedTags        = "denied_tags"
+	PolicyRuleRequiredLabels    = "required_labels"
+	PolicyRuleMaxAge            = "max_age"
+)
+
+// ImagePolicy restricts which images containers may start from. Empty rules
+// are disabled.
+type ImagePolicy struct {
+	Mode string `json:"mode"`
+	// AllowedRegistries are globs over registry hosts, e.g.
+	// "registry.local:5000" or "*.example.com"; Docker Hub is "docker.io".
+	AllowedRegistries []string `json:"allowed_registries"`
+	// RequireDigest requires references pinned with @sha256:...
+	RequireDigest bool `json:"require_digest"`
+	// DeniedTags are globs over tags, e.g. "latest" or "*-dev".
+	DeniedTags []string `json:"denied_tags"`
+	// RequiredLabels are "key" (any value) or "key=value".
+	RequiredLabels []string  `json:"required_labels"`
+	MaxAgeDays     int       `json:"max_age_days"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Evaluate checks the image reference ref against the policy. img is the
+// local image ref resolves to; rules that need its metadata are reported as
+// violated when it is nil.
+func (p *ImagePolicy) Evaluate(ref string, img *ImageDetails, now time.Time) []PolicyViolation {
+	out := []PolicyViolation{}
+	if isImageID(ref) && img != nil && len(img.RepoTags) > 0 {
+		// Containers created from an image ID carry no registry or tag;
+		// judge them by the image's first tag.
+		ref = img.RepoTags[0]
+	}
+	name, digest, _ := strings.Cut(ref, "@")
+	repo, tag := SplitImageRef(name)
+	if digest != "" && repo == name {
+		// Pinned by digest alone.
+		tag = ""
+	}
+
+	if len(p.AllowedRegistries) > 0 {
+		host := ImageRegistryHost(repo)
+		allowed := false
+		for _, pattern := range p.AllowedRegistries {
+			if ok, _ := path.Match(NormalizeRegistryHost(pattern), host); ok {
+				allowed = true
+				break
+			}
+		}
+		switch {
+		case isImageID(ref):
+			out = append(out, PolicyViolation{PolicyRuleAllowedRegistries, "untagged image has no registry"})
+		case !allowed:
+			out = append(out, PolicyViolation{PolicyRuleAllowedRegistries, fmt.Sprintf("registry %s is not allowed", host)})
+		}
+	}
+	if p.RequireDigest && !strings.HasPrefix(digest, "sha256:") {
+		out = append(out, PolicyViolation{PolicyRuleRequireDigest, fmt.Sprintf("%s is not pinned by digest", ref)})
+	}
+	if tag != "" && !isImageID(ref) {
+		for _, pattern := range p.DeniedTags {
+			if ok, _ := path.Match(pattern, tag); ok {
+				out = append(out, PolicyViolation{PolicyRuleDeniedTags, fmt.Sprintf("tag %q is denied", tag)})
+				break
+			}
+		}
+	}
+	if len(p.RequiredLabels) > 0 {
+		var missing []string
+		for _, want := range p.RequiredLabels {
+			key, value, withValue := strings.Cut(want, "=")
+			got, ok := "", false
+			if img != nil {
+				got, ok = img.Config.Labels[key]
+			}
+			if !ok || (withValue && got != value) {
+				missing = append(missing, want)
+			}
+		}
+		if len(missing) > 0 {
+			out = append(out, PolicyViolation{PolicyRuleRequiredLabels, "missing labels: " + strings.Join(missing, ", ")})
+		}
+	}
+	if p.MaxAgeDays > 0 {
+		switch {
+		case img == nil || img.CreatedAt.IsZero():
+			out = append(out, PolicyViolation{PolicyRuleMaxAge, "image creation date is unknown"})
+		case now.Sub(img.CreatedAt) > time.Duration(p.MaxAgeDays)*24*time.Hour:
+			days := int(now.Sub(img.CreatedAt).Hours() / 24)
+			out = append(out, PolicyViolation{PolicyRuleMaxAge, fmt.Sprintf("image is %d days old, the maximum is %d", days, p.MaxAgeDays)})
+		}
+	}
+	return out
+}
+
+func isImageID(ref string) bool {
+	return strings.HasPrefix(ref, "sha256:")
+}
+
+// ImagePolicyError reports an image blocked by the enforced policy.
+type ImagePolicyError struct {
+	Image      string
+	Violations []PolicyViolation
+}
+
+func (e *ImagePolicyError) Error() string {
+	rules := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		rules[i] = v.Rule
+	}
+	return fmt.Sprintf("image %s violates the image policy (%s)", e.Image, strings.Join(rules, ", "))
+}
+
+func (e *ImagePolicyError) Unwrap() error { return ErrForbidden }
+
+// ImagePolicyDecision records one evaluation made while the policy was in
+// audit or enforce mode. Allowed is false only when the action was blocked.
+type ImagePolicyDecision struct {
+	Time        time.Time         `json:"time"`
+	ContainerID string            `json:"container_id,omitempty"`
+	Container   string            `json:"container,omitempty"`
+	Image       string            `json:"image"`
+	Action      string            `json:"action"`
+	Mode        string            `json:"mode"`
+	Violations  []PolicyViolation `json:"violations"`
+	Allowed     bool              `json:"allowed"`
+}
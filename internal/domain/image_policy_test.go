@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImagePolicy_Evaluate(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := &ImagePolicy{
+		Mode:              ImagePolicyEnforce,
+		AllowedRegistries: []string{"registry.local:5000", "*.example.com"},
+		DeniedTags:        []string{"latest", "*-dev"},
+		RequiredLabels:    []string{"org.opencontainers.image.source", "team=payments"},
+		MaxAgeDays:        30,
+	}
+	fresh := &ImageDetails{
+		CreatedAt: now.Add(-24 * time.Hour),
+		Config:    ImageConfig{Labels: map[string]string{"org.opencontainers.image.source": "git", "team": "payments"}},
+	}
+	stale := &ImageDetails{CreatedAt: now.Add(-60 * 24 * time.Hour), Config: ImageConfig{Labels: map[string]string{"team": "search"}}}
+
+	for _, tc := range []struct {
+		ref  string
+		img  *ImageDetails
+		want []string
+	}{
+		{"registry.local:5000/pay/api:1.4", fresh, nil},
+		{"cr.example.com/pay/api@sha256:abc", fresh, nil},
+		{"registry.local:5000/pay/api", fresh, []string{PolicyRuleDeniedTags}},
+		{"cr.example.com/pay/api:1.4-dev", fresh, []string{PolicyRuleDeniedTags}},
+		{"nginx:1.27", fresh, []string{PolicyRuleAllowedRegistries}},
+		{"registry.local:5000/pay/api:1.4", stale, []string{PolicyRuleRequiredLabels, PolicyRuleMaxAge}},
+		{"registry.local:5000/pay/api:1.4", nil, []string{PolicyRuleRequiredLabels, PolicyRuleMaxAge}},
+	} {
+		var got []string
+		for _, v := range p.Evaluate(tc.ref, tc.img, now) {
+			got = append(got, v.Rule)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Evaluate(%q) = %v, want %v", tc.ref, got, tc.want)
+		}
+	}
+
+	digest := &ImagePolicy{RequireDigest: true, AllowedRegistries: []string{"docker.io"}}
+	if v := digest.Evaluate("nginx:1.27", nil, now); len(v) != 1 || v[0].Rule != PolicyRuleRequireDigest {
+		t.Errorf("unpinned ref: %+v", v)
+	}
+	if v := digest.Evaluate("nginx:1.27@sha256:abc", nil, now); len(v) != 0 {
+		t.Errorf("pinned ref: %+v", v)
+	}
+	// An image ID is judged by the image's tag.
+	byID := &ImageDetails{RepoTags: []string{"nginx:latest"}}
+	if v := (&ImagePolicy{DeniedTags: []string{"latest"}}).Evaluate("sha256:0123", byID, now); len(v) != 1 || v[0].Rule != PolicyRuleDeniedTags {
+		t.Errorf("image ID: %+v", v)
+	}
+}
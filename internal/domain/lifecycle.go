@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type ContainerState struct {
+	Status       string    `json:"status"`
+	Running      bool      `json:"running"`
+	Restarting   bool      `json:"restarting"`
+	OOMKilled    bool      `json:"oom_killed"`
+	ExitCode     int       `json:"exit_code"`
+	Error        string    `json:"error,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	RestartCount int       `json:"restart_count"`
+	// Health is nil when the container has no HEALTHCHECK.
+	Health *ContainerHealth `json:"health,omitempty"`
+}
+
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+type ContainerHealth struct {
+	Status        string        `json:"status"`
+	FailingStreak int           `json:"failing_streak"`
+	Probes        []HealthProbe `json:"probes"`
+}
+
+type HealthProbe struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	ExitCode int       `json:"exit_code"`
+	Output   string    `json:"output"`
+}
+
+// ContainerDetails holds the inspect-level data that the list endpoint does
+// not return.
+type ContainerDetails struct {
+	ID      string
+	Name    string
+	Image   string
+	ImageID string
+	Labels  map[string]string
+	State   ContainerState
+}
+
+type LifecycleEvent struct {
+	Action    string    `json:"action"`
+	ExitCode  int       `json:"exit_code,omitempty"`
+	OOMKilled bool      `json:"oom_killed,omitempty"`
+	Health    string    `json:"health,omitempty"`
+	Time      time.Time `json:"time"`
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"net/url"
+	"time"
+)
+
+const (
+	ChannelTypeWebhook    = "webhook"
+	ChannelTypeEmail      = "email"
+	ChannelTypeSlack      = "slack"
+	ChannelTypeMattermost = "mattermost"
+	ChannelTypeDiscord    = "discord"
+)
+
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// RedactedSecret replaces secrets in channels returned by the API. Sending it
+// back on update keeps the stored value.
+const RedactedSecret = "********"
+
+type NotificationChannel struct {
+	ID                 string         `json:"id"`
+	Name               string         `json:"name"`
+	Type               string         `json:"type"`
+	Enabled            bool           `json:"enabled"`
+	Webhook            *WebhookConfig `json:"webhook,omitempty"`
+	Email              *EmailConfig   `json:"email,omitempty"`
+	Chat               *ChatConfig    `json:"chat,omitempty"`
+	RateLimitPerMinute int            `json:"rate_limit_per_minute,omitempty"`
+	MaxRetries         int            `json:"max_retries,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Secret  string            `json:"secret,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type EmailConfig struct {
+	Host            string   `json:"host"`
+	Port            int      `json:"port"`
+	Username        string   `json:"username,omitempty"`
+	Password        string   `json:"password,omitempty"`
+	From            string   `json:"from"`
+	To              []string `json:"to"`
+	SubjectTemplate string   `json:"subject_template,omitempty"`
+	BodyTemplate    string   `json:"body_template,omitempty"`
+}
+
+// ChatConfig covers Slack, Mattermost and Discord incoming webhooks.
+type ChatConfig struct {
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+}
+
+// Redacted masks the channel's secrets: the webhook secret and header
+// values, the email password and, since an incoming webhook URL is itself
+// the credential, everything in a chat URL after the host.
+func (c *NotificationChannel) Redacted() *NotificationChannel {
+	out := *c
+	if c.Webhook != nil {
+		wh := *c.Webhook
+		if wh.Secret != "" {
+			wh.Secret = RedactedSecret
+		}
+		if len(wh.Headers) > 0 {
+			wh.Headers = make(map[string]string, len(c.Webhook.Headers))
+			for k := range c.Webhook.Headers {
+				wh.Headers[k] = RedactedSecret
+			}
+		}
+		out.Webhook = &wh
+	}
+	if c.Chat != nil {
+		chat := *c.Chat
+		chat.URL = RedactURL(chat.URL)
+		out.Chat = &chat
+	}
+	if c.Email != nil {
+		em := *c.Email
+		if em.Password != "" {
+			em.Password = RedactedSecret
+		}
+		out.Email = &em
+	}
+	return &out
+}
+
+// RedactURL keeps only the scheme and host of raw, e.g.
+// "https://hooks.slack.com/********".
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return RedactedSecret
+	}
+	return u.Scheme + "://" + u.Host + "/" + RedactedSecret
+}
+
+type Notification struct {
+	Title         string            `json:"title"`
+	Message       string            `json:"message"`
+	Severity      string            `json:"severity"`
+	Rule          string            `json:"rule,omitempty"`
+	ContainerID   string            `json:"container_id,omitempty"`
+	ContainerName string            `json:"container_name,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Timestamp     time.Time         `json:"timestamp"`
+}
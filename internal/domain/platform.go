@@ -0,0 +1,42 @@
+package domain
+
+import "strings"
+
+// Platform is the OS and CPU architecture an image is built for, with the
+// variant for ARM ("v7", "v8").
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// String formats the platform as docker's --platform flag does:
+// "linux/arm64/v8".
+func (p Platform) String() string {
+	parts := []string{p.OS, p.Architecture}
+	if p.Variant != "" {
+		parts = append(parts, p.Variant)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Runs reports whether an image for p runs natively on a host of platform
+// host. Variants are compared only when both sides know theirs.
+func (p Platform) Runs(host Platform) bool {
+	if p.OS != host.OS || p.Architecture != host.Architecture {
+		return false
+	}
+	return p.Variant == "" || host.Variant == "" || p.Variant == host.Variant
+}
+
+// ImageDistribution is what a registry reports for an image reference: the
+// manifest it resolves to and, for a manifest list, the platform of each
+// entry.
+type ImageDistribution struct {
+	Ref       string     `json:"ref"`
+	Digest    string     `json:"digest"`
+	MediaType string     `json:"media_type"`
+	Size      int64      `json:"size"`
+	MultiArch bool       `json:"multi_arch"`
+	Platforms []Platform `json:"platforms"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Registry is a Docker Registry HTTP API v2 endpoint that can be browsed.
+type Registry struct {
+	Name string `json:"name"`
+	// URL is the registry's base URL, e.g. "https://registry.local:5000".
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Insecure skips TLS certificate verification.
+	Insecure  bool      `json:"insecure"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r *Registry) Redacted() *Registry {
+	out := *r
+	if out.Password != "" {
+		out.Password = RedactedSecret
+	}
+	return &out
+}
+
+// Host is the registry address as it appears in image references.
+func (r *Registry) Host() string {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return ""
+	}
+	return NormalizeRegistryHost(u.Host)
+}
+
+// ImageRef returns the reference to pull repo at ref, a tag or a digest.
+func (r *Registry) ImageRef(repo, ref string) string {
+	if strings.Contains(ref, ":") {
+		return r.Host() + "/" + repo + "@" + ref
+	}
+	return r.Host() + "/" + repo + ":" + ref
+}
+
+// RegistryRepositories is one page of the catalog. Next, when set, is the
+// value to pass as "last" to get the following page.
+type RegistryRepositories struct {
+	Repositories []string `json:"repositories"`
+	Next         string   `json:"next,omitempty"`
+}
+
+type RegistryTags struct {
+	Repository string   `json:"repository"`
+	Tags       []string `json:"tags"`
+	Next       string   `json:"next,omitempty"`
+}
+
+type RegistryDescriptor struct {
+	MediaType string `json:"media_type"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// RegistryManifest is an image manifest or a manifest list (OCI index). For
+// an image, Size is the compressed size of its config and layers; for a list,
+// Manifests holds one entry per platform and Size counts every distinct blob
+// they reference once.
+type RegistryManifest struct {
+	Repository string               `json:"repository,omitempty"`
+	Reference  string               `json:"reference,omitempty"`
+	Digest     string               `json:"digest"`
+	MediaType  string               `json:"media_type"`
+	PullRef    string               `json:"pull_ref,omitempty"`
+	Size       int64                `json:"size"`
+	Platform   *Platform            `json:"platform,omitempty"`
+	CreatedAt  *time.Time           `json:"created_at,omitempty"`
+	Config     *RegistryDescriptor  `json:"config,omitempty"`
+	Layers     []RegistryDescriptor `json:"layers,omitempty"`
+	Manifests  []RegistryManifest   `json:"manifests,omitempty"`
+}
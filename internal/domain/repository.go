@@ -17,6 +17,49 @@ type VolumeRepository interface {
 	List(ctx context.Context) ([]*Volume, error)
 }
 
+// VolumeUsageProvider reports the disk usage of every volume by name. It
+// walks the volumes on disk, so it is slow on large ones.
+type VolumeUsageProvider interface {
+	VolumeUsage(ctx context.Context) (map[string]VolumeUsageData, error)
+}
+
+// VolumeFileAccess reaches the files of a volume through a short-lived
+// helper container; paths are absolute from the volume root. Read streams a
+// file or directory as a tar archive whose Close removes the helper, along
+// with what Stat would return for path, so callers that need both use one
+// helper. Write extracts a tar archive into the existing directory dir.
+type VolumeFileAccess interface {
+	Stat(ctx context.Context, volume, path string) (*VolumeFileEntry, error)
+	Read(ctx context.Context, volume, path string) (io.ReadCloser, *VolumeFileEntry, error)
+	Write(ctx context.Context, volume, dir string, archive io.Reader) error
+}
+
+// VolumeBackupRepository stores volume backup archives. Save consumes the
+// archive and keeps the backup only once it was read to the end; it sets the
+// backup's Size. List returns the backups newest first.
+type VolumeBackupRepository interface {
+	List(ctx context.Context) ([]*VolumeBackup, error)
+	Get(ctx context.Context, id string) (*VolumeBackup, error)
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	Save(ctx context.Context, b *VolumeBackup, archive io.Reader) error
+	Delete(ctx context.Context, id string) error
+}
+
+type VolumeBackupScheduleRepository interface {
+	List(ctx context.Context) ([]*VolumeBackupSchedule, error)
+	Get(ctx context.Context, id string) (*VolumeBackupSchedule, error)
+	Save(ctx context.Context, s *VolumeBackupSchedule) error
+	Delete(ctx context.Context, id string) error
+}
+
+// VolumeManager changes volumes. Remove fails with an *InUseError when
+// containers, running or not, still use the volume.
+type VolumeManager interface {
+	Create(ctx context.Context, opts VolumeCreateOptions) (*Volume, error)
+	Remove(ctx context.Context, name string, force bool) error
+	Prune(ctx context.Context, filter VolumePruneFilter) (*VolumePruneResult, error)
+}
+
 type SystemInfoProvider interface {
 	GetMemTotal(ctx context.Context) (uint64, error)
 }
@@ -41,3 +84,188 @@ const (
 	ActionPause   = "pause"
 	ActionUnpause = "unpause"
 )
+
+type NotificationChannelRepository interface {
+	List(ctx context.Context) ([]*NotificationChannel, error)
+	Get(ctx context.Context, id string) (*NotificationChannel, error)
+	Save(ctx context.Context, ch *NotificationChannel) error
+	Delete(ctx context.Context, id string) error
+}
+
+type Notifier interface {
+	Send(ctx context.Context, ch *NotificationChannel, n *Notification) error
+}
+
+type ContainerEventSource interface {
+	Events(ctx context.Context) (<-chan ContainerEvent, <-chan error)
+}
+
+type AlertRepository interface {
+	Append(ctx context.Context, a *Alert) error
+	List(ctx context.Context, limit int) ([]*Alert, error)
+}
+
+type SilenceRepository interface {
+	List(ctx context.Context) ([]*Silence, error)
+	Save(ctx context.Context, s *Silence) error
+	Delete(ctx context.Context, id string) error
+}
+
+type MaintenanceWindowRepository interface {
+	List(ctx context.Context) ([]*MaintenanceWindow, error)
+	Get(ctx context.Context, id string) (*MaintenanceWindow, error)
+	Save(ctx context.Context, w *MaintenanceWindow) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ContainerInspector interface {
+	Inspect(ctx context.Context, containerID string) (*ContainerDetails, error)
+}
+
+type LifecycleRepository interface {
+	Record(ctx context.Context, containerID string, ev LifecycleEvent) error
+	History(ctx context.Context, containerID string) ([]LifecycleEvent, error)
+	All(ctx context.Context) (map[string][]LifecycleEvent, error)
+	// Forget drops the history of a removed container.
+	Forget(ctx context.Context, containerID string) error
+}
+
+type AutoHealAuditRepository interface {
+	Append(ctx context.Context, a *AutoHealAction) error
+	List(ctx context.Context, limit int) ([]*AutoHealAction, error)
+}
+
+type ImageManager interface {
+	Pull(ctx context.Context, ref string, cred *RegistryCredential, progress func(LayerProgress)) (*PullResult, error)
+	Remove(ctx context.Context, ref string, force, noPrune bool) (*ImageDeleteResult, error)
+	Tag(ctx context.Context, source, target string) error
+	// Untag removes tag only if it points at imageRef.
+	Untag(ctx context.Context, imageRef, tag string) (*ImageDeleteResult, error)
+}
+
+// ImagePusher pushes a local tag to its registry. Failures are reported as
+// *PushError.
+type ImagePusher interface {
+	Push(ctx context.Context, ref string, cred *RegistryCredential, progress func(LayerProgress)) (*PushResult, error)
+}
+
+// RegistryCredentialProvider returns the stored credential for the registry
+// hosting imageRef, or nil when none is configured.
+type RegistryCredentialProvider interface {
+	CredentialFor(ctx context.Context, imageRef string) (*RegistryCredential, error)
+}
+
+// RegistryCredentialRepository stores credentials by normalized registry
+// host (see NormalizeRegistryHost).
+type RegistryCredentialRepository interface {
+	List(ctx context.Context) ([]*StoredRegistryCredential, error)
+	Get(ctx context.Context, registry string) (*StoredRegistryCredential, error)
+	Save(ctx context.Context, c *StoredRegistryCredential) error
+	Delete(ctx context.Context, registry string) error
+}
+
+type ImageInspector interface {
+	Inspect(ctx context.Context, ref string) (*ImageDetails, error)
+}
+
+// ImageLayerReader lists the files of each filesystem layer of an image,
+// bottom first.
+type ImageLayerReader interface {
+	ReadLayers(ctx context.Context, imageID string) ([]LayerContents, error)
+}
+
+// ImageFileScanner streams the layers of an image once, calling read for each
+// regular file that want selects. The results of files the final image no
+// longer has (overwritten or deleted by a later layer) are dropped; the rest
+// are returned by path.
+type ImageFileScanner interface {
+	ScanFiles(ctx context.Context, imageID string, want func(LayerFile) bool, read func(LayerFile, io.Reader) (any, error)) (map[string]any, error)
+}
+
+// PackageCataloger finds installed packages in an image: it selects the
+// package databases and lockfiles to read during a scan, then builds the
+// inventory from what was read.
+type PackageCataloger interface {
+	Wants(f LayerFile) bool
+	Read(f LayerFile, r io.Reader) (any, error)
+	Catalog(results map[string]any) (*Distro, []Package)
+}
+
+// SBOMEncoder renders an SBOM in one of the SBOMFormat* formats.
+type SBOMEncoder interface {
+	Encode(sbom *SBOM, format string) ([]byte, error)
+}
+
+// ImageBuilder builds an image from a tar build context, calling output for
+// each line of build output.
+type ImageBuilder interface {
+	Build(ctx context.Context, buildContext io.Reader, opts BuildOptions, output func(line string)) (*BuildResult, error)
+}
+
+// ImageArchiver moves images in and out of the daemon as tarballs. All
+// methods stream; nothing is buffered whole.
+type ImageArchiver interface {
+	Export(ctx context.Context, refs []string) (io.ReadCloser, error)
+	Load(ctx context.Context, archive io.Reader, progress func(LayerProgress)) (*LoadResult, error)
+	Import(ctx context.Context, rootfs io.Reader, opts ImportOptions) (string, error)
+}
+
+// ImageLayerIndex returns the filesystem layers of every local image, keyed
+// by image ID, bottom first.
+type ImageLayerIndex interface {
+	ImageLayers(ctx context.Context) (map[string][]LayerRef, error)
+}
+
+// ImagePlatformIndex returns the platform of every local image by ID.
+type ImagePlatformIndex interface {
+	ImagePlatforms(ctx context.Context) (map[string]Platform, error)
+}
+
+// ImageDistributionInspector asks the registry of ref, through the daemon,
+// which manifest the reference resolves to.
+type ImageDistributionInspector interface {
+	DistributionInspect(ctx context.Context, ref string, cred *RegistryCredential) (*ImageDistribution, error)
+}
+
+type DaemonPlatformProvider interface {
+	DaemonPlatform(ctx context.Context) (*Platform, error)
+}
+
+type RetentionPolicyRepository interface {
+	List(ctx context.Context) ([]*RetentionPolicy, error)
+	Get(ctx context.Context, id string) (*RetentionPolicy, error)
+	Save(ctx context.Context, p *RetentionPolicy) error
+	Delete(ctx context.Context, id string) error
+}
+
+type RetentionRunRepository interface {
+	Append(ctx context.Context, r *RetentionRun) error
+	List(ctx context.Context, limit int) ([]*RetentionRun, error)
+}
+
+type RegistryRepository interface {
+	List(ctx context.Context) ([]*Registry, error)
+	Get(ctx context.Context, name string) (*Registry, error)
+	Save(ctx context.Context, r *Registry) error
+	Delete(ctx context.Context, name string) error
+}
+
+// RegistryClient browses a registry through the Registry HTTP API v2. Limit
+// and last page through results; a zero limit uses the registry's default.
+type RegistryClient interface {
+	Repositories(ctx context.Context, reg *Registry, limit int, last string) (*RegistryRepositories, error)
+	Tags(ctx context.Context, reg *Registry, repo string, limit int, last string) (*RegistryTags, error)
+	Manifest(ctx context.Context, reg *Registry, repo, ref string) (*RegistryManifest, error)
+}
+
+// ImagePolicyRepository holds the single image policy; Get returns a policy
+// in ImagePolicyOff mode until one is saved.
+type ImagePolicyRepository interface {
+	Get(ctx context.Context) (*ImagePolicy, error)
+	Save(ctx context.Context, p *ImagePolicy) error
+}
+
+type ImagePolicyDecisionRepository interface {
+	Append(ctx context.Context, d *ImagePolicyDecision) error
+	List(ctx context.Context, limit int) ([]*ImagePolicyDecision, error)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	RetentionTriggerManual   = "manual"
+	RetentionTriggerSchedule = "schedule"
+)
+
+// RetentionPolicy selects image tags by repository pattern and retains a tag
+// when any of its rules does: it is among the KeepLast newest tags of its
+// repository (by image creation date), it matches KeepTagPattern, or its
+// image is younger than MaxAgeDays. Every other tag of a matching
+// repository is deleted. Zero values disable a rule.
+type RetentionPolicy struct {
+	ID string `json:"id"`
+	// Repository is a glob over repository names, e.g. "registry.local/ci/*".
+	Repository     string `json:"repository"`
+	KeepLast       int    `json:"keep_last"`
+	KeepTagPattern string `json:"keep_tag_pattern,omitempty"`
+	MaxAgeDays     int    `json:"max_age_days"`
+	// Schedule is a cron expression; empty means the policy only runs on
+	// demand.
+	Schedule  string    `json:"schedule,omitempty"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (p *RetentionPolicy) MatchesRepository(repo string) bool {
+	ok, _ := path.Match(p.Repository, repo)
+	return ok
+}
+
+// Retains reports whether the policy keeps tag, the rank-th newest tag of
+// its repository (0 is the newest), created at created. The reason is set
+// when the tag is not retained.
+func (p *RetentionPolicy) Retains(tag string, rank int, created, now time.Time) (bool, string) {
+	if p.KeepLast > 0 && rank < p.KeepLast {
+		return true, ""
+	}
+	if p.KeepTagPattern != "" {
+		if re, err := regexp.Compile(p.KeepTagPattern); err == nil && re.MatchString(tag) {
+			return true, ""
+		}
+	}
+	maxAge := time.Duration(p.MaxAgeDays) * 24 * time.Hour
+	if p.MaxAgeDays > 0 && now.Sub(created) < maxAge {
+		return true, ""
+	}
+	var reasons []string
+	if p.KeepLast > 0 {
+		reasons = append(reasons, fmt.Sprintf("not among the newest %d", p.KeepLast))
+	}
+	if p.MaxAgeDays > 0 {
+		reasons = append(reasons, fmt.Sprintf("older than %d days", p.MaxAgeDays))
+	}
+	return false, strings.Join(reasons, ", ")
+}
+
+// SplitImageRef splits "repo:tag" into its repository and tag; a missing tag
+// is "latest".
+func SplitImageRef(ref string) (repo, tag string) {
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, "latest"
+}
+
+type RetentionCandidate struct {
+	PolicyID  string    `json:"policy_id"`
+	Ref       string    `json:"ref"`
+	ImageID   string    `json:"image_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Reason    string    `json:"reason"`
+	// RemovesImage is set when Ref is the image's last tag, so deleting it
+	// deletes the image.
+	RemovesImage bool `json:"removes_image"`
+}
+
+type RetentionPlan struct {
+	Candidates []RetentionCandidate `json:"candidates"`
+	// Protected lists matching tags kept because a container uses the image.
+	Protected  []string `json:"protected"`
+	FreedBytes int64    `json:"freed_bytes"`
+}
+
+type RetentionFailure struct {
+	Ref   string `json:"ref"`
+	Error string `json:"error"`
+}
+
+type RetentionRun struct {
+	ID string `json:"id"`
+	// PolicyID is empty when all enabled policies ran.
+	PolicyID   string             `json:"policy_id,omitempty"`
+	Trigger    string             `json:"trigger"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+	Deleted    []string           `json:"deleted"`
+	Failed     []RetentionFailure `json:"failed,omitempty"`
+	FreedBytes int64              `json:"freed_bytes"`
+	Error      string             `json:"error,omitempty"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+const (
+	PackageTypeDeb    = "deb"
+	PackageTypeAPK    = "apk"
+	PackageTypeRPM    = "rpm"
+	PackageTypeGolang = "golang"
+	PackageTypeNPM    = "npm"
+	PackageTypePyPI   = "pypi"
+)
+
+const (
+	SBOMFormatCycloneDX = "cyclonedx"
+	SBOMFormatSPDX      = "spdx"
+)
+
+// Package is one entry of an image's software inventory.
+type Package struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	Arch    string `json:"arch,omitempty"`
+	// SourceName is the source package an OS package was built from, when
+	// it differs from Name.
+	SourceName string   `json:"source_name,omitempty"`
+	Licenses   []string `json:"licenses,omitempty"`
+	PURL       string   `json:"purl"`
+	// Location is the file the package was found in.
+	Location string `json:"location"`
+}
+
+// Distro identifies the image's OS from /etc/os-release.
+type Distro struct {
+	ID        string `json:"id"`
+	VersionID string `json:"version_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+type SBOM struct {
+	ImageID      string    `json:"image_id"`
+	RepoTags     []string  `json:"repo_tags"`
+	RepoDigests  []string  `json:"repo_digests"`
+	Architecture string    `json:"architecture"`
+	OS           string    `json:"os"`
+	Distro       *Distro   `json:"distro,omitempty"`
+	Packages     []Package `json:"packages"`
+	GeneratedAt  time.Time `json:"generated_at"`
+}
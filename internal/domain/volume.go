@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type Volume struct {
 	Name       string
 	Driver     string
@@ -7,4 +9,57 @@ type Volume struct {
 	Labels     map[string]string
 	Scope      string
 	CreatedAt  string
+	// UsageData comes from the daemon's disk usage report and is nil when
+	// that is unavailable. Containers lists the containers, running or
+	// stopped, that mount the volume.
+	UsageData  *VolumeUsageData
+	Containers []string
+}
+
+// VolumeUsageData mirrors the daemon's: Size is -1 for drivers other than
+// "local", RefCount -1 when unknown.
+type VolumeUsageData struct {
+	Size     int64 `json:"size"`
+	RefCount int64 `json:"ref_count"`
+}
+
+// VolumeOrphanReport lists the volumes no container mounts. Reclaimable
+// counts the volumes whose size is known.
+type VolumeOrphanReport struct {
+	Volumes          []*Volume `json:"volumes"`
+	ReclaimableBytes int64     `json:"reclaimable_bytes"`
+}
+
+type VolumeCreateOptions struct {
+	// Name is generated by the daemon when empty.
+	Name       string            `json:"name"`
+	Driver     string            `json:"driver"`
+	DriverOpts map[string]string `json:"driver_opts"`
+	Labels     map[string]string `json:"labels"`
+}
+
+// VolumePruneFilter selects the unused volumes to prune by label: every
+// Labels entry must match and no ExcludeLabels entry may. Entries are "key"
+// or "key=value".
+type VolumePruneFilter struct {
+	Labels        []string `json:"labels"`
+	ExcludeLabels []string `json:"exclude_labels"`
+}
+
+type VolumePruneResult struct {
+	Deleted        []string `json:"deleted"`
+	SpaceReclaimed uint64   `json:"space_reclaimed"`
+}
+
+// VolumeFileEntry is a file inside a volume. Path is absolute from the
+// volume root; Type is one of the FileType values and, for a directory,
+// Size is the total of the files below it.
+type VolumeFileEntry struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Type       string    `json:"type"`
+	Size       int64     `json:"size"`
+	Mode       string    `json:"mode"`
+	ModTime    time.Time `json:"mod_time"`
+	LinkTarget string    `json:"link_target,omitempty"`
 }
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	VolumeBackupTriggerManual   = "manual"
+	VolumeBackupTriggerSchedule = "schedule"
+
+	volumeBackupSuffix     = ".tar.gz"
+	volumeBackupTimeLayout = "20060102T150405Z"
+)
+
+// VolumeBackup is a gzip-compressed tar of a volume's contents, with paths
+// relative to the volume root. Its ID is the archive's file name, which
+// encodes the volume, creation time and trigger.
+type VolumeBackup struct {
+	ID        string    `json:"id"`
+	Volume    string    `json:"volume"`
+	Trigger   string    `json:"trigger"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VolumeBackupID names the backup of volume taken at t, e.g.
+// "pgdata_20261018T020000Z_schedule.tar.gz".
+func VolumeBackupID(volume string, t time.Time, trigger string) string {
+	return volume + "_" + t.UTC().Format(volumeBackupTimeLayout) + "_" + trigger + volumeBackupSuffix
+}
+
+// ParseVolumeBackupID reverses VolumeBackupID; Size is left unset.
+func ParseVolumeBackupID(id string) (*VolumeBackup, error) {
+	rest, ok := strings.CutSuffix(id, volumeBackupSuffix)
+	parts := strings.Split(rest, "_")
+	if !ok || len(parts) < 3 || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid volume backup id %q: %w", id, ErrInvalidArgument)
+	}
+	n := len(parts)
+	t, err := time.Parse(volumeBackupTimeLayout, parts[n-2])
+	trigger := parts[n-1]
+	if err != nil || (trigger != VolumeBackupTriggerManual && trigger != VolumeBackupTriggerSchedule) {
+		return nil, fmt.Errorf("invalid volume backup id %q: %w", id, ErrInvalidArgument)
+	}
+	return &VolumeBackup{ID: id, Volume: strings.Join(parts[:n-2], "_"), Trigger: trigger, CreatedAt: t}, nil
+}
+
+// VolumeBackupSchedule backs a volume up on a cron schedule and then prunes
+// its older scheduled backups: it keeps the newest backup of each of the
+// last KeepDaily days and of each of the last KeepWeekly ISO weeks that
+// have one. With both at zero nothing is pruned. Manual backups are never
+// pruned.
+type VolumeBackupSchedule struct {
+	ID       string `json:"id"`
+	Volume   string `json:"volume"`
+	Schedule string `json:"schedule"`
+	// StopContainers stops the running containers that mount the volume
+	// while it is archived, and starts them again afterwards.
+	StopContainers bool      `json:"stop_containers"`
+	KeepDaily      int       `json:"keep_daily"`
+	KeepWeekly     int       `json:"keep_weekly"`
+	Enabled        bool      `json:"enabled"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Expired returns the scheduled backups of the schedule's volume that its
+// retention no longer keeps.
+func (s *VolumeBackupSchedule) Expired(backups []*VolumeBackup) []*VolumeBackup {
+	if s.KeepDaily <= 0 && s.KeepWeekly <= 0 {
+		return nil
+	}
+	var own []*VolumeBackup
+	for _, b := range backups {
+		if b.Volume == s.Volume && b.Trigger == VolumeBackupTriggerSchedule {
+			own = append(own, b)
+		}
+	}
+	sort.Slice(own, func(i, j int) bool { return own[i].CreatedAt.After(own[j].CreatedAt) })
+
+	keep := make(map[string]bool)
+	bucketed := func(n int, bucket func(time.Time) string) {
+		seen := make(map[string]bool)
+		for _, b := range own {
+			k := bucket(b.CreatedAt.UTC())
+			if seen[k] {
+				continue
+			}
+			if len(seen) == n {
+				return
+			}
+			seen[k] = true
+			keep[b.ID] = true
+		}
+	}
+	bucketed(s.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") })
+	bucketed(s.KeepWeekly, func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	})
+
+	var out []*VolumeBackup
+	for _, b := range own {
+		if !keep[b.ID] {
+			out = append(out, b)
+		}
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVolumeBackupID_RoundTrip(t *testing.T) {
+	at := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+	id := VolumeBackupID("app_data.v2", at, VolumeBackupTriggerSchedule)
+	if id != "app_data.v2_20261018T020000Z_schedule.tar.gz" {
+		t.Fatalf("id = %q", id)
+	}
+	b, err := ParseVolumeBackupID(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Volume != "app_data.v2" || b.Trigger != VolumeBackupTriggerSchedule || !b.CreatedAt.Equal(at) {
+		t.Errorf("parsed = %+v", b)
+	}
+	for _, bad := range []string{"x.tar.gz", "a_20261018T020000Z_other.tar.gz", "a_yesterday_manual.tar.gz", "../a_20261018T020000Z_manual.tar.gz", "a_20261018T020000Z_manual.tar"} {
+		if _, err := ParseVolumeBackupID(bad); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("%q: expected ErrInvalidArgument, got %v", bad, err)
+		}
+	}
+}
+
+func TestVolumeBackupSchedule_Expired(t *testing.T) {
+	var backups []*VolumeBackup
+	add := func(volume string, at time.Time, trigger string) {
+		backups = append(backups, &VolumeBackup{ID: VolumeBackupID(volume, at, trigger), Volume: volume, Trigger: trigger, CreatedAt: at})
+	}
+	// Daily at 02:00 from Sunday March 1st (ISO week 9) to Friday March 20th
+	// (week 12), plus a second backup on the 20th.
+	for day := 1; day <= 20; day++ {
+		add("pgdata", time.Date(2026, 3, day, 2, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule)
+	}
+	add("pgdata", time.Date(2026, 3, 20, 14, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule)
+	add("pgdata", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), VolumeBackupTriggerManual)
+	add("other", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule)
+
+	s := &VolumeBackupSchedule{Volume: "pgdata", KeepDaily: 3, KeepWeekly: 2}
+	expired := s.Expired(backups)
+	kept := map[string]bool{}
+	for _, b := range backups {
+		kept[b.ID] = true
+	}
+	for _, b := range expired {
+		delete(kept, b.ID)
+	}
+	// The 20th (14:00), 19th and 18th by day; the 20th again and Sunday the
+	// 15th, the newest of week 11, by week.
+	want := []string{
+		VolumeBackupID("pgdata", time.Date(2026, 3, 20, 14, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule),
+		VolumeBackupID("pgdata", time.Date(2026, 3, 19, 2, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule),
+		VolumeBackupID("pgdata", time.Date(2026, 3, 18, 2, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule),
+		VolumeBackupID("pgdata", time.Date(2026, 3, 15, 2, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule),
+		VolumeBackupID("pgdata", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), VolumeBackupTriggerManual),
+		VolumeBackupID("other", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), VolumeBackupTriggerSchedule),
+	}
+	for _, id := range want {
+		if !kept[id] {
+			t.Errorf("%s was expired", id)
+		}
+	}
+	if len(kept) != len(want) {
+		t.Errorf("kept %d backups, want %d: %v", len(kept), len(want), kept)
+	}
+
+	if got := (&VolumeBackupSchedule{Volume: "pgdata"}).Expired(backups); len(got) != 0 {
+		t.Errorf("without retention expired %d backups", len(got))
+	}
+}
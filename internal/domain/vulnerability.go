@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Vulnerability severities, from CVSS ratings or the advisory's own label.
+const (
+	VulnSeverityCritical = "critical"
+	VulnSeverityHigh     = "high"
+	VulnSeverityMedium   = "medium"
+	VulnSeverityLow      = "low"
+	VulnSeverityUnknown  = "unknown"
+)
+
+// VulnSeverityRank orders severities from critical (0) to unknown.
+func VulnSeverityRank(severity string) int {
+	switch severity {
+	case VulnSeverityCritical:
+		return 0
+	case VulnSeverityHigh:
+		return 1
+	case VulnSeverityMedium:
+		return 2
+	case VulnSeverityLow:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// VulnerabilityFinding is an advisory affecting one installed package.
+type VulnerabilityFinding struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Severity string   `json:"severity"`
+	// Score is the CVSS v3 base score, when the advisory has a vector.
+	Score       float64 `json:"score,omitempty"`
+	Package     string  `json:"package"`
+	Version     string  `json:"version"`
+	PackageType string  `json:"package_type"`
+	PURL        string  `json:"purl"`
+	Location    string  `json:"location"`
+	// FixedVersion is the first version the advisory lists as fixed, if any.
+	FixedVersion string `json:"fixed_version,omitempty"`
+}
+
+type SeverityCounts struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Medium   int `json:"medium"`
+	Low      int `json:"low"`
+	Unknown  int `json:"unknown"`
+}
+
+func (c *SeverityCounts) Add(severity string) {
+	switch severity {
+	case VulnSeverityCritical:
+		c.Critical++
+	case VulnSeverityHigh:
+		c.High++
+	case VulnSeverityMedium:
+		c.Medium++
+	case VulnSeverityLow:
+		c.Low++
+	default:
+		c.Unknown++
+	}
+}
+
+func (c SeverityCounts) Total() int {
+	return c.Critical + c.High + c.Medium + c.Low + c.Unknown
+}
+
+type VulnerabilityDBStatus struct {
+	Dir        string    `json:"dir"`
+	Files      int       `json:"files"`
+	Advisories int       `json:"advisories"`
+	LoadedAt   time.Time `json:"loaded_at"`
+	// Errors lists files that could not be read; they are skipped.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// VulnerabilityDatabase matches package inventories against a local set of
+// advisories, which Reload rereads from disk.
+type VulnerabilityDatabase interface {
+	Match(distro *Distro, pkgs []Package) []VulnerabilityFinding
+	Reload(ctx context.Context) (*VulnerabilityDBStatus, error)
+	Status() *VulnerabilityDBStatus
+}
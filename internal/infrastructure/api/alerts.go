@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+func (s *Server) handleListAlerts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	list, err := s.uc.ListAlerts.Execute(ctx, limit)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list alerts")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleListSilences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListSilences.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list silences")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body domain.Silence
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.CreateSilence.Execute(ctx, body)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to create silence")
+		return
+	}
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (s *Server) handleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteSilence.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete silence")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+func (s *Server) handleListMaintenanceWindows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListMaintenanceWindows.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list maintenance windows")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	s.saveMaintenanceWindow(w, r, "", http.StatusCreated)
+}
+
+func (s *Server) handleUpdateMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	s.saveMaintenanceWindow(w, r, r.PathValue("id"), http.StatusOK)
+}
+
+func (s *Server) saveMaintenanceWindow(w http.ResponseWriter, r *http.Request, id string, status int) {
+	ctx := r.Context()
+	var body domain.MaintenanceWindow
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.SaveMaintenanceWindow.Execute(ctx, usecase.SaveMaintenanceWindowInput{ID: id, Window: body})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save maintenance window")
+		return
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) handleDeleteMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteMaintenanceWindow.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete maintenance window")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+const maxBuildContextBytes = 2 << 30
+
+type buildStreamMessage struct {
+	Type   string           `json:"type"`
+	Line   string           `json:"line,omitempty"`
+	Result *domain.BuildJob `json:"result,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
+
+// handleBuildImage accepts the build context either as a tar body
+// (optionally gzip/bzip2/xz compressed) or as multipart/form-data where each
+// file field's name is its path inside the context, e.g.
+// -F Dockerfile=@Dockerfile -F src/app.go=@app.go. Options come from the
+// query string: tag (repeatable), buildarg=KEY=VALUE (repeatable), target,
+// dockerfile, nocache and pull. The body is spooled to disk so the build can
+// outlive the request; it answers 202 with the job.
+func (s *Server) handleBuildImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	opts := domain.BuildOptions{
+		Tags:       q["tag"],
+		Target:     q.Get("target"),
+		Dockerfile: q.Get("dockerfile"),
+		NoCache:    queryBool(r, "nocache"),
+		Pull:       queryBool(r, "pull"),
+	}
+	for _, kv := range q["buildarg"] {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid buildarg %q, expected KEY=VALUE", kv))
+			return
+		}
+		if opts.BuildArgs == nil {
+			opts.BuildArgs = make(map[string]string)
+		}
+		opts.BuildArgs[k] = v
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBuildContextBytes)
+	var (
+		buildContext *spooledFile
+		err          error
+	)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		buildContext, err = spoolMultipartContext(r)
+	} else {
+		buildContext, err = spool(r.Body)
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "build context too large")
+		case errors.Is(err, usecase.ErrInvalidInput):
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+		default:
+			s.log.ErrorContext(ctx, "build context upload failed", "error", err)
+			writeJSONError(w, http.StatusBadRequest, "failed to read build context")
+		}
+		return
+	}
+
+	job, err := s.uc.BuildImage.Execute(ctx, usecase.BuildImageInput{Context: buildContext, Options: opts})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to start build")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (s *Server) handleListBuilds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobs, err := s.uc.ListBuilds.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list builds")
+		return
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *Server) handleGetBuild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	job, err := s.uc.GetBuild.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get build")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (s *Server) handleCancelBuild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.CancelBuild.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to cancel build")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+// handleBuildStreamWebSocket replays the build output so far and follows it
+// as "output" messages, ending with "done" (the job, with image_id) or
+// "error". Closing the socket does not cancel the build.
+func (s *Server) handleBuildStreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, err := s.uc.GetBuild.Execute(r.Context(), id); err != nil {
+		s.writeUseCaseError(r.Context(), w, err, "failed to get build")
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.WarnContext(r.Context(), "websocket upgrade failed (build)", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	job, err := s.uc.FollowBuild.Execute(ctx, usecase.FollowBuildInput{ID: id, Output: func(line string) {
+		_ = conn.WriteJSON(buildStreamMessage{Type: "output", Line: line})
+	}})
+	switch {
+	case err != nil:
+		if ctx.Err() == nil {
+			_ = conn.WriteJSON(buildStreamMessage{Type: "error", Error: err.Error()})
+		}
+	case job.Status == domain.BuildSucceeded:
+		_ = conn.WriteJSON(buildStreamMessage{Type: "done", Result: job})
+	default:
+		_ = conn.WriteJSON(buildStreamMessage{Type: "error", Result: job, Error: job.Error})
+	}
+}
+
+// spooledFile is a temporary file removed on Close.
+type spooledFile struct{ *os.File }
+
+func (f *spooledFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
+
+func spool(r io.Reader) (*spooledFile, error) {
+	f, err := os.CreateTemp("", "dockscope-build-*.tar")
+	if err != nil {
+		return nil, err
+	}
+	sf := &spooledFile{f}
+	if _, err := io.Copy(f, r); err != nil {
+		sf.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		sf.Close()
+		return nil, err
+	}
+	return sf, nil
+}
+
+func spoolMultipartContext(r *http.Request) (*spooledFile, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	out, err := os.CreateTemp("", "dockscope-build-*.tar")
+	if err != nil {
+		return nil, err
+	}
+	sf := &spooledFile{out}
+	tw := tar.NewWriter(out)
+	fail := func(err error) (*spooledFile, error) {
+		sf.Close()
+		return nil, err
+	}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		name := path.Clean(part.FormName())
+		if part.FileName() == "" || name == "." || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fail(fmt.Errorf("%w: form field %q must be a file with a relative path as its name", usecase.ErrInvalidInput, part.FormName()))
+		}
+		// Tar headers need the size up front.
+		tmp, err := spool(part)
+		if err != nil {
+			return fail(err)
+		}
+		info, err := tmp.Stat()
+		if err == nil {
+			err = tw.WriteHeader(&tar.Header{Name: name, Mode: multipartFileMode(tmp), Size: info.Size(), Typeflag: tar.TypeReg})
+		}
+		if err == nil {
+			_, err = io.Copy(tw, tmp)
+		}
+		tmp.Close()
+		if err != nil {
+			return fail(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fail(err)
+	}
+	if _, err := out.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+	return sf, nil
+}
+
+// multipartFileMode picks the mode for an uploaded file, since multipart
+// carries none: scripts starting with "#!" are made executable so
+// entrypoints keep working, everything else is 0644.
+func multipartFileMode(f *spooledFile) int64 {
+	var magic [2]byte
+	n, _ := f.ReadAt(magic[:], 0)
+	if n == 2 && string(magic[:]) == "#!" {
+		return 0o755
+	}
+	return 0o644
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) handleContainerLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.GetContainerLifecycle.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get container lifecycle")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleContainerHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	out, err := s.uc.GetContainerHealth.Execute(ctx, r.PathValue("id"), limit)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get container health")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleListAutoHealActions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	list, err := s.uc.ListAutoHealActions.Execute(ctx, limit)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list auto-heal actions")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
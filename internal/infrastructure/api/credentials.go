@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+const maxDockerConfigBytes = 1 << 20
+
+func (s *Server) handleListRegistryCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListRegistryCredentials.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list registry credentials")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleSaveRegistryCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body domain.RegistryCredential
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.SaveRegistryCredential.Execute(ctx, body)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save registry credential")
+		return
+	}
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (s *Server) handleDeleteRegistryCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteRegistryCredential.Execute(ctx, r.PathValue("registry")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete registry credential")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+// handleImportRegistryCredentials imports a docker config.json sent as the
+// body, or the server's own when the body is empty. ?overwrite=true replaces
+// credentials already stored.
+func (s *Server) handleImportRegistryCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	config, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDockerConfigBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSONError(w, http.StatusRequestEntityTooLarge, "docker config too large")
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+	out, err := s.uc.ImportRegistryCredentials.Execute(ctx, usecase.ImportRegistryCredentialsInput{
+		Config:    config,
+		Overwrite: queryBool(r, "overwrite"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to import registry credentials")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+// writeUseCaseError maps use case errors to HTTP statuses. Unexpected errors
+// are logged and reported with the generic fallback message.
+func (s *Server) writeUseCaseError(ctx context.Context, w http.ResponseWriter, err error, fallback string) {
+	var inUse *domain.InUseError
+	var pushErr *domain.PushError
+	var policyErr *domain.ImagePolicyError
+	switch {
+	case errors.As(err, &inUse):
+		writeJSON(w, http.StatusConflict, map[string]any{"error": inUse.Error(), "containers": inUse.Containers})
+	case errors.As(err, &pushErr):
+		writeJSON(w, pushErrorStatus(pushErr.Code), map[string]any{"error": pushErr.Error(), "code": pushErr.Code})
+	case errors.As(err, &policyErr):
+		writeJSON(w, http.StatusForbidden, map[string]any{"error": policyErr.Error(), "image": policyErr.Image, "violations": policyErr.Violations})
+	case errors.Is(err, usecase.ErrInvalidInput), errors.Is(err, domain.ErrInvalidArgument):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		writeJSONError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrForbidden):
+		writeJSONError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrRateLimited):
+		writeJSONError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, domain.ErrUnauthorized):
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, domain.ErrUnavailable):
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+	default:
+		s.log.ErrorContext(ctx, "api request failed", "error", err)
+		writeJSONError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// isUseCaseError reports whether writeUseCaseError has a specific status for
+// err, i.e. err is one of the typed domain errors or wraps a sentinel.
+func isUseCaseError(err error) bool {
+	var inUse *domain.InUseError
+	var pushErr *domain.PushError
+	var policyErr *domain.ImagePolicyError
+	if errors.As(err, &inUse) || errors.As(err, &pushErr) || errors.As(err, &policyErr) {
+		return true
+	}
+	for _, sentinel := range []error{
+		usecase.ErrInvalidInput, domain.ErrInvalidArgument, domain.ErrNotFound, domain.ErrConflict,
+		domain.ErrForbidden, domain.ErrRateLimited, domain.ErrUnauthorized, domain.ErrUnavailable,
+	} {
+		if errors.Is(err, sentinel) {
+			return true
+		}
+	}
+	return false
+}
+
+func pushErrorStatus(code string) int {
+	switch code {
+	case domain.PushErrorAuthDenied:
+		return http.StatusUnauthorized
+	case domain.PushErrorNotFound:
+		return http.StatusNotFound
+	case domain.PushErrorUnknownBlob, domain.PushErrorUnreachable:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (s *Server) handleGetImagePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	p, err := s.uc.GetImagePolicy.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get image policy")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleSaveImagePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body domain.ImagePolicy
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	p, err := s.uc.SaveImagePolicy.Execute(ctx, body)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save image policy")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+// handleEvaluateImagePolicy is the dry run: {"image": "nginx:latest"} gets
+// the violations the policy would report, whatever its mode.
+func (s *Server) handleEvaluateImagePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		Image string `json:"image"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.EvaluateImagePolicy.Execute(ctx, body.Image)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to evaluate image policy")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleListImagePolicyDecisions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	list, err := s.uc.ListImagePolicyDecisions.Execute(ctx, limit)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list image policy decisions")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
@@ -0,0 +1,332 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+type imageStreamMessage struct {
+	Type     string                `json:"type"`
+	Progress *domain.LayerProgress `json:"progress,omitempty"`
+	Result   any                   `json:"result,omitempty"`
+	Error    string                `json:"error,omitempty"`
+	Code     string                `json:"code,omitempty"`
+}
+
+// handlePullImageWebSocket streams pull progress as JSON messages of type
+// "progress", then a final "done" (with digest and status) or "error".
+func (s *Server) handlePullImageWebSocket(w http.ResponseWriter, r *http.Request) {
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing image reference")
+		return
+	}
+
+	s.streamPull(w, r, ref, func(ctx context.Context, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+		return s.uc.PullImage.Execute(ctx, ref, progress)
+	})
+}
+
+// streamPull runs pull over a WebSocket, cancelling it when the client goes
+// away.
+func (s *Server) streamPull(w http.ResponseWriter, r *http.Request, ref string, pull func(context.Context, func(domain.LayerProgress)) (*domain.PullResult, error)) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.WarnContext(r.Context(), "websocket upgrade failed (pull)", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	res, err := pull(ctx, func(p domain.LayerProgress) {
+		_ = conn.WriteJSON(imageStreamMessage{Type: "progress", Progress: &p})
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.DebugContext(ctx, "image pull ended with error", "ref", ref, "error", err)
+			_ = conn.WriteJSON(imageStreamMessage{Type: "error", Error: err.Error()})
+		}
+		return
+	}
+	_ = conn.WriteJSON(imageStreamMessage{Type: "done", Result: res})
+}
+
+func queryBool(r *http.Request, name string) bool {
+	v := r.URL.Query().Get(name)
+	return v == "1" || v == "true"
+}
+
+// queryFalse reports an explicit opt-out of a parameter that defaults to on.
+func queryFalse(r *http.Request, name string) bool {
+	v := r.URL.Query().Get(name)
+	return v == "0" || v == "false"
+}
+
+func (s *Server) handleRemoveImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.RemoveImage.Execute(ctx, usecase.RemoveImageInput{
+		Ref:     r.PathValue("id"),
+		Force:   queryBool(r, "force"),
+		NoPrune: queryBool(r, "noprune"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to remove image")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type requestBodyTagImage struct {
+	Repo string `json:"repo"`
+	Tag  string `json:"tag"`
+}
+
+func (s *Server) handleTagImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body requestBodyTagImage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	target, err := s.uc.TagImage.Execute(ctx, usecase.TagImageInput{
+		Ref:  r.PathValue("id"),
+		Repo: body.Repo,
+		Tag:  body.Tag,
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to tag image")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"tag": target})
+}
+
+type requestBodyUntagImage struct {
+	Tag string `json:"tag"`
+}
+
+func (s *Server) handleUntagImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body requestBodyUntagImage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.UntagImage.Execute(ctx, r.PathValue("id"), body.Tag)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to untag image")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleImageHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.GetImageHistory.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get image history")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleImagePlatforms answers with the local image's platform and, through
+// distribution inspect, the platforms its reference provides in the
+// registry.
+func (s *Server) handleImagePlatforms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.GetImagePlatforms.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get image platforms")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleImageFiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	layer := -1
+	if v := r.URL.Query().Get("layer"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeJSONError(w, http.StatusBadRequest, "invalid layer")
+			return
+		}
+		layer = n
+	}
+	out, err := s.uc.ExploreImageFiles.Execute(ctx, usecase.ExploreImageFilesInput{
+		Ref:   r.PathValue("id"),
+		Layer: layer,
+		Path:  r.URL.Query().Get("path"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to explore image files")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+var sbomContentTypes = map[string]string{
+	domain.SBOMFormatCycloneDX: "application/vnd.cyclonedx+json",
+	domain.SBOMFormatSPDX:      "application/spdx+json",
+}
+
+// handleImageSBOM answers the image's SBOM as CycloneDX (the default) or,
+// with ?format=spdx, SPDX JSON.
+func (s *Server) handleImageSBOM(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.GenerateImageSBOM.Execute(ctx, usecase.GenerateImageSBOMInput{
+		Ref:    r.PathValue("id"),
+		Format: r.URL.Query().Get("format"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to generate sbom")
+		return
+	}
+	w.Header().Set("Content-Type", sbomContentTypes[out.Format])
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out.Document)
+}
+
+// handleExportImages streams `docker save` of the image plus any extra
+// ?ref= images as one tarball.
+func (s *Server) handleExportImages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	refs := append([]string{r.PathValue("id")}, r.URL.Query()["ref"]...)
+	rc, err := s.uc.ExportImages.Execute(ctx, refs)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to export images")
+		return
+	}
+	defer rc.Close()
+
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(refs[0])
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".tar"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rc); err != nil && ctx.Err() == nil {
+		s.log.WarnContext(ctx, "image export interrupted", "refs", refs, "error", err)
+	}
+}
+
+// handleLoadImages pipes the request body into `docker load` and answers
+// with newline-delimited JSON: "progress" messages while layers load, then
+// "done" (with the loaded images) or "error". The response is written while
+// the upload is still being read, so the connection runs full duplex.
+func (s *Server) handleLoadImages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+	if err := rc.EnableFullDuplex(); err != nil {
+		s.log.DebugContext(ctx, "full duplex not supported", "error", err)
+	}
+	enc := json.NewEncoder(w)
+	started := false
+	start := func() {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+	}
+
+	res, err := s.uc.LoadImages.Execute(ctx, r.Body, func(p domain.LayerProgress) {
+		start()
+		_ = enc.Encode(imageStreamMessage{Type: "progress", Progress: &p})
+		_ = rc.Flush()
+	})
+	if err != nil {
+		if !started {
+			s.writeUseCaseError(ctx, w, err, "failed to load images")
+			return
+		}
+		_ = enc.Encode(imageStreamMessage{Type: "error", Error: err.Error()})
+		return
+	}
+	start()
+	_ = enc.Encode(imageStreamMessage{Type: "done", Result: res})
+}
+
+// handlePushImage pushes {id}, a "repo:tag" reference with its slashes
+// URL-encoded, and answers with newline-delimited JSON: "progress" messages
+// per layer while uploading, then "done" (with the digest) or "error" (with a
+// code from domain.PushError). Failures before the first progress message get
+// a plain error status instead.
+func (s *Server) handlePushImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	started := false
+	start := func() {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+	}
+
+	res, err := s.uc.PushImage.Execute(ctx, r.PathValue("id"), func(p domain.LayerProgress) {
+		start()
+		_ = enc.Encode(imageStreamMessage{Type: "progress", Progress: &p})
+		_ = rc.Flush()
+	})
+	if err != nil {
+		if !started {
+			s.writeUseCaseError(ctx, w, err, "failed to push image")
+			return
+		}
+		msg := imageStreamMessage{Type: "error", Error: err.Error(), Code: domain.PushErrorFailed}
+		var pushErr *domain.PushError
+		if errors.As(err, &pushErr) {
+			msg.Code = pushErr.Code
+		}
+		_ = enc.Encode(msg)
+		return
+	}
+	start()
+	_ = enc.Encode(imageStreamMessage{Type: "done", Result: res})
+}
+
+// handleImportImage creates an image from a rootfs tarball body. Options
+// come from the query string: ref, message and change (repeatable).
+func (s *Server) handleImportImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	id, err := s.uc.ImportImage.Execute(ctx, r.Body, domain.ImportOptions{
+		Ref:     q.Get("ref"),
+		Message: q.Get("message"),
+		Changes: q["change"],
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to import image")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]string{"image_id": id})
+}
+
+func (s *Server) handleListUnusedImages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.ListUnusedImages.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list unused images")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
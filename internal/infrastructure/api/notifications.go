@@ -0,0 +1,66 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+func (s *Server) handleListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListNotificationChannels.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list notification channels")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	s.saveNotificationChannel(w, r, "", http.StatusCreated)
+}
+
+func (s *Server) handleUpdateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	s.saveNotificationChannel(w, r, r.PathValue("id"), http.StatusOK)
+}
+
+func (s *Server) saveNotificationChannel(w http.ResponseWriter, r *http.Request, id string, status int) {
+	ctx := r.Context()
+	var body domain.NotificationChannel
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	ch, err := s.uc.SaveNotificationChannel.Execute(ctx, usecase.SaveNotificationChannelInput{ID: id, Channel: body})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save notification channel")
+		return
+	}
+	writeJSON(w, status, ch)
+}
+
+func (s *Server) handleDeleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteNotificationChannel.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete notification channel")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+func (s *Server) handleTestNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := s.uc.TestNotificationChannel.Execute(ctx, r.PathValue("id"))
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrRateLimited) {
+		s.writeUseCaseError(ctx, w, err, "")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, "test notification failed: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
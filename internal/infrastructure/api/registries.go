@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+func (s *Server) handleListRegistries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListRegistries.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list registries")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateRegistry(w http.ResponseWriter, r *http.Request) {
+	s.saveRegistry(w, r, "", http.StatusCreated)
+}
+
+func (s *Server) handleUpdateRegistry(w http.ResponseWriter, r *http.Request) {
+	s.saveRegistry(w, r, r.PathValue("name"), http.StatusOK)
+}
+
+func (s *Server) saveRegistry(w http.ResponseWriter, r *http.Request, name string, status int) {
+	ctx := r.Context()
+	var body domain.Registry
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.SaveRegistry.Execute(ctx, usecase.SaveRegistryInput{Name: name, Registry: body})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save registry")
+		return
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) handleDeleteRegistry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteRegistry.Execute(ctx, r.PathValue("name")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete registry")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+func (s *Server) handleRegistryRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	n, _ := strconv.Atoi(q.Get("n"))
+	out, err := s.uc.ListRegistryRepositories.Execute(ctx, usecase.ListRegistryRepositoriesInput{Registry: r.PathValue("name"), Limit: n, Last: q.Get("last")})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list registry repositories")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleRegistryTags takes the repository as a query parameter since
+// repository names contain slashes.
+func (s *Server) handleRegistryTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+	n, _ := strconv.Atoi(q.Get("n"))
+	out, err := s.uc.ListRegistryTags.Execute(ctx, usecase.ListRegistryTagsInput{
+		Registry:   r.PathValue("name"),
+		Repository: q.Get("repository"),
+		Limit:      n,
+		Last:       q.Get("last"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list registry tags")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleRegistryManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	out, err := s.uc.GetRegistryManifest.Execute(ctx, usecase.GetRegistryManifestInput{
+		Registry:   r.PathValue("name"),
+		Repository: r.URL.Query().Get("repository"),
+		Reference:  r.PathValue("ref"),
+	})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to get registry manifest")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// handleRegistryPullWebSocket pulls ?repository=&ref= from the registry with
+// its credentials, streaming progress like /api/images/pull.
+func (s *Server) handleRegistryPullWebSocket(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	input := usecase.PullRegistryImageInput{
+		Registry:   r.PathValue("name"),
+		Repository: q.Get("repository"),
+		Reference:  q.Get("ref"),
+	}
+	if input.Repository == "" {
+		writeJSONError(w, http.StatusBadRequest, "missing repository")
+		return
+	}
+	s.streamPull(w, r, input.Repository, func(ctx context.Context, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+		return s.uc.PullRegistryImage.Execute(ctx, input, progress)
+	})
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+func (s *Server) handleListRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListRetentionPolicies.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list retention policies")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	s.saveRetentionPolicy(w, r, "", http.StatusCreated)
+}
+
+func (s *Server) handleUpdateRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	s.saveRetentionPolicy(w, r, r.PathValue("id"), http.StatusOK)
+}
+
+func (s *Server) saveRetentionPolicy(w http.ResponseWriter, r *http.Request, id string, status int) {
+	ctx := r.Context()
+	var body domain.RetentionPolicy
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.SaveRetentionPolicy.Execute(ctx, usecase.SaveRetentionPolicyInput{ID: id, Policy: body})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save retention policy")
+		return
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) handleDeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteRetentionPolicy.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete retention policy")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+// handlePreviewRetention is the dry run: it lists what ?policy= (or every
+// enabled policy) would delete without deleting anything.
+func (s *Server) handlePreviewRetention(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	plan, err := s.uc.PreviewRetention.Execute(ctx, r.URL.Query().Get("policy"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to preview retention")
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
+
+func (s *Server) handleRunRetention(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	run, err := s.uc.RunRetention.Execute(ctx, r.URL.Query().Get("policy"), domain.RetentionTriggerManual)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to run retention")
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+func (s *Server) handleListRetentionRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	list, err := s.uc.ListRetentionRuns.Execute(ctx, limit)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list retention runs")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
@@ -17,55 +17,195 @@ var upgrader = websocket.Upgrader{
 	WriteBufferSize: 1024,
 }
 
+type UseCases struct {
+	ListContainers             *usecase.ListContainers
+	ListImages                 *usecase.ListImages
+	ListUnusedImages           *usecase.ListUnusedImages
+	ListVolumes                *usecase.ListVolumes
+	ListOrphanVolumes          *usecase.ListOrphanVolumes
+	CreateVolume               *usecase.CreateVolume
+	RemoveVolume               *usecase.RemoveVolume
+	PruneVolumes               *usecase.PruneVolumes
+	BrowseVolumeFiles          *usecase.BrowseVolumeFiles
+	DownloadVolumeFiles        *usecase.DownloadVolumeFiles
+	UploadVolumeFiles          *usecase.UploadVolumeFiles
+	CloneVolume                *usecase.CloneVolume
+	BackupVolume               *usecase.BackupVolume
+	StreamVolumeBackup         *usecase.StreamVolumeBackup
+	RestoreVolume              *usecase.RestoreVolume
+	ListVolumeBackups          *usecase.ListVolumeBackups
+	OpenVolumeBackup           *usecase.OpenVolumeBackup
+	DeleteVolumeBackup         *usecase.DeleteVolumeBackup
+	ListVolumeBackupSchedules  *usecase.ListVolumeBackupSchedules
+	SaveVolumeBackupSchedule   *usecase.SaveVolumeBackupSchedule
+	DeleteVolumeBackupSchedule *usecase.DeleteVolumeBackupSchedule
+	RunVolumeBackupSchedules   *usecase.RunVolumeBackupSchedules
+	GetSystemSummary           *usecase.GetSystemSummary
+	StreamContainerStats       *usecase.StreamContainerStats
+	StreamContainerLogs        *usecase.StreamContainerLogs
+	ExecuteContainerAction     *usecase.ExecuteContainerAction
+	GetImagePolicy             *usecase.GetImagePolicy
+	SaveImagePolicy            *usecase.SaveImagePolicy
+	EvaluateImagePolicy        *usecase.EvaluateImagePolicy
+	ListImagePolicyDecisions   *usecase.ListImagePolicyDecisions
+	ListNotificationChannels   *usecase.ListNotificationChannels
+	SaveNotificationChannel    *usecase.SaveNotificationChannel
+	DeleteNotificationChannel  *usecase.DeleteNotificationChannel
+	TestNotificationChannel    *usecase.TestNotificationChannel
+	ListAlerts                 *usecase.ListAlerts
+	ListSilences               *usecase.ListSilences
+	CreateSilence              *usecase.CreateSilence
+	DeleteSilence              *usecase.DeleteSilence
+	ListMaintenanceWindows     *usecase.ListMaintenanceWindows
+	SaveMaintenanceWindow      *usecase.SaveMaintenanceWindow
+	DeleteMaintenanceWindow    *usecase.DeleteMaintenanceWindow
+	GetContainerLifecycle      *usecase.GetContainerLifecycle
+	GetContainerHealth         *usecase.GetContainerHealth
+	ListAutoHealActions        *usecase.ListAutoHealActions
+	PullImage                  *usecase.PullImage
+	PushImage                  *usecase.PushImage
+	RemoveImage                *usecase.RemoveImage
+	TagImage                   *usecase.TagImage
+	UntagImage                 *usecase.UntagImage
+	GetImageHistory            *usecase.GetImageHistory
+	GetImagePlatforms          *usecase.GetImagePlatforms
+	ExploreImageFiles          *usecase.ExploreImageFiles
+	GenerateImageSBOM          *usecase.GenerateImageSBOM
+	ScanImageVulnerabilities   *usecase.ScanImageVulnerabilities
+	ListVulnerableContainers   *usecase.ListVulnerableContainers
+	GetVulnerabilityDBStatus   *usecase.GetVulnerabilityDBStatus
+	ReloadVulnerabilityDB      *usecase.ReloadVulnerabilityDB
+	BuildImage                 *usecase.BuildImage
+	ListBuilds                 *usecase.ListBuilds
+	GetBuild                   *usecase.GetBuild
+	CancelBuild                *usecase.CancelBuild
+	FollowBuild                *usecase.FollowBuild
+	ExportImages               *usecase.ExportImages
+	LoadImages                 *usecase.LoadImages
+	ImportImage                *usecase.ImportImage
+	ListRetentionPolicies      *usecase.ListRetentionPolicies
+	SaveRetentionPolicy        *usecase.SaveRetentionPolicy
+	DeleteRetentionPolicy      *usecase.DeleteRetentionPolicy
+	PreviewRetention           *usecase.PreviewRetention
+	RunRetention               *usecase.RunRetention
+	ListRetentionRuns          *usecase.ListRetentionRuns
+	ListRegistries             *usecase.ListRegistries
+	SaveRegistry               *usecase.SaveRegistry
+	DeleteRegistry             *usecase.DeleteRegistry
+	ListRegistryRepositories   *usecase.ListRegistryRepositories
+	ListRegistryTags           *usecase.ListRegistryTags
+	GetRegistryManifest        *usecase.GetRegistryManifest
+	PullRegistryImage          *usecase.PullRegistryImage
+	ListRegistryCredentials    *usecase.ListRegistryCredentials
+	SaveRegistryCredential     *usecase.SaveRegistryCredential
+	DeleteRegistryCredential   *usecase.DeleteRegistryCredential
+	ImportRegistryCredentials  *usecase.ImportRegistryCredentials
+}
+
 type Server struct {
-	listContainers        *usecase.ListContainers
-	listImages            *usecase.ListImages
-	listVolumes           *usecase.ListVolumes
-	getSystemSummary      *usecase.GetSystemSummary
-	streamContainerStats  *usecase.StreamContainerStats
-	streamContainerLogs   *usecase.StreamContainerLogs
-	executeContainerAction *usecase.ExecuteContainerAction
-	log                   *slog.Logger
+	uc  UseCases
+	log *slog.Logger
 }
 
-func NewServer(
-	listContainers *usecase.ListContainers,
-	listImages *usecase.ListImages,
-	listVolumes *usecase.ListVolumes,
-	getSystemSummary *usecase.GetSystemSummary,
-	streamContainerStats *usecase.StreamContainerStats,
-	streamContainerLogs *usecase.StreamContainerLogs,
-	executeContainerAction *usecase.ExecuteContainerAction,
-	log *slog.Logger,
-) *Server {
-	return &Server{
-		listContainers:        listContainers,
-		listImages:            listImages,
-		listVolumes:           listVolumes,
-		getSystemSummary:      getSystemSummary,
-		streamContainerStats:  streamContainerStats,
-		streamContainerLogs:   streamContainerLogs,
-		executeContainerAction: executeContainerAction,
-		log:                   log,
-	}
+func NewServer(uc UseCases, log *slog.Logger) *Server {
+	return &Server{uc: uc, log: log}
 }
 
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /api/containers", s.handleListContainers)
 	mux.HandleFunc("POST /api/containers/", s.handleContainerAction)
+	mux.HandleFunc("GET /api/containers/{id}/lifecycle", s.handleContainerLifecycle)
+	mux.HandleFunc("GET /api/containers/{id}/health", s.handleContainerHealth)
 	mux.HandleFunc("GET /api/system/summary", s.handleSystemSummary)
 	mux.HandleFunc("GET /api/images", s.handleListImages)
+	mux.HandleFunc("GET /api/images/unused", s.handleListUnusedImages)
+	mux.HandleFunc("GET /api/images/pull", s.handlePullImageWebSocket)
+	mux.HandleFunc("POST /api/images/build", s.handleBuildImage)
+	mux.HandleFunc("POST /api/images/load", s.handleLoadImages)
+	mux.HandleFunc("POST /api/images/import", s.handleImportImage)
+	mux.HandleFunc("GET /api/images/{id}/export", s.handleExportImages)
+	mux.HandleFunc("GET /api/builds", s.handleListBuilds)
+	mux.HandleFunc("GET /api/builds/{id}", s.handleGetBuild)
+	mux.HandleFunc("DELETE /api/builds/{id}", s.handleCancelBuild)
+	mux.HandleFunc("GET /api/builds/{id}/stream", s.handleBuildStreamWebSocket)
+	mux.HandleFunc("DELETE /api/images/{id}", s.handleRemoveImage)
+	mux.HandleFunc("GET /api/images/{id}/history", s.handleImageHistory)
+	mux.HandleFunc("GET /api/images/{id}/platforms", s.handleImagePlatforms)
+	mux.HandleFunc("GET /api/images/{id}/files", s.handleImageFiles)
+	mux.HandleFunc("GET /api/images/{id}/sbom", s.handleImageSBOM)
+	mux.HandleFunc("GET /api/images/{id}/vulnerabilities", s.handleImageVulnerabilities)
+	mux.HandleFunc("POST /api/images/{id}/tag", s.handleTagImage)
+	mux.HandleFunc("POST /api/images/{id}/untag", s.handleUntagImage)
+	mux.HandleFunc("POST /api/images/{id}/push", s.handlePushImage)
+	mux.HandleFunc("GET /api/retention/policies", s.handleListRetentionPolicies)
+	mux.HandleFunc("POST /api/retention/policies", s.handleCreateRetentionPolicy)
+	mux.HandleFunc("PUT /api/retention/policies/{id}", s.handleUpdateRetentionPolicy)
+	mux.HandleFunc("DELETE /api/retention/policies/{id}", s.handleDeleteRetentionPolicy)
+	mux.HandleFunc("GET /api/retention/preview", s.handlePreviewRetention)
+	mux.HandleFunc("POST /api/retention/run", s.handleRunRetention)
+	mux.HandleFunc("GET /api/retention/runs", s.handleListRetentionRuns)
+	mux.HandleFunc("GET /api/registries", s.handleListRegistries)
+	mux.HandleFunc("POST /api/registries", s.handleCreateRegistry)
+	mux.HandleFunc("PUT /api/registries/{name}", s.handleUpdateRegistry)
+	mux.HandleFunc("DELETE /api/registries/{name}", s.handleDeleteRegistry)
+	mux.HandleFunc("GET /api/registries/{name}/repositories", s.handleRegistryRepositories)
+	mux.HandleFunc("GET /api/registries/{name}/tags", s.handleRegistryTags)
+	mux.HandleFunc("GET /api/registries/{name}/manifests/{ref}", s.handleRegistryManifest)
+	mux.HandleFunc("GET /api/registries/{name}/pull", s.handleRegistryPullWebSocket)
+	mux.HandleFunc("GET /api/registry-credentials", s.handleListRegistryCredentials)
+	mux.HandleFunc("POST /api/registry-credentials", s.handleSaveRegistryCredential)
+	mux.HandleFunc("POST /api/registry-credentials/import", s.handleImportRegistryCredentials)
+	mux.HandleFunc("DELETE /api/registry-credentials/{registry}", s.handleDeleteRegistryCredential)
+	mux.HandleFunc("GET /api/vulnerabilities/containers", s.handleVulnerableContainers)
+	mux.HandleFunc("GET /api/vulnerabilities/db", s.handleVulnerabilityDBStatus)
+	mux.HandleFunc("POST /api/vulnerabilities/db/reload", s.handleReloadVulnerabilityDB)
+	mux.HandleFunc("GET /api/image-policy", s.handleGetImagePolicy)
+	mux.HandleFunc("PUT /api/image-policy", s.handleSaveImagePolicy)
+	mux.HandleFunc("POST /api/image-policy/evaluate", s.handleEvaluateImagePolicy)
+	mux.HandleFunc("GET /api/image-policy/decisions", s.handleListImagePolicyDecisions)
 	mux.HandleFunc("GET /api/volumes", s.handleListVolumes)
+	mux.HandleFunc("POST /api/volumes", s.handleCreateVolume)
+	mux.HandleFunc("GET /api/volumes/orphans", s.handleOrphanVolumes)
+	mux.HandleFunc("POST /api/volumes/prune", s.handlePruneVolumes)
+	mux.HandleFunc("DELETE /api/volumes/{name}", s.handleRemoveVolume)
+	mux.HandleFunc("GET /api/volumes/{name}/files", s.handleVolumeFiles)
+	mux.HandleFunc("POST /api/volumes/{name}/files", s.handleUploadVolumeFiles)
+	mux.HandleFunc("POST /api/volumes/{name}/clone", s.handleCloneVolume)
+	mux.HandleFunc("GET /api/volumes/{name}/backup", s.handleDownloadVolumeBackup)
+	mux.HandleFunc("POST /api/volumes/{name}/backups", s.handleCreateVolumeBackup)
+	mux.HandleFunc("POST /api/volumes/{name}/restore", s.handleRestoreVolume)
+	mux.HandleFunc("GET /api/volume-backups", s.handleListVolumeBackups)
+	mux.HandleFunc("GET /api/volume-backups/{id}", s.handleGetVolumeBackup)
+	mux.HandleFunc("DELETE /api/volume-backups/{id}", s.handleDeleteVolumeBackup)
+	mux.HandleFunc("GET /api/volume-backup-schedules", s.handleListVolumeBackupSchedules)
+	mux.HandleFunc("POST /api/volume-backup-schedules", s.handleCreateVolumeBackupSchedule)
+	mux.HandleFunc("PUT /api/volume-backup-schedules/{id}", s.handleUpdateVolumeBackupSchedule)
+	mux.HandleFunc("DELETE /api/volume-backup-schedules/{id}", s.handleDeleteVolumeBackupSchedule)
+	mux.HandleFunc("POST /api/volume-backup-schedules/{id}/run", s.handleRunVolumeBackupSchedule)
 	mux.HandleFunc("GET /api/health", s.handleHealth)
 	mux.HandleFunc("GET /api/stats/{id}", s.handleStatsWebSocket)
 	mux.HandleFunc("GET /api/logs/{id}", s.handleLogsWebSocket)
+	mux.HandleFunc("GET /api/notifications/channels", s.handleListNotificationChannels)
+	mux.HandleFunc("POST /api/notifications/channels", s.handleCreateNotificationChannel)
+	mux.HandleFunc("PUT /api/notifications/channels/{id}", s.handleUpdateNotificationChannel)
+	mux.HandleFunc("DELETE /api/notifications/channels/{id}", s.handleDeleteNotificationChannel)
+	mux.HandleFunc("POST /api/notifications/channels/{id}/test", s.handleTestNotificationChannel)
+	mux.HandleFunc("GET /api/alerts", s.handleListAlerts)
+	mux.HandleFunc("GET /api/autoheal/actions", s.handleListAutoHealActions)
+	mux.HandleFunc("GET /api/silences", s.handleListSilences)
+	mux.HandleFunc("POST /api/silences", s.handleCreateSilence)
+	mux.HandleFunc("DELETE /api/silences/{id}", s.handleDeleteSilence)
+	mux.HandleFunc("GET /api/maintenance-windows", s.handleListMaintenanceWindows)
+	mux.HandleFunc("POST /api/maintenance-windows", s.handleCreateMaintenanceWindow)
+	mux.HandleFunc("PUT /api/maintenance-windows/{id}", s.handleUpdateMaintenanceWindow)
+	mux.HandleFunc("DELETE /api/maintenance-windows/{id}", s.handleDeleteMaintenanceWindow)
 	return corsMiddleware(mux, s.log)
 }
 
 func (s *Server) handleSystemSummary(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	out, err := s.getSystemSummary.Execute(ctx)
+	out, err := s.uc.GetSystemSummary.Execute(ctx)
 	if err != nil {
 		s.log.ErrorContext(ctx, "api system summary failed", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to get system summary")
@@ -77,7 +217,7 @@ func (s *Server) handleSystemSummary(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleListContainers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	all := r.URL.Query().Get("all") == "1" || r.URL.Query().Get("all") == "true"
-	list, err := s.listContainers.Execute(ctx, usecase.ListContainersInput{All: all})
+	list, err := s.uc.ListContainers.Execute(ctx, usecase.ListContainersInput{All: all})
 	if err != nil {
 		s.log.ErrorContext(ctx, "api list containers failed", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to list containers")
@@ -112,11 +252,17 @@ func (s *Server) handleContainerAction(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
 		return
 	}
-	err := s.executeContainerAction.Execute(ctx, usecase.ExecuteContainerActionInput{
+	err := s.uc.ExecuteContainerAction.Execute(ctx, usecase.ExecuteContainerActionInput{
 		ContainerID: containerID,
 		Action:      body.Action,
 	})
 	if err != nil {
+		// Errors from the image policy check (missing container, policy
+		// violation) carry domain errors; the daemon's do not.
+		if isUseCaseError(err) {
+			s.writeUseCaseError(ctx, w, err, "failed to execute container action")
+			return
+		}
 		msg := err.Error()
 		if msg == "invalid action: must be one of start, stop, restart, pause, unpause" || msg == "missing container id" {
 			writeJSONError(w, http.StatusBadRequest, msg)
@@ -135,7 +281,7 @@ func (s *Server) handleContainerAction(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleListImages(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	list, err := s.listImages.Execute(ctx)
+	list, err := s.uc.ListImages.Execute(ctx)
 	if err != nil {
 		s.log.ErrorContext(ctx, "api list images failed", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to list images")
@@ -146,7 +292,7 @@ func (s *Server) handleListImages(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) handleListVolumes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	list, err := s.listVolumes.Execute(ctx)
+	list, err := s.uc.ListVolumes.Execute(ctx, usecase.ListVolumesInput{SkipSize: queryFalse(r, "size")})
 	if err != nil {
 		s.log.ErrorContext(ctx, "api list volumes failed", "error", err)
 		writeJSONError(w, http.StatusInternalServerError, "failed to list volumes")
@@ -190,7 +336,7 @@ func (s *Server) handleStatsWebSocket(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
-	if err := s.streamContainerStats.Execute(ctx, containerID, conn); err != nil && ctx.Err() == nil {
+	if err := s.uc.StreamContainerStats.Execute(ctx, containerID, conn); err != nil && ctx.Err() == nil {
 		s.log.DebugContext(ctx, "stats stream ended", "container_id", containerID, "error", err)
 	}
 }
@@ -234,7 +380,7 @@ func (s *Server) handleLogsWebSocket(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	logWriter := &wsLogWriter{conn: conn}
-	if err := s.streamContainerLogs.Execute(ctx, containerID, logWriter); err != nil && ctx.Err() == nil {
+	if err := s.uc.StreamContainerLogs.Execute(ctx, containerID, logWriter); err != nil && ctx.Err() == nil {
 		s.log.DebugContext(ctx, "logs stream ended", "container_id", containerID, "error", err)
 		if msg, _ := json.Marshal(map[string]string{"error": err.Error()}); len(msg) > 0 {
 			_ = conn.WriteMessage(websocket.TextMessage, msg)
@@ -268,7 +414,7 @@ func writeJSONError(w http.ResponseWriter, status int, message string) {
 func corsMiddleware(next http.Handler, log *slog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
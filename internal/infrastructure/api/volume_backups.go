@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+// handleDownloadVolumeBackup streams a fresh backup of the volume as
+// .tar.gz without storing it. With ?stop_containers=true the containers
+// using the volume stay stopped until the download ends.
+func (s *Server) handleDownloadVolumeBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	b, rc, err := s.uc.StreamVolumeBackup.Execute(ctx, usecase.BackupVolumeInput{Volume: r.PathValue("name"), StopContainers: queryBool(r, "stop_containers")})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to back up volume")
+		return
+	}
+	defer rc.Close()
+	s.writeVolumeBackup(w, r, b, rc)
+}
+
+func (s *Server) handleCreateVolumeBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	b, err := s.uc.BackupVolume.Execute(ctx, usecase.BackupVolumeInput{Volume: r.PathValue("name"), StopContainers: queryBool(r, "stop_containers")})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to back up volume")
+		return
+	}
+	writeJSON(w, http.StatusCreated, b)
+}
+
+// handleRestoreVolume restores the stored backup ?backup= or, without it,
+// the archive in the request body into the volume, creating it if missing.
+func (s *Server) handleRestoreVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	input := usecase.RestoreVolumeInput{
+		Volume:         r.PathValue("name"),
+		BackupID:       r.URL.Query().Get("backup"),
+		StopContainers: queryBool(r, "stop_containers"),
+	}
+	if input.BackupID == "" && r.ContentLength != 0 {
+		input.Archive = r.Body
+	}
+	out, err := s.uc.RestoreVolume.Execute(ctx, input)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to restore volume")
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleListVolumeBackups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListVolumeBackups.Execute(ctx, r.URL.Query().Get("volume"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list volume backups")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleGetVolumeBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	b, rc, err := s.uc.OpenVolumeBackup.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to open volume backup")
+		return
+	}
+	defer rc.Close()
+	w.Header().Set("Content-Length", strconv.FormatInt(b.Size, 10))
+	s.writeVolumeBackup(w, r, b, rc)
+}
+
+func (s *Server) writeVolumeBackup(w http.ResponseWriter, r *http.Request, b *domain.VolumeBackup, rc io.Reader) {
+	ctx := r.Context()
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", b.ID))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rc); err != nil && ctx.Err() == nil {
+		s.log.WarnContext(ctx, "volume backup download interrupted", "volume", b.Volume, "backup_id", b.ID, "error", err)
+	}
+}
+
+func (s *Server) handleDeleteVolumeBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteVolumeBackup.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete volume backup")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+func (s *Server) handleListVolumeBackupSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListVolumeBackupSchedules.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list volume backup schedules")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleCreateVolumeBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	s.saveVolumeBackupSchedule(w, r, "", http.StatusCreated)
+}
+
+func (s *Server) handleUpdateVolumeBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	s.saveVolumeBackupSchedule(w, r, r.PathValue("id"), http.StatusOK)
+}
+
+func (s *Server) saveVolumeBackupSchedule(w http.ResponseWriter, r *http.Request, id string, status int) {
+	ctx := r.Context()
+	var body domain.VolumeBackupSchedule
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	out, err := s.uc.SaveVolumeBackupSchedule.Execute(ctx, usecase.SaveVolumeBackupScheduleInput{ID: id, Schedule: body})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to save volume backup schedule")
+		return
+	}
+	writeJSON(w, status, out)
+}
+
+func (s *Server) handleDeleteVolumeBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := s.uc.DeleteVolumeBackupSchedule.Execute(ctx, r.PathValue("id")); err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to delete volume backup schedule")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+// handleRunVolumeBackupSchedule takes the schedule's backup now, pruning as
+// a scheduled run would.
+func (s *Server) handleRunVolumeBackupSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	b, err := s.uc.RunVolumeBackupSchedules.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to run volume backup schedule")
+		return
+	}
+	writeJSON(w, http.StatusCreated, b)
+}
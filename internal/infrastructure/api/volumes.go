@@ -0,0 +1,192 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/dockscope/dockscope/internal/domain"
+	"github.com/dockscope/dockscope/internal/usecase"
+)
+
+const maxVolumeUploadBytes = 2 << 30
+
+// handleOrphanVolumes lists the volumes no container mounts, with the bytes
+// removing them would free.
+func (s *Server) handleOrphanVolumes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	report, err := s.uc.ListOrphanVolumes.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to list orphan volumes")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) handleCreateVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body domain.VolumeCreateOptions
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	v, err := s.uc.CreateVolume.Execute(ctx, body)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to create volume")
+		return
+	}
+	writeJSON(w, http.StatusCreated, v)
+}
+
+func (s *Server) handleRemoveVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	err := s.uc.RemoveVolume.Execute(ctx, usecase.RemoveVolumeInput{Name: r.PathValue("name"), Force: queryBool(r, "force")})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to remove volume")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+// handlePruneVolumes takes an optional {"labels": [...], "exclude_labels":
+// [...]} body; without one it prunes every unused volume.
+func (s *Server) handlePruneVolumes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body domain.VolumePruneFilter
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	res, err := s.uc.PruneVolumes.Execute(ctx, body)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to prune volumes")
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+var volumeDownloadContentTypes = map[string]string{
+	usecase.VolumeDownloadRaw: "application/octet-stream",
+	usecase.VolumeDownloadTar: "application/x-tar",
+	usecase.VolumeDownloadZip: "application/zip",
+}
+
+// handleVolumeFiles lists the directory at ?path= (the volume root by
+// default). With ?download=true it streams the path instead, as ?format=raw,
+// tar or zip.
+func (s *Server) handleVolumeFiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name, p := r.PathValue("name"), r.URL.Query().Get("path")
+	if !queryBool(r, "download") {
+		out, err := s.uc.BrowseVolumeFiles.Execute(ctx, usecase.BrowseVolumeFilesInput{Volume: name, Path: p})
+		if err != nil {
+			s.writeUseCaseError(ctx, w, err, "failed to list volume files")
+			return
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+
+	dl, err := s.uc.DownloadVolumeFiles.Execute(ctx, usecase.DownloadVolumeFilesInput{Volume: name, Path: p, Format: r.URL.Query().Get("format")})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to download volume files")
+		return
+	}
+	defer dl.Body.Close()
+	w.Header().Set("Content-Type", volumeDownloadContentTypes[dl.Format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dl.Filename))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, dl.Body); err != nil && ctx.Err() == nil {
+		s.log.WarnContext(ctx, "volume download interrupted", "volume", name, "path", p, "error", err)
+	}
+}
+
+// handleUploadVolumeFiles extracts the upload into the existing directory
+// ?path=. Like build contexts it takes a tar body (optionally compressed) or
+// multipart/form-data with each file field named after its relative path.
+func (s *Server) handleUploadVolumeFiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	r.Body = http.MaxBytesReader(w, r.Body, maxVolumeUploadBytes)
+	var archive io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		f, err := spoolMultipartContext(r)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			switch {
+			case errors.As(err, &tooLarge):
+				writeJSONError(w, http.StatusRequestEntityTooLarge, "upload too large")
+			case errors.Is(err, usecase.ErrInvalidInput):
+				writeJSONError(w, http.StatusBadRequest, err.Error())
+			default:
+				s.log.ErrorContext(ctx, "volume upload failed", "error", err)
+				writeJSONError(w, http.StatusBadRequest, "failed to read upload")
+			}
+			return
+		}
+		defer f.Close()
+		archive = f
+	}
+	err := s.uc.UploadVolumeFiles.Execute(ctx, usecase.UploadVolumeFilesInput{Volume: r.PathValue("name"), Dir: r.URL.Query().Get("path"), Archive: archive})
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to upload volume files")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+}
+
+type volumeCloneMessage struct {
+	Type     string                       `json:"type"`
+	Progress *usecase.VolumeCloneProgress `json:"progress,omitempty"`
+	Result   *usecase.CloneVolumeOutput   `json:"result,omitempty"`
+	Error    string                       `json:"error,omitempty"`
+}
+
+// handleCloneVolume takes {"name": ..., "driver": ..., "driver_opts": {...},
+// "labels": {...}, "force": false} and answers with newline-delimited JSON:
+// "progress" messages while files are copied, then "done" (with the
+// verified counts) or "error". Failures before the copy starts, such as a
+// source in use (409), are plain JSON errors.
+func (s *Server) handleCloneVolume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		domain.VolumeCreateOptions
+		Force bool `json:"force"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	started := false
+	start := func() {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+	}
+
+	res, err := s.uc.CloneVolume.Execute(ctx, usecase.CloneVolumeInput{
+		Source: r.PathValue("name"),
+		Target: body.VolumeCreateOptions,
+		Force:  body.Force,
+	}, func(p usecase.VolumeCloneProgress) {
+		start()
+		_ = enc.Encode(volumeCloneMessage{Type: "progress", Progress: &p})
+		_ = rc.Flush()
+	})
+	if err != nil {
+		if !started {
+			s.writeUseCaseError(ctx, w, err, "failed to clone volume")
+			return
+		}
+		_ = enc.Encode(volumeCloneMessage{Type: "error", Error: err.Error()})
+		return
+	}
+	start()
+	_ = enc.Encode(volumeCloneMessage{Type: "done", Result: res})
+}
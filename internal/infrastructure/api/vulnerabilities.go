@@ -0,0 +1,37 @@
+package api
+
+import "net/http"
+
+func (s *Server) handleImageVulnerabilities(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	report, err := s.uc.ScanImageVulnerabilities.Execute(ctx, r.PathValue("id"))
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to scan image")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (s *Server) handleVulnerableContainers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := s.uc.ListVulnerableContainers.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to scan containers")
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) handleVulnerabilityDBStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.uc.GetVulnerabilityDBStatus.Execute(r.Context()))
+}
+
+func (s *Server) handleReloadVulnerabilityDB(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	st, err := s.uc.ReloadVulnerabilityDB.Execute(ctx)
+	if err != nil {
+		s.writeUseCaseError(ctx, w, err, "failed to reload vulnerability database")
+		return
+	}
+	writeJSON(w, http.StatusOK, st)
+}
@@ -0,0 +1,75 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (r *ContainerRepository) Inspect(ctx context.Context, containerID string) (*domain.ContainerDetails, error) {
+	raw, err := r.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, fmt.Errorf("container %s: %w", containerID, domain.ErrNotFound)
+		}
+		r.log.ErrorContext(ctx, "container inspect failed", "container_id", containerID, "error", err)
+		return nil, err
+	}
+	return mapContainerDetailsToDomain(&raw), nil
+}
+
+func mapContainerDetailsToDomain(c *types.ContainerJSON) *domain.ContainerDetails {
+	d := &domain.ContainerDetails{
+		Name: strings.TrimPrefix(c.Name, "/"),
+	}
+	if c.ContainerJSONBase != nil {
+		d.ID = c.ID
+		d.ImageID = c.Image
+		d.State.RestartCount = c.RestartCount
+		if s := c.State; s != nil {
+			d.State.Status = s.Status
+			d.State.Running = s.Running
+			d.State.Restarting = s.Restarting
+			d.State.OOMKilled = s.OOMKilled
+			d.State.ExitCode = s.ExitCode
+			d.State.Error = s.Error
+			d.State.StartedAt = timeFromRFC3339(s.StartedAt)
+			d.State.FinishedAt = timeFromRFC3339(s.FinishedAt)
+			d.State.Health = mapHealthToDomain(s.Health)
+		}
+	}
+	if c.Config != nil {
+		d.Image = c.Config.Image
+		d.Labels = c.Config.Labels
+	}
+	return d
+}
+
+func mapHealthToDomain(h *types.Health) *domain.ContainerHealth {
+	if h == nil || h.Status == "" || h.Status == types.NoHealthcheck {
+		return nil
+	}
+	probes := make([]domain.HealthProbe, 0, len(h.Log))
+	for _, p := range h.Log {
+		if p == nil {
+			continue
+		}
+		probes = append(probes, domain.HealthProbe{
+			Start:    p.Start,
+			End:      p.End,
+			ExitCode: p.ExitCode,
+			Output:   p.Output,
+		})
+	}
+	return &domain.ContainerHealth{
+		Status:        h.Status,
+		FailingStreak: h.FailingStreak,
+		Probes:        probes,
+	}
+}
+
+var _ domain.ContainerInspector = (*ContainerRepository)(nil)
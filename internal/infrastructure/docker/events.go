@@ -0,0 +1,94 @@
+package docker
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// eventAttributes are the non-label attributes Docker adds to container events.
+var eventAttributes = map[string]bool{
+	"name":         true,
+	"image":        true,
+	"exitCode":     true,
+	"signal":       true,
+	"execDuration": true,
+}
+
+type EventWatcher struct {
+	cli *client.Client
+	log *slog.Logger
+}
+
+func NewEventWatcher(cli *client.Client, log *slog.Logger) *EventWatcher {
+	return &EventWatcher{cli: cli, log: log}
+}
+
+func (w *EventWatcher) Events(ctx context.Context) (<-chan domain.ContainerEvent, <-chan error) {
+	outCh := make(chan domain.ContainerEvent, 32)
+	errCh := make(chan error, 1)
+
+	args := filters.NewArgs(filters.Arg("type", events.ContainerEventType))
+	msgs, errs := w.cli.Events(ctx, types.EventsOptions{Filters: args})
+
+	go func() {
+		defer close(outCh)
+		defer close(errCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-errs:
+				if ctx.Err() == nil {
+					w.log.WarnContext(ctx, "docker events stream failed", "error", err)
+					errCh <- err
+				}
+				return
+			case msg := <-msgs:
+				ev := mapEventToDomain(&msg)
+				select {
+				case <-ctx.Done():
+					return
+				case outCh <- ev:
+				}
+			}
+		}
+	}()
+
+	return outCh, errCh
+}
+
+func mapEventToDomain(msg *events.Message) domain.ContainerEvent {
+	attrs := msg.Actor.Attributes
+	labels := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if !eventAttributes[k] {
+			labels[k] = v
+		}
+	}
+	exitCode, _ := strconv.Atoi(attrs["exitCode"])
+	// Health transitions arrive as "health_status: <status>".
+	action, health := msg.Action, ""
+	if status, ok := strings.CutPrefix(action, domain.EventHealthStatus+":"); ok {
+		action, health = domain.EventHealthStatus, strings.TrimSpace(status)
+	}
+	return domain.ContainerEvent{
+		ContainerID:   msg.Actor.ID,
+		ContainerName: strings.TrimPrefix(attrs["name"], "/"),
+		Image:         attrs["image"],
+		Action:        action,
+		ExitCode:      exitCode,
+		Health:        health,
+		Labels:        labels,
+		Time:          timeFromUnixNano(msg.TimeNano),
+	}
+}
+
+var _ domain.ContainerEventSource = (*EventWatcher)(nil)
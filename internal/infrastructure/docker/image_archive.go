@@ -0,0 +1,174 @@
+package docker
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// gzipMagic starts the compressed layer blobs that the containerd image
+// store exports as they were pulled.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+type archiveManifest struct {
+	Layers []string `json:"Layers"`
+}
+
+// ReadLayers streams the image through ImageSave and records the headers of
+// every layer tarball; file contents are skipped.
+func (m *ImageManager) ReadLayers(ctx context.Context, imageID string) ([]domain.LayerContents, error) {
+	layers := make(map[string]domain.LayerContents)
+	order, err := m.walkArchive(ctx, imageID, func(name string, r io.Reader) error {
+		files, err := readLayerTar(r)
+		if err != nil {
+			return err
+		}
+		layers[name] = domain.LayerContents{Files: files}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.LayerContents, 0, len(order))
+	for _, l := range order {
+		out = append(out, layers[l])
+	}
+	return out, nil
+}
+
+// walkArchive streams the image through ImageSave and hands every member
+// that may be a layer tarball to layer, then returns the layer names in
+// manifest order, bottom first. Both the legacy "<id>/layer.tar" layout and
+// the OCI "blobs/sha256/<digest>" layout are handled, with gzip-compressed
+// layers decompressed before layer sees them: since the manifest comes last,
+// layer sees members in archive order and a layer error only means the
+// member was not a tar (config blobs and indexes in the OCI layout). It
+// fails if a manifest layer was not read.
+func (m *ImageManager) walkArchive(ctx context.Context, imageID string, layer func(name string, r io.Reader) error) ([]string, error) {
+	rc, err := m.cli.ImageSave(ctx, []string{imageID})
+	if err != nil {
+		m.log.WarnContext(ctx, "image save failed", "image_id", imageID, "error", err)
+		return nil, mapImageError(imageID, err)
+	}
+	defer rc.Close()
+
+	read := make(map[string]bool)
+	var manifest []archiveManifest
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read image archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == "manifest.json":
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				return nil, fmt.Errorf("decode image manifest: %w", err)
+			}
+		case strings.HasSuffix(name, ".json") || name == "repositories" || name == "oci-layout":
+		default:
+			if lr, err := layerReader(tr); err == nil && layer(name, lr) == nil {
+				read[name] = true
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if len(manifest) == 0 {
+		return nil, errors.New("image archive has no manifest")
+	}
+	order := make([]string, 0, len(manifest[0].Layers))
+	for _, l := range manifest[0].Layers {
+		name := path.Clean(l)
+		if !read[name] {
+			return nil, fmt.Errorf("image archive is missing layer %s", l)
+		}
+		order = append(order, name)
+	}
+	return order, nil
+}
+
+// layerReader returns the uncompressed stream of a layer member.
+func layerReader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		return gzip.NewReader(br)
+	}
+	return br, nil
+}
+
+func readLayerTar(r io.Reader) ([]domain.LayerFile, error) {
+	tr := tar.NewReader(r)
+	files := []domain.LayerFile{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if f, ok := layerFile(hdr); ok {
+			files = append(files, f)
+		}
+	}
+}
+
+// layerFile describes a layer tarball entry, turning whiteout markers into
+// Whiteout and Opaque entries. It returns false for the root directory.
+func layerFile(hdr *tar.Header) (domain.LayerFile, bool) {
+	p := strings.Trim(path.Clean("/"+hdr.Name), "/")
+	if p == "" {
+		return domain.LayerFile{}, false
+	}
+	dir, base := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case base == whiteoutOpaque:
+		return domain.LayerFile{Path: dir, Type: domain.FileTypeDir, Opaque: true}, true
+	case strings.HasPrefix(base, whiteoutPrefix):
+		return domain.LayerFile{Path: path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)), Whiteout: true}, true
+	}
+	f := domain.LayerFile{Path: p, LinkTarget: hdr.Linkname}
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		f.Type = domain.FileTypeDir
+	case tar.TypeSymlink:
+		f.Type = domain.FileTypeSymlink
+	case tar.TypeLink:
+		f.Type = domain.FileTypeHardlink
+		f.LinkTarget = strings.Trim(path.Clean("/"+hdr.Linkname), "/")
+	case tar.TypeReg:
+		f.Type = domain.FileTypeFile
+		f.Size = hdr.Size
+		f.Executable = hdr.Mode&0o111 != 0
+	default:
+		f.Type = domain.FileTypeOther
+	}
+	return f, true
+}
+
+var _ domain.ImageLayerReader = (*ImageManager)(nil)
@@ -0,0 +1,99 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/client"
+)
+
+func tarOf(t *testing.T, files map[string]string, order ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range order {
+		body := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(body))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// ociArchive builds what "docker save" returns with the containerd image
+// store: an OCI layout whose layer blobs are still gzip-compressed, except
+// the second one which is left uncompressed.
+func ociArchive(t *testing.T) []byte {
+	t.Helper()
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write(tarOf(t, map[string]string{"etc/os-release": "ID=alpine\n"}, "etc/os-release"))
+	zw.Close()
+	layers := [][]byte{gz.Bytes(), tarOf(t, map[string]string{"app/run.sh": "#!/bin/sh\n"}, "app/run.sh")}
+
+	files := map[string]string{"oci-layout": `{"imageLayoutVersion":"1.0.0"}`}
+	order := []string{"oci-layout"}
+	blob := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		name := "blobs/sha256/" + hex.EncodeToString(sum[:])
+		files[name] = string(data)
+		order = append(order, name)
+		return name
+	}
+	blob([]byte(`{"architecture":"amd64","os":"linux"}`))
+	var names []string
+	for _, l := range layers {
+		names = append(names, blob(l))
+	}
+	manifest, _ := json.Marshal([]map[string]any{{"Config": order[1], "RepoTags": []string{"app:oci"}, "Layers": names}})
+	files["manifest.json"] = string(manifest)
+	order = append(order, "manifest.json")
+	return tarOf(t, files, order...)
+}
+
+func TestImageManager_ReadLayersCompressedOCI(t *testing.T) {
+	archive := ociArchive(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/images/get") && r.URL.Query().Get("names") == "app:oci" {
+			w.Write(archive)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(srv.URL, "http://")), client.WithVersion("1.41"))
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := NewImageManager(cli, log)
+
+	layers, err := m.ReadLayers(context.Background(), "app:oci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(layers) != 2 {
+		t.Fatalf("expected 2 layers, got %d", len(layers))
+	}
+	if f := layers[0].Files; len(f) != 1 || f[0].Path != "etc/os-release" {
+		t.Errorf("compressed layer files = %+v", f)
+	}
+	if f := layers[1].Files; len(f) != 1 || f[0].Path != "app/run.sh" {
+		t.Errorf("plain layer files = %+v", f)
+	}
+}
@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (m *ImageManager) Build(ctx context.Context, buildContext io.Reader, opts domain.BuildOptions, output func(string)) (*domain.BuildResult, error) {
+	args := make(map[string]*string, len(opts.BuildArgs))
+	for k, v := range opts.BuildArgs {
+		v := v
+		args[k] = &v
+	}
+	resp, err := m.cli.ImageBuild(ctx, buildContext, types.ImageBuildOptions{
+		Tags:        opts.Tags,
+		BuildArgs:   args,
+		Target:      opts.Target,
+		Dockerfile:  opts.Dockerfile,
+		NoCache:     opts.NoCache,
+		PullParent:  opts.Pull,
+		Remove:      true,
+		ForceRemove: true,
+		AuthConfigs: authConfigs(opts.Credentials),
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "image build failed", "tags", opts.Tags, "error", err)
+		return nil, mapImageError(strings.Join(opts.Tags, ","), err)
+	}
+	defer resp.Body.Close()
+
+	res := &domain.BuildResult{}
+	err = decodeJSONMessages(resp.Body, func(msg *jsonmessage.JSONMessage) {
+		switch {
+		case msg.Aux != nil:
+			var aux struct {
+				ID string `json:"ID"`
+			}
+			if json.Unmarshal(*msg.Aux, &aux) == nil && aux.ID != "" {
+				res.ImageID = aux.ID
+			}
+		case msg.Stream != "":
+			for _, line := range strings.Split(strings.TrimRight(msg.Stream, "\n"), "\n") {
+				output(line)
+			}
+		case msg.Status != "" && msg.Status != "Downloading" && msg.Status != "Extracting":
+			// Base image pulls; per-tick progress is left out of the log.
+			if msg.ID != "" {
+				output(fmt.Sprintf("%s: %s", msg.ID, msg.Status))
+			} else {
+				output(msg.Status)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.ImageID == "" {
+		return nil, errors.New("build finished without reporting an image ID")
+	}
+	m.log.InfoContext(ctx, "image built", "image_id", res.ImageID, "tags", opts.Tags)
+	return res, nil
+}
+
+var _ domain.ImageBuilder = (*ImageManager)(nil)
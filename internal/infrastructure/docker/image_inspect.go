@@ -0,0 +1,246 @@
+package docker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (m *ImageManager) Inspect(ctx context.Context, ref string) (*domain.ImageDetails, error) {
+	raw, _, err := m.cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		m.log.WarnContext(ctx, "image inspect failed", "ref", ref, "error", err)
+		return nil, mapImageError(ref, err)
+	}
+	history, err := m.cli.ImageHistory(ctx, raw.ID)
+	if err != nil {
+		m.log.WarnContext(ctx, "image history failed", "ref", ref, "error", err)
+		return nil, mapImageError(ref, err)
+	}
+	d := mapImageDetailsToDomain(&raw)
+	d.Layers = layersFromHistory(history, raw.RootFS.Layers)
+
+	shared, err := m.sharedChains(ctx, raw.ID)
+	if err != nil {
+		// Sharing is informative only; report the layers without it.
+		m.log.WarnContext(ctx, "image layer sharing lookup failed", "ref", ref, "error", err)
+		return d, nil
+	}
+	chains := chainIDs(raw.RootFS.Layers)
+	n := 0
+	for i := range d.Layers {
+		if d.Layers[i].DiffID == "" {
+			continue
+		}
+		d.Layers[i].Shared = shared[chains[n]]
+		n++
+	}
+	return d, nil
+}
+
+// sharedChains returns the chain IDs used by every local image other than
+// imageID.
+func (m *ImageManager) sharedChains(ctx context.Context, imageID string) (map[string]bool, error) {
+	all, err := m.ImageLayers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shared := make(map[string]bool)
+	for id, layers := range all {
+		if id == imageID {
+			continue
+		}
+		for _, l := range layers {
+			shared[l.ChainID] = true
+		}
+	}
+	return shared, nil
+}
+
+// ImageLayers returns the filesystem layers of every top-level local image.
+// Intermediate build images are left out: removing an image also prunes
+// its untagged parents, so their layers are not really shared.
+func (m *ImageManager) ImageLayers(ctx context.Context) (map[string][]domain.LayerRef, error) {
+	list, err := m.cli.ImageList(ctx, types.ImageListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make(map[string][]domain.LayerRef, len(list))
+	for _, img := range list {
+		layers, ok := m.layers[img.ID]
+		if !ok {
+			layers, err = m.readLayerRefs(ctx, img.ID)
+			if client.IsErrNotFound(err) {
+				// Removed since the list call.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			m.layers[img.ID] = layers
+		}
+		out[img.ID] = layers
+	}
+	for id := range m.layers {
+		if _, ok := out[id]; !ok {
+			delete(m.layers, id)
+		}
+	}
+	return out, nil
+}
+
+func (m *ImageManager) readLayerRefs(ctx context.Context, imageID string) ([]domain.LayerRef, error) {
+	raw, _, err := m.cli.ImageInspectWithRaw(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := m.cli.ImageHistory(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	chains := chainIDs(raw.RootFS.Layers)
+	refs := make([]domain.LayerRef, len(chains))
+	for i, c := range chains {
+		refs[i].ChainID = c
+	}
+	n := 0
+	for _, l := range layersFromHistory(history, raw.RootFS.Layers) {
+		if l.DiffID != "" {
+			refs[n].Size = l.Size
+			n++
+		}
+	}
+	return refs, nil
+}
+
+// chainIDs identifies each layer together with everything below it, which is
+// how the daemon stores and shares layers: the same diff on top of a
+// different parent is a different layer on disk.
+func chainIDs(diffIDs []string) []string {
+	out := make([]string, len(diffIDs))
+	for i, d := range diffIDs {
+		if i == 0 {
+			out[i] = d
+			continue
+		}
+		sum := sha256.Sum256([]byte(out[i-1] + " " + d))
+		out[i] = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return out
+}
+
+// layersFromHistory returns the history oldest first and pairs the entries
+// that created a filesystem layer with the image's diff IDs. The history API
+// does not say which entries are empty, so entries with a size are layers,
+// and zero-size entries are layers only when they are not metadata
+// instructions and diff IDs are left over for them.
+func layersFromHistory(history []image.HistoryResponseItem, diffIDs []string) []domain.ImageLayer {
+	sized := 0
+	for _, h := range history {
+		if h.Size > 0 {
+			sized++
+		}
+	}
+	spare := len(diffIDs) - sized
+
+	out := make([]domain.ImageLayer, 0, len(history))
+	next := 0
+	for i := len(history) - 1; i >= 0; i-- {
+		h := history[i]
+		l := domain.ImageLayer{
+			CreatedBy: h.CreatedBy,
+			CreatedAt: timeFromUnixSeconds(h.Created),
+			Size:      h.Size,
+			Comment:   h.Comment,
+			Empty:     true,
+		}
+		isLayer := h.Size > 0
+		if !isLayer && spare > 0 && !metadataInstruction(h.CreatedBy) {
+			isLayer = true
+			spare--
+		}
+		if isLayer && next < len(diffIDs) {
+			l.DiffID = diffIDs[next]
+			l.Empty = false
+			next++
+		}
+		out = append(out, l)
+	}
+	return out
+}
+
+var metadataInstructions = map[string]bool{
+	"ARG": true, "CMD": true, "ENTRYPOINT": true, "ENV": true, "EXPOSE": true,
+	"HEALTHCHECK": true, "LABEL": true, "MAINTAINER": true, "ONBUILD": true,
+	"SHELL": true, "STOPSIGNAL": true, "USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+func metadataInstruction(createdBy string) bool {
+	s := strings.TrimSpace(createdBy)
+	// Classic builder: "/bin/sh -c #(nop)  ENV A=b"; ADD and COPY still
+	// create layers.
+	if i := strings.Index(s, "#(nop)"); i >= 0 {
+		s = strings.TrimSpace(s[i+len("#(nop)"):])
+		return !strings.HasPrefix(s, "ADD ") && !strings.HasPrefix(s, "COPY ")
+	}
+	word, _, _ := strings.Cut(s, " ")
+	return metadataInstructions[strings.ToUpper(word)]
+}
+
+func mapImageDetailsToDomain(raw *types.ImageInspect) *domain.ImageDetails {
+	d := &domain.ImageDetails{
+		ID:           raw.ID,
+		RepoTags:     nonNil(raw.RepoTags),
+		RepoDigests:  nonNil(raw.RepoDigests),
+		CreatedAt:    timeFromRFC3339(raw.Created),
+		Size:         raw.Size,
+		Author:       raw.Author,
+		Architecture: raw.Architecture,
+		Variant:      raw.Variant,
+		OS:           raw.Os,
+		Config: domain.ImageConfig{
+			Env:          []string{},
+			Entrypoint:   []string{},
+			Cmd:          []string{},
+			ExposedPorts: []string{},
+			Labels:       map[string]string{},
+		},
+	}
+	if c := raw.Config; c != nil {
+		d.Config.Env = nonNil(c.Env)
+		d.Config.Entrypoint = nonNil([]string(c.Entrypoint))
+		d.Config.Cmd = nonNil([]string(c.Cmd))
+		d.Config.WorkingDir = c.WorkingDir
+		d.Config.User = c.User
+		for p := range c.ExposedPorts {
+			d.Config.ExposedPorts = append(d.Config.ExposedPorts, string(p))
+		}
+		sort.Strings(d.Config.ExposedPorts)
+		if c.Labels != nil {
+			d.Config.Labels = c.Labels
+		}
+	}
+	return d
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+var (
+	_ domain.ImageInspector  = (*ImageManager)(nil)
+	_ domain.ImageLayerIndex = (*ImageManager)(nil)
+)
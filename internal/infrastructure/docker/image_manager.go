@@ -0,0 +1,146 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ImageManager struct {
+	cli *client.Client
+	log *slog.Logger
+
+	mu sync.Mutex
+	// layers caches the filesystem layers of each local image; image
+	// content is immutable so entries only go away when the image does.
+	layers    map[string][]domain.LayerRef
+	platforms map[string]domain.Platform
+}
+
+func NewImageManager(cli *client.Client, log *slog.Logger) *ImageManager {
+	return &ImageManager{cli: cli, log: log, layers: make(map[string][]domain.LayerRef), platforms: make(map[string]domain.Platform)}
+}
+
+func (m *ImageManager) Pull(ctx context.Context, ref string, cred *domain.RegistryCredential, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+	auth, err := encodeRegistryAuth(cred)
+	if err != nil {
+		return nil, err
+	}
+	body, err := m.cli.ImagePull(ctx, ref, types.ImagePullOptions{RegistryAuth: auth})
+	if err != nil {
+		m.log.ErrorContext(ctx, "image pull failed", "ref", ref, "error", err)
+		return nil, mapImageError(ref, err)
+	}
+	defer body.Close()
+
+	res := &domain.PullResult{Ref: ref}
+	err = decodeJSONMessages(body, func(msg *jsonmessage.JSONMessage) {
+		switch {
+		case strings.HasPrefix(msg.Status, "Digest: "):
+			res.Digest = strings.TrimPrefix(msg.Status, "Digest: ")
+		case strings.HasPrefix(msg.Status, "Status: "):
+			res.Status = strings.TrimPrefix(msg.Status, "Status: ")
+		default:
+			progress(progressFromMessage(msg))
+		}
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "image pull stream failed", "ref", ref, "error", err)
+		return nil, err
+	}
+	m.log.InfoContext(ctx, "image pulled", "ref", ref, "digest", res.Digest)
+	return res, nil
+}
+
+func (m *ImageManager) Remove(ctx context.Context, ref string, force, noPrune bool) (*domain.ImageDeleteResult, error) {
+	items, err := m.cli.ImageRemove(ctx, ref, types.ImageRemoveOptions{Force: force, PruneChildren: !noPrune})
+	if err != nil {
+		if errdefs.IsConflict(err) {
+			return nil, m.inUseError(ctx, ref, err)
+		}
+		m.log.WarnContext(ctx, "image remove failed", "ref", ref, "error", err)
+		return nil, mapImageError(ref, err)
+	}
+	res := &domain.ImageDeleteResult{Untagged: []string{}, Deleted: []string{}}
+	for _, it := range items {
+		if it.Untagged != "" {
+			res.Untagged = append(res.Untagged, it.Untagged)
+		}
+		if it.Deleted != "" {
+			res.Deleted = append(res.Deleted, it.Deleted)
+		}
+	}
+	m.log.InfoContext(ctx, "image removed", "ref", ref, "untagged", len(res.Untagged), "deleted", len(res.Deleted))
+	return res, nil
+}
+
+func (m *ImageManager) Tag(ctx context.Context, source, target string) error {
+	if err := m.cli.ImageTag(ctx, source, target); err != nil {
+		m.log.WarnContext(ctx, "image tag failed", "source", source, "target", target, "error", err)
+		return mapImageError(source, err)
+	}
+	m.log.InfoContext(ctx, "image tagged", "source", source, "target", target)
+	return nil
+}
+
+func (m *ImageManager) Untag(ctx context.Context, imageRef, tag string) (*domain.ImageDeleteResult, error) {
+	img, _, err := m.cli.ImageInspectWithRaw(ctx, imageRef)
+	if err != nil {
+		return nil, mapImageError(imageRef, err)
+	}
+	tagged, _, err := m.cli.ImageInspectWithRaw(ctx, tag)
+	if err != nil {
+		return nil, mapImageError(tag, err)
+	}
+	if tagged.ID != img.ID {
+		return nil, fmt.Errorf("tag %s does not reference image %s: %w", tag, imageRef, domain.ErrNotFound)
+	}
+	return m.Remove(ctx, tag, false, true)
+}
+
+// inUseError lists the containers (running or stopped) created from the
+// image that blocked its removal.
+func (m *ImageManager) inUseError(ctx context.Context, ref string, cause error) error {
+	img, _, err := m.cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cause.Error(), domain.ErrConflict)
+	}
+	list, err := m.cli.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return fmt.Errorf("%s: %w", cause.Error(), domain.ErrConflict)
+	}
+	inUse := &domain.InUseError{Resource: "image " + ref, Containers: []string{}}
+	for _, c := range list {
+		if c.ImageID == img.ID {
+			inUse.Containers = append(inUse.Containers, c.ID)
+		}
+	}
+	if len(inUse.Containers) == 0 {
+		// Conflicts can also come from dependent child images.
+		return fmt.Errorf("%s: %w", cause.Error(), domain.ErrConflict)
+	}
+	return inUse
+}
+
+func mapImageError(ref string, err error) error {
+	switch {
+	case client.IsErrNotFound(err):
+		return fmt.Errorf("image %s: %w", ref, domain.ErrNotFound)
+	case errdefs.IsInvalidParameter(err):
+		return fmt.Errorf("%s: %w", err.Error(), domain.ErrInvalidArgument)
+	case errdefs.IsConflict(err):
+		return fmt.Errorf("%s: %w", err.Error(), domain.ErrConflict)
+	default:
+		return err
+	}
+}
+
+var _ domain.ImageManager = (*ImageManager)(nil)
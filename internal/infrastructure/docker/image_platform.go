@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+)
+
+// ImagePlatforms returns the platform of every local image, inspecting only
+// the images it has not seen before.
+func (m *ImageManager) ImagePlatforms(ctx context.Context) (map[string]domain.Platform, error) {
+	list, err := m.cli.ImageList(ctx, types.ImageListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make(map[string]domain.Platform, len(list))
+	for _, img := range list {
+		p, ok := m.platforms[img.ID]
+		if !ok {
+			raw, _, err := m.cli.ImageInspectWithRaw(ctx, img.ID)
+			if client.IsErrNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			p = domain.Platform{OS: raw.Os, Architecture: raw.Architecture, Variant: raw.Variant}
+			m.platforms[img.ID] = p
+		}
+		out[img.ID] = p
+	}
+	for id := range m.platforms {
+		if _, ok := out[id]; !ok {
+			delete(m.platforms, id)
+		}
+	}
+	return out, nil
+}
+
+func (m *ImageManager) DistributionInspect(ctx context.Context, ref string, cred *domain.RegistryCredential) (*domain.ImageDistribution, error) {
+	auth, err := encodeRegistryAuth(cred)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := m.cli.DistributionInspect(ctx, ref, auth)
+	if err != nil {
+		m.log.WarnContext(ctx, "distribution inspect failed", "ref", ref, "error", err)
+		switch {
+		case errdefs.IsUnauthorized(err), errdefs.IsForbidden(err):
+			return nil, fmt.Errorf("distribution inspect %s: %w", ref, domain.ErrUnauthorized)
+		case client.IsErrNotFound(err):
+			return nil, fmt.Errorf("image %s not found in its registry: %w", ref, domain.ErrNotFound)
+		case errdefs.IsInvalidParameter(err):
+			return nil, fmt.Errorf("%s: %w", err.Error(), domain.ErrInvalidArgument)
+		}
+		// Anything else is the daemon failing to reach the registry.
+		return nil, fmt.Errorf("distribution inspect %s: %w: %v", ref, domain.ErrUnavailable, err)
+	}
+	d := &domain.ImageDistribution{
+		Ref:       ref,
+		Digest:    raw.Descriptor.Digest.String(),
+		MediaType: raw.Descriptor.MediaType,
+		Size:      raw.Descriptor.Size,
+		MultiArch: raw.Descriptor.MediaType == mediaTypeDockerManifestList || raw.Descriptor.MediaType == mediaTypeOCIIndex,
+		Platforms: []domain.Platform{},
+	}
+	for _, p := range raw.Platforms {
+		d.Platforms = append(d.Platforms, domain.Platform{OS: p.OS, Architecture: p.Architecture, Variant: p.Variant})
+	}
+	return d, nil
+}
+
+var (
+	_ domain.ImagePlatformIndex         = (*ImageManager)(nil)
+	_ domain.ImageDistributionInspector = (*ImageManager)(nil)
+)
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (m *ImageManager) Push(ctx context.Context, ref string, cred *domain.RegistryCredential, progress func(domain.LayerProgress)) (*domain.PushResult, error) {
+	auth, err := encodeRegistryAuth(cred)
+	if err != nil {
+		return nil, err
+	}
+	if auth == "" {
+		// The daemon wants the header even for anonymous pushes.
+		auth = "e30="
+	}
+	body, err := m.cli.ImagePush(ctx, ref, types.ImagePushOptions{RegistryAuth: auth})
+	if err != nil {
+		m.log.WarnContext(ctx, "image push failed", "ref", ref, "error", err)
+		return nil, pushError(ref, err)
+	}
+	defer body.Close()
+
+	res := &domain.PushResult{Ref: ref}
+	err = decodeJSONMessages(body, func(msg *jsonmessage.JSONMessage) {
+		if msg.Aux != nil {
+			var aux types.PushResult
+			if json.Unmarshal(*msg.Aux, &aux) == nil && aux.Digest != "" {
+				res.Digest = aux.Digest
+				res.Size = int64(aux.Size)
+			}
+			return
+		}
+		if msg.ID == "" && strings.Contains(msg.Status, ": digest: ") {
+			// "<tag>: digest: sha256:... size: 1234", already in Aux.
+			return
+		}
+		progress(progressFromMessage(msg))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		m.log.WarnContext(ctx, "image push stream failed", "ref", ref, "error", err)
+		return nil, pushError(ref, err)
+	}
+	m.log.InfoContext(ctx, "image pushed", "ref", ref, "digest", res.Digest)
+	return res, nil
+}
+
+// pushError classifies push failures. Errors reported inside the stream only
+// carry the registry's message, so those are matched on text.
+func pushError(ref string, err error) error {
+	msg := err.Error()
+	lower := strings.ToLower(msg)
+	code := domain.PushErrorFailed
+	switch {
+	case client.IsErrNotFound(err), strings.Contains(lower, "does not exist locally"), strings.Contains(lower, "no such image"):
+		code = domain.PushErrorNotFound
+	case errdefs.IsUnauthorized(err), errdefs.IsForbidden(err), containsAny(lower,
+		"unauthorized", "denied", "authentication required", "no basic auth credentials", "insufficient_scope", "incorrect username or password"):
+		code = domain.PushErrorAuthDenied
+	case containsAny(lower, "blob unknown", "blob_unknown", "unknown blob"):
+		code = domain.PushErrorUnknownBlob
+	case containsAny(lower, "connection refused", "no such host", "i/o timeout", "dial tcp", "tls handshake",
+		"http response to https client", "network is unreachable", "connection reset", "service unavailable", "bad gateway"):
+		code = domain.PushErrorUnreachable
+	}
+	return &domain.PushError{Code: code, Message: "push " + ref + ": " + msg}
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ domain.ImagePusher = (*ImageManager)(nil)
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// layerScan is what one layer contributed to a scan: the results of the
+// selected files it holds, and the paths it replaces or deletes in the
+// layers below.
+type layerScan struct {
+	results map[string]any
+	// replaced lists the non-directory entries of the layer; an entry that
+	// was not selected still hides a selected file of the same path below.
+	replaced []string
+	deleted  []string
+	opaque   []string
+}
+
+// ScanFiles reads the image in a single ImageSave pass. Layers arrive in
+// archive order, so each one is scanned on its own and the scans are merged
+// bottom-up once the manifest gives their order.
+func (m *ImageManager) ScanFiles(ctx context.Context, imageID string, want func(domain.LayerFile) bool, read func(domain.LayerFile, io.Reader) (any, error)) (map[string]any, error) {
+	scans := make(map[string]*layerScan)
+	order, err := m.walkArchive(ctx, imageID, func(name string, r io.Reader) error {
+		scan, err := scanLayerTar(r, want, read)
+		if err != nil {
+			return err
+		}
+		scans[name] = scan
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]any)
+	removeTree := func(p string) {
+		for k := range results {
+			if k == p || strings.HasPrefix(k, p+"/") {
+				delete(results, k)
+			}
+		}
+	}
+	for _, name := range order {
+		scan := scans[name]
+		for _, dir := range scan.opaque {
+			for k := range results {
+				if strings.HasPrefix(k, dir+"/") {
+					delete(results, k)
+				}
+			}
+		}
+		for _, p := range scan.deleted {
+			removeTree(p)
+		}
+		for _, p := range scan.replaced {
+			delete(results, p)
+		}
+		for p, v := range scan.results {
+			results[p] = v
+		}
+	}
+	return results, nil
+}
+
+// scanLayerTar returns an error only when r is not a tar at all; a read
+// error of a selected file drops that file's result.
+func scanLayerTar(r io.Reader, want func(domain.LayerFile) bool, read func(domain.LayerFile, io.Reader) (any, error)) (*layerScan, error) {
+	tr := tar.NewReader(r)
+	scan := &layerScan{results: make(map[string]any)}
+	for first := true; ; first = false {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return scan, nil
+		}
+		if err != nil {
+			if first {
+				return nil, err
+			}
+			// A truncated layer still contributes what was read.
+			return scan, nil
+		}
+		f, ok := layerFile(hdr)
+		switch {
+		case !ok:
+		case f.Opaque:
+			scan.opaque = append(scan.opaque, f.Path)
+		case f.Whiteout:
+			scan.deleted = append(scan.deleted, f.Path)
+		case f.Type == domain.FileTypeDir:
+		default:
+			scan.replaced = append(scan.replaced, f.Path)
+			if f.Type != domain.FileTypeFile || !want(f) {
+				continue
+			}
+			if v, err := read(f, tr); err == nil && v != nil {
+				scan.results[f.Path] = v
+			}
+		}
+	}
+}
+
+var _ domain.ImageFileScanner = (*ImageManager)(nil)
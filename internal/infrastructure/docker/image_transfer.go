@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (m *ImageManager) Export(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	rc, err := m.cli.ImageSave(ctx, refs)
+	if err != nil {
+		m.log.WarnContext(ctx, "image export failed", "refs", refs, "error", err)
+		return nil, mapImageError(strings.Join(refs, ", "), err)
+	}
+	return rc, nil
+}
+
+func (m *ImageManager) Load(ctx context.Context, archive io.Reader, progress func(domain.LayerProgress)) (*domain.LoadResult, error) {
+	resp, err := m.cli.ImageLoad(ctx, archive, false)
+	if err != nil {
+		m.log.WarnContext(ctx, "image load failed", "error", err)
+		return nil, mapImageError("archive", err)
+	}
+	defer resp.Body.Close()
+	if !resp.JSON {
+		return nil, errors.New("image load: daemon did not return a JSON stream")
+	}
+
+	res := &domain.LoadResult{Images: []string{}}
+	err = decodeJSONMessages(resp.Body, func(msg *jsonmessage.JSONMessage) {
+		if msg.Stream != "" {
+			for _, line := range strings.Split(strings.TrimSpace(msg.Stream), "\n") {
+				if ref, ok := strings.CutPrefix(line, "Loaded image: "); ok {
+					res.Images = append(res.Images, ref)
+				} else if id, ok := strings.CutPrefix(line, "Loaded image ID: "); ok {
+					res.Images = append(res.Images, id)
+				}
+			}
+			return
+		}
+		progress(progressFromMessage(msg))
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "image load stream failed", "error", err)
+		return nil, err
+	}
+	m.log.InfoContext(ctx, "images loaded", "images", res.Images)
+	return res, nil
+}
+
+func (m *ImageManager) Import(ctx context.Context, rootfs io.Reader, opts domain.ImportOptions) (string, error) {
+	rc, err := m.cli.ImageImport(ctx, types.ImageImportSource{Source: rootfs, SourceName: "-"}, opts.Ref, types.ImageImportOptions{
+		Message: opts.Message,
+		Changes: opts.Changes,
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "image import failed", "ref", opts.Ref, "error", err)
+		return "", mapImageError(opts.Ref, err)
+	}
+	defer rc.Close()
+
+	// The last status message carries the new image ID.
+	var id string
+	err = decodeJSONMessages(rc, func(msg *jsonmessage.JSONMessage) {
+		if strings.HasPrefix(msg.Status, "sha256:") {
+			id = msg.Status
+		}
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "image import stream failed", "ref", opts.Ref, "error", err)
+		return "", err
+	}
+	if id == "" {
+		return "", errors.New("import finished without reporting an image ID")
+	}
+	m.log.InfoContext(ctx, "image imported", "ref", opts.Ref, "image_id", id)
+	return id, nil
+}
+
+var _ domain.ImageArchiver = (*ImageManager)(nil)
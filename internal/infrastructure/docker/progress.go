@@ -0,0 +1,111 @@
+package docker
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// decodeJSONMessages reads the daemon's JSON progress stream, calling fn (if
+// set) for each message and returning the first error reported inside the
+// stream.
+func decodeJSONMessages(r io.Reader, fn func(*jsonmessage.JSONMessage)) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if msg.ErrorMessage != "" {
+			return errors.New(msg.ErrorMessage)
+		}
+		if fn != nil {
+			fn(&msg)
+		}
+	}
+}
+
+func progressFromMessage(msg *jsonmessage.JSONMessage) domain.LayerProgress {
+	p := domain.LayerProgress{
+		LayerID: msg.ID,
+		Phase:   phaseFromStatus(msg.Status),
+		Status:  msg.Status,
+	}
+	if msg.Progress != nil {
+		p.Current = msg.Progress.Current
+		p.Total = msg.Progress.Total
+	}
+	return p
+}
+
+func phaseFromStatus(status string) string {
+	switch {
+	case status == "Waiting", status == "Pulling fs layer", status == "Preparing":
+		return domain.PhaseWaiting
+	case status == "Downloading", status == "Verifying Checksum", status == "Download complete":
+		return domain.PhaseDownloading
+	case status == "Extracting":
+		return domain.PhaseExtracting
+	case status == "Pushing":
+		return domain.PhaseUploading
+	case status == "Already exists", status == "Layer already exists", strings.HasPrefix(status, "Mounted from"):
+		return domain.PhaseExists
+	case status == "Pull complete", status == "Pushed":
+		return domain.PhaseComplete
+	default:
+		return domain.PhaseOther
+	}
+}
+
+// encodeRegistryAuth builds the X-Registry-Auth value the daemon expects.
+func encodeRegistryAuth(cred *domain.RegistryCredential) (string, error) {
+	if cred == nil {
+		return "", nil
+	}
+	buf, err := json.Marshal(authConfig(*cred))
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
+
+// authConfigs keys credentials by server address for builds, which may pull
+// from several registries.
+func authConfigs(creds []domain.RegistryCredential) map[string]types.AuthConfig {
+	if len(creds) == 0 {
+		return nil
+	}
+	out := make(map[string]types.AuthConfig, len(creds))
+	for _, c := range creds {
+		ac := authConfig(c)
+		out[ac.ServerAddress] = ac
+	}
+	return out
+}
+
+// authConfig converts cred, using the legacy index address the daemon
+// expects for Docker Hub.
+func authConfig(cred domain.RegistryCredential) types.AuthConfig {
+	addr := cred.Registry
+	if domain.NormalizeRegistryHost(addr) == domain.DockerHubHost {
+		addr = "https://index.docker.io/v1/"
+	}
+	return types.AuthConfig{
+		Username:      cred.Username,
+		Password:      cred.Password,
+		IdentityToken: cred.IdentityToken,
+		ServerAddress: addr,
+	}
+}
@@ -44,14 +44,38 @@ func (r *ContainerRepository) ListActive(ctx context.Context, all bool) ([]*doma
 		r.log.ErrorContext(ctx, "container list failed", "error", err)
 		return nil, err
 	}
+	health, err := r.containerHealth(ctx, all)
+	if err != nil {
+		r.log.WarnContext(ctx, "container health lookup failed, listing without health", "error", err)
+	}
 	out := make([]*domain.Container, 0, len(raw))
 	for i := range raw {
-		out = append(out, mapContainerToDomain(&raw[i]))
+		c := mapContainerToDomain(&raw[i])
+		c.Health = health[c.ID]
+		out = append(out, c)
 	}
 	r.log.DebugContext(ctx, "containers listed", "count", len(out), "all", all)
 	return out, nil
 }
 
+// containerHealth maps container IDs to their health status. The list API
+// only carries health in the human-readable Status text, so the daemon is
+// asked for the containers in each state with the health filter, which
+// reads the same structured state inspect reports.
+func (r *ContainerRepository) containerHealth(ctx context.Context, all bool) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, status := range []string{types.Starting, types.Healthy, types.Unhealthy} {
+		raw, err := r.cli.ContainerList(ctx, types.ContainerListOptions{All: all, Filters: filters.NewArgs(filters.Arg("health", status))})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range raw {
+			out[c.ID] = status
+		}
+	}
+	return out, nil
+}
+
 func (r *ImageRepository) List(ctx context.Context) ([]*domain.Image, error) {
 	raw, err := r.cli.ImageList(ctx, types.ImageListOptions{})
 	if err != nil {
@@ -80,6 +104,21 @@ func (r *VolumeRepository) List(ctx context.Context) ([]*domain.Volume, error) {
 	return out, nil
 }
 
+func (r *VolumeRepository) VolumeUsage(ctx context.Context) (map[string]domain.VolumeUsageData, error) {
+	du, err := r.cli.DiskUsage(ctx)
+	if err != nil {
+		r.log.ErrorContext(ctx, "disk usage failed", "error", err)
+		return nil, err
+	}
+	out := make(map[string]domain.VolumeUsageData, len(du.Volumes))
+	for _, v := range du.Volumes {
+		if v != nil && v.UsageData != nil {
+			out[v.Name] = domain.VolumeUsageData{Size: v.UsageData.Size, RefCount: v.UsageData.RefCount}
+		}
+	}
+	return out, nil
+}
+
 func mapContainerToDomain(c *types.Container) *domain.Container {
 	ports := make([]domain.PortBinding, 0, len(c.Ports))
 	for _, p := range c.Ports {
@@ -94,8 +133,10 @@ func mapContainerToDomain(c *types.Container) *domain.Container {
 	for _, m := range c.Mounts {
 		mounts = append(mounts, domain.Mount{
 			Type:   string(m.Type),
+			Name:   m.Name,
 			Source: m.Source,
 			Target: m.Destination,
+			RW:     m.RW,
 		})
 	}
 	var hostCfg *domain.HostConfig
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// healthDaemon lists containers whose Status text disagrees with the
+// structured health the daemon filters and inspects on.
+type healthDaemon struct {
+	health map[string]string
+}
+
+func (d *healthDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path[strings.Index(r.URL.Path[1:], "/")+1:] // drop /v1.41
+	switch {
+	case path == "/containers/json":
+		args, err := filters.FromJSON(r.URL.Query().Get("filters"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		list := []map[string]any{}
+		for _, c := range []map[string]any{
+			{"Id": "c1", "Names": []string{"/web"}, "State": "running", "Status": "Up 5 minutes (healthy)"},
+			{"Id": "c2", "Names": []string{"/db"}, "State": "running", "Status": "Up 2 hours (unhealthy)"},
+		} {
+			if args.Contains("health") && !args.ExactMatch("health", d.health[c["Id"].(string)]) {
+				continue
+			}
+			list = append(list, c)
+		}
+		json.NewEncoder(w).Encode(list)
+	case path == "/containers/c1/json":
+		json.NewEncoder(w).Encode(map[string]any{
+			"Id":     "c1",
+			"Name":   "/web",
+			"State":  map[string]any{"Status": "running", "Running": true, "Health": map[string]any{"Status": d.health["c1"]}},
+			"Config": map[string]any{},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "unexpected " + r.Method + " " + path})
+	}
+}
+
+func TestContainerRepository_HealthFromStructuredState(t *testing.T) {
+	srv := httptest.NewServer(&healthDaemon{health: map[string]string{"c1": domain.HealthUnhealthy}})
+	defer srv.Close()
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(srv.URL, "http://")), client.WithVersion("1.41"))
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := NewContainerRepository(cli, log)
+	ctx := context.Background()
+
+	list, err := repo.ListActive(ctx, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 containers, got %d", len(list))
+	}
+	details, err := repo.Inspect(ctx, "c1")
+	if err != nil {
+		t.Fatalf("inspect: %v", err)
+	}
+	if list[0].Health != domain.HealthUnhealthy || list[0].Health != details.State.Health.Status {
+		t.Errorf("list health %q, inspect health %q; the Status text must not decide", list[0].Health, details.State.Health.Status)
+	}
+	if list[1].Health != "" {
+		t.Errorf("container without a healthcheck reported %q from its Status text", list[1].Health)
+	}
+}
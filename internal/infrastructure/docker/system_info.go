@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"log/slog"
+	"sync"
 
 	"github.com/docker/docker/client"
 	"github.com/dockscope/dockscope/internal/domain"
@@ -11,6 +12,11 @@ import (
 type SystemInfoProvider struct {
 	cli *client.Client
 	log *slog.Logger
+
+	mu sync.Mutex
+	// platform caches the daemon's platform, which cannot change while it
+	// runs.
+	platform *domain.Platform
 }
 
 func NewSystemInfoProvider(cli *client.Client, log *slog.Logger) *SystemInfoProvider {
@@ -25,4 +31,25 @@ func (s *SystemInfoProvider) GetMemTotal(ctx context.Context) (uint64, error) {
 	return uint64(info.MemTotal), nil
 }
 
-var _ domain.SystemInfoProvider = (*SystemInfoProvider)(nil)
+func (s *SystemInfoProvider) DaemonPlatform(ctx context.Context) (*domain.Platform, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.platform != nil {
+		p := *s.platform
+		return &p, nil
+	}
+	// Unlike Info's uname-style "x86_64", the version reports GOARCH names,
+	// as images do.
+	v, err := s.cli.ServerVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.platform = &domain.Platform{OS: v.Os, Architecture: v.Arch}
+	p := *s.platform
+	return &p, nil
+}
+
+var (
+	_ domain.SystemInfoProvider     = (*SystemInfoProvider)(nil)
+	_ domain.DaemonPlatformProvider = (*SystemInfoProvider)(nil)
+)
@@ -15,3 +15,11 @@ func timeFromUnixNano(nano int64) time.Time {
 	}
 	return time.Unix(0, nano)
 }
+
+func timeFromRFC3339(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || t.Year() <= 1 {
+		return time.Time{}
+	}
+	return t
+}
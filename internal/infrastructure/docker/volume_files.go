@@ -0,0 +1,199 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	volumeHelperMount = "/volume"
+	// volumeHelperLabel marks helper containers; its value is the volume.
+	volumeHelperLabel = "io.dockscope.volume-helper"
+	// helperRemoveTimeout bounds the cleanup, which runs detached from the
+	// request so a cancelled request still removes its helper.
+	helperRemoveTimeout = 30 * time.Second
+)
+
+// VolumeFiles reaches volume contents through the archive API of helper
+// containers. Helpers are created but never started: the archive API works
+// on stopped containers, so nothing runs inside them.
+type VolumeFiles struct {
+	cli   *client.Client
+	image string
+	log   *slog.Logger
+}
+
+// NewVolumeFiles takes the image helper containers are created from; it is
+// pulled on first use if missing.
+func NewVolumeFiles(cli *client.Client, helperImage string, log *slog.Logger) *VolumeFiles {
+	return &VolumeFiles{cli: cli, image: helperImage, log: log}
+}
+
+func (f *VolumeFiles) Stat(ctx context.Context, volume, p string) (*domain.VolumeFileEntry, error) {
+	id, err := f.createHelper(ctx, volume, true)
+	if err != nil {
+		return nil, err
+	}
+	defer f.removeHelper(id)
+	st, err := f.cli.ContainerStatPath(ctx, id, path.Join(volumeHelperMount, p))
+	if err != nil {
+		return nil, mapVolumePathError(volume, p, err)
+	}
+	return volumeFileEntry(p, st), nil
+}
+
+func (f *VolumeFiles) Read(ctx context.Context, volume, p string) (io.ReadCloser, *domain.VolumeFileEntry, error) {
+	id, err := f.createHelper(ctx, volume, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, st, err := f.cli.CopyFromContainer(ctx, id, path.Join(volumeHelperMount, p))
+	if err != nil {
+		f.removeHelper(id)
+		return nil, nil, mapVolumePathError(volume, p, err)
+	}
+	return &helperStream{ReadCloser: rc, remove: func() { f.removeHelper(id) }}, volumeFileEntry(p, st), nil
+}
+
+func volumeFileEntry(p string, st types.ContainerPathStat) *domain.VolumeFileEntry {
+	return &domain.VolumeFileEntry{
+		Name:       path.Base(p),
+		Path:       p,
+		Type:       fileType(st.Mode),
+		Size:       st.Size,
+		Mode:       st.Mode.String(),
+		ModTime:    st.Mtime,
+		LinkTarget: st.LinkTarget,
+	}
+}
+
+func (f *VolumeFiles) Write(ctx context.Context, volume, dir string, archive io.Reader) error {
+	id, err := f.createHelper(ctx, volume, false)
+	if err != nil {
+		return err
+	}
+	defer f.removeHelper(id)
+	err = f.cli.CopyToContainer(ctx, id, path.Join(volumeHelperMount, dir), archive, types.CopyToContainerOptions{})
+	if err != nil {
+		f.log.WarnContext(ctx, "volume upload failed", "volume", volume, "dir", dir, "error", err)
+		return mapVolumePathError(volume, dir, err)
+	}
+	f.log.DebugContext(ctx, "files written to volume", "volume", volume, "dir", dir)
+	return nil
+}
+
+// CleanupHelpers removes helper containers left behind by a previous run
+// that did not shut down cleanly.
+func (f *VolumeFiles) CleanupHelpers(ctx context.Context) error {
+	list, err := f.cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: filters.NewArgs(filters.Arg("label", volumeHelperLabel))})
+	if err != nil {
+		return err
+	}
+	for _, c := range list {
+		f.removeHelper(c.ID)
+	}
+	return nil
+}
+
+func (f *VolumeFiles) createHelper(ctx context.Context, volume string, readOnly bool) (string, error) {
+	// Creating a container with an unknown volume would create the volume.
+	if _, err := f.cli.VolumeInspect(ctx, volume); err != nil {
+		return "", mapVolumeError(volume, err)
+	}
+	if err := f.ensureImage(ctx); err != nil {
+		return "", err
+	}
+	resp, err := f.cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:           f.image,
+			Cmd:             []string{"true"},
+			Labels:          map[string]string{volumeHelperLabel: volume},
+			NetworkDisabled: true,
+		},
+		&container.HostConfig{
+			Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: volume, Target: volumeHelperMount, ReadOnly: readOnly}},
+		},
+		nil, nil, "")
+	if err != nil {
+		f.log.ErrorContext(ctx, "volume helper create failed", "volume", volume, "error", err)
+		return "", fmt.Errorf("create helper container for volume %s: %w", volume, err)
+	}
+	f.log.DebugContext(ctx, "volume helper created", "volume", volume, "container_id", resp.ID, "read_only", readOnly)
+	return resp.ID, nil
+}
+
+func (f *VolumeFiles) removeHelper(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), helperRemoveTimeout)
+	defer cancel()
+	if err := f.cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil && !client.IsErrNotFound(err) {
+		f.log.Warn("volume helper remove failed", "container_id", id, "error", err)
+	}
+}
+
+func (f *VolumeFiles) ensureImage(ctx context.Context) error {
+	if _, _, err := f.cli.ImageInspectWithRaw(ctx, f.image); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return err
+	}
+	f.log.InfoContext(ctx, "pulling volume helper image", "image", f.image)
+	body, err := f.cli.ImagePull(ctx, f.image, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("pull volume helper image %s: %w", f.image, mapImageError(f.image, err))
+	}
+	defer body.Close()
+	return decodeJSONMessages(body, nil)
+}
+
+// helperStream removes its helper container once, when closed.
+type helperStream struct {
+	io.ReadCloser
+	once   sync.Once
+	remove func()
+}
+
+func (s *helperStream) Close() error {
+	err := s.ReadCloser.Close()
+	s.once.Do(s.remove)
+	return err
+}
+
+func fileType(mode os.FileMode) string {
+	switch {
+	case mode.IsDir():
+		return domain.FileTypeDir
+	case mode&os.ModeSymlink != 0:
+		return domain.FileTypeSymlink
+	case mode.IsRegular():
+		return domain.FileTypeFile
+	default:
+		return domain.FileTypeOther
+	}
+}
+
+func mapVolumePathError(volume, p string, err error) error {
+	switch {
+	case client.IsErrNotFound(err):
+		return fmt.Errorf("%s in volume %s: %w", p, volume, domain.ErrNotFound)
+	case errdefs.IsInvalidParameter(err), errdefs.IsForbidden(err):
+		return fmt.Errorf("%s: %w", err.Error(), domain.ErrInvalidArgument)
+	default:
+		return err
+	}
+}
+
+var _ domain.VolumeFileAccess = (*VolumeFiles)(nil)
@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// fakeDaemon answers the calls a volume helper makes, on a host that does
+// not have the helper image yet.
+type fakeDaemon struct {
+	mu      sync.Mutex
+	pulled  bool
+	removed []string
+}
+
+func (d *fakeDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	path := r.URL.Path[strings.Index(r.URL.Path[1:], "/")+1:] // drop /v1.41
+	switch {
+	case r.Method == http.MethodGet && path == "/volumes/app-data":
+		json.NewEncoder(w).Encode(map[string]string{"Name": "app-data", "Driver": "local"})
+	case r.Method == http.MethodGet && path == "/images/busybox:latest/json":
+		if !d.pulled {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"message": "No such image: busybox:latest"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"Id": "sha256:busybox"})
+	case r.Method == http.MethodPost && path == "/images/create":
+		d.pulled = true
+		enc := json.NewEncoder(w)
+		enc.Encode(map[string]string{"status": "Pulling from library/busybox", "id": "latest"})
+		enc.Encode(map[string]string{"status": "Pull complete", "id": "abc"})
+		enc.Encode(map[string]string{"status": "Status: Downloaded newer image for busybox:latest"})
+	case r.Method == http.MethodPost && path == "/containers/create":
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"Id": "helper1"})
+	case r.Method == http.MethodHead && path == "/containers/helper1/archive":
+		stat, _ := json.Marshal(map[string]any{"name": "data", "size": 4096, "mode": uint32(os.ModeDir | 0o755), "mtime": "2026-10-18T02:00:00Z"})
+		w.Header().Set("X-Docker-Container-Path-Stat", base64.StdEncoding.EncodeToString(stat))
+	case r.Method == http.MethodDelete && path == "/containers/helper1":
+		d.removed = append(d.removed, "helper1")
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "unexpected " + r.Method + " " + path})
+	}
+}
+
+func TestVolumeFiles_PullsMissingHelperImage(t *testing.T) {
+	d := &fakeDaemon{}
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(srv.URL, "http://")), client.WithVersion("1.41"))
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	f := NewVolumeFiles(cli, "busybox:latest", log)
+
+	e, err := f.Stat(context.Background(), "app-data", "/data")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Type != domain.FileTypeDir || e.Name != "data" {
+		t.Errorf("entry = %+v", e)
+	}
+	if !d.pulled {
+		t.Error("helper image was not pulled")
+	}
+	if len(d.removed) != 1 {
+		t.Errorf("helpers removed = %v", d.removed)
+	}
+}
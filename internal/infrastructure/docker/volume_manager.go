@@ -0,0 +1,97 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type VolumeManager struct {
+	cli *client.Client
+	log *slog.Logger
+}
+
+func NewVolumeManager(cli *client.Client, log *slog.Logger) *VolumeManager {
+	return &VolumeManager{cli: cli, log: log}
+}
+
+func (m *VolumeManager) Create(ctx context.Context, opts domain.VolumeCreateOptions) (*domain.Volume, error) {
+	raw, err := m.cli.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Name:       opts.Name,
+		Driver:     opts.Driver,
+		DriverOpts: opts.DriverOpts,
+		Labels:     opts.Labels,
+	})
+	if err != nil {
+		m.log.WarnContext(ctx, "volume create failed", "name", opts.Name, "driver", opts.Driver, "error", err)
+		return nil, mapVolumeError(opts.Name, err)
+	}
+	m.log.InfoContext(ctx, "volume created", "name", raw.Name, "driver", raw.Driver)
+	return mapVolumeToDomain(&raw), nil
+}
+
+func (m *VolumeManager) Remove(ctx context.Context, name string, force bool) error {
+	if err := m.cli.VolumeRemove(ctx, name, force); err != nil {
+		if errdefs.IsConflict(err) {
+			return m.inUseError(ctx, name, err)
+		}
+		m.log.WarnContext(ctx, "volume remove failed", "name", name, "error", err)
+		return mapVolumeError(name, err)
+	}
+	m.log.InfoContext(ctx, "volume removed", "name", name)
+	return nil
+}
+
+func (m *VolumeManager) Prune(ctx context.Context, filter domain.VolumePruneFilter) (*domain.VolumePruneResult, error) {
+	args := filters.NewArgs()
+	for _, l := range filter.Labels {
+		args.Add("label", l)
+	}
+	for _, l := range filter.ExcludeLabels {
+		args.Add("label!", l)
+	}
+	report, err := m.cli.VolumesPrune(ctx, args)
+	if err != nil {
+		m.log.WarnContext(ctx, "volume prune failed", "error", err)
+		return nil, mapVolumeError("", err)
+	}
+	res := &domain.VolumePruneResult{Deleted: nonNil(report.VolumesDeleted), SpaceReclaimed: report.SpaceReclaimed}
+	m.log.InfoContext(ctx, "volumes pruned", "deleted", len(res.Deleted), "space_reclaimed", res.SpaceReclaimed)
+	return res, nil
+}
+
+// inUseError lists the containers, running or stopped, that mount the
+// volume.
+func (m *VolumeManager) inUseError(ctx context.Context, name string, cause error) error {
+	list, err := m.cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: filters.NewArgs(filters.Arg("volume", name))})
+	if err != nil || len(list) == 0 {
+		return fmt.Errorf("%s: %w", cause.Error(), domain.ErrConflict)
+	}
+	inUse := &domain.InUseError{Resource: "volume " + name, Containers: []string{}}
+	for _, c := range list {
+		inUse.Containers = append(inUse.Containers, c.ID)
+	}
+	return inUse
+}
+
+func mapVolumeError(name string, err error) error {
+	switch {
+	case client.IsErrNotFound(err):
+		return fmt.Errorf("volume %s: %w", name, domain.ErrNotFound)
+	case errdefs.IsInvalidParameter(err):
+		return fmt.Errorf("%s: %w", err.Error(), domain.ErrInvalidArgument)
+	case errdefs.IsConflict(err):
+		return fmt.Errorf("%s: %w", err.Error(), domain.ErrConflict)
+	default:
+		return err
+	}
+}
+
+var _ domain.VolumeManager = (*VolumeManager)(nil)
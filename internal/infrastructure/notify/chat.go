@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func (s *Sender) sendChat(ctx context.Context, chType string, cfg *domain.ChatConfig, n *domain.Notification) error {
+	payload := chatPayload(chType, cfg, n)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DockScope")
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return checkHTTPStatus(resp)
+}
+
+func chatPayload(chType string, cfg *domain.ChatConfig, n *domain.Notification) map[string]string {
+	bold := "*"
+	if chType == domain.ChannelTypeDiscord || chType == domain.ChannelTypeMattermost {
+		bold = "**"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s[%s] %s%s", bold, strings.ToUpper(n.Severity), n.Title, bold)
+	if n.Message != "" {
+		fmt.Fprintf(&b, "\n%s", n.Message)
+	}
+	if n.ContainerName != "" {
+		fmt.Fprintf(&b, "\nContainer: %s", n.ContainerName)
+	}
+
+	payload := map[string]string{}
+	if chType == domain.ChannelTypeDiscord {
+		payload["content"] = b.String()
+	} else {
+		payload["text"] = b.String()
+		if cfg.Channel != "" {
+			payload["channel"] = cfg.Channel
+		}
+	}
+	if cfg.Username != "" {
+		payload["username"] = cfg.Username
+	}
+	return payload
+}
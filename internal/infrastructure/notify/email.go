@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	defaultSubjectTemplate = `[DockScope] {{.Severity}}: {{.Title}}`
+	defaultBodyTemplate    = `{{.Title}}
+
+{{.Message}}
+{{if .ContainerName}}
+Container: {{.ContainerName}} ({{.ContainerID}}){{end}}{{if .Rule}}
+Rule: {{.Rule}}{{end}}
+Time: {{.Timestamp.Format "2006-01-02 15:04:05 MST"}}
+`
+	smtpTimeout = 15 * time.Second
+)
+
+func sendEmail(ctx context.Context, cfg *domain.EmailConfig, n *domain.Notification) error {
+	subject, err := renderTemplate("subject", cfg.SubjectTemplate, defaultSubjectTemplate, n)
+	if err != nil {
+		return permanent(err)
+	}
+	body, err := renderTemplate("body", cfg.BodyTemplate, defaultBodyTemplate, n)
+	if err != nil {
+		return permanent(err)
+	}
+	msg := buildMessage(cfg.From, cfg.To, strings.TrimSpace(subject), body)
+
+	port := cfg.Port
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return permanent(err)
+		}
+	}
+	if err := c.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, to := range cfg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func renderTemplate(name, text, fallback string, n *domain.Notification) (string, error) {
+	if text == "" {
+		text = fallback
+	}
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse %s template: %w", name, err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, n); err != nil {
+		return "", fmt.Errorf("render %s template: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+func buildMessage(from string, to []string, subject, body string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return b.Bytes()
+}
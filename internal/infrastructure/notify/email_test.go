@@ -0,0 +1,131 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// fakeSMTP accepts a single session and records the envelope and message.
+type fakeSMTP struct {
+	ln   net.Listener
+	from string
+	rcpt []string
+	data string
+	done chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeSMTP{ln: ln, done: make(chan struct{})}
+	go f.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return f
+}
+
+func (f *fakeSMTP) port() int {
+	return f.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSMTP) serve() {
+	defer close(f.done)
+	conn, err := f.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			f.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			f.rcpt = append(f.rcpt, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			f.data = b.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 unsupported")
+		}
+	}
+}
+
+func TestSender_Email(t *testing.T) {
+	srv := newFakeSMTP(t)
+	ch := &domain.NotificationChannel{
+		ID:   "mail",
+		Type: domain.ChannelTypeEmail,
+		Email: &domain.EmailConfig{
+			Host:            "127.0.0.1",
+			Port:            srv.port(),
+			From:            "dockscope@example.com",
+			To:              []string{"ops@example.com", "dev@example.com"},
+			SubjectTemplate: "ALERT {{.ContainerName}}",
+		},
+	}
+	if err := newTestSender().Send(context.Background(), ch, testNotification()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-srv.done
+
+	if srv.from != "dockscope@example.com" {
+		t.Errorf("from: got %q", srv.from)
+	}
+	if len(srv.rcpt) != 2 {
+		t.Errorf("rcpt: got %v", srv.rcpt)
+	}
+	if !strings.Contains(srv.data, "Subject: ALERT web\r\n") {
+		t.Errorf("subject not rendered:\n%s", srv.data)
+	}
+	if !strings.Contains(srv.data, "web exited with code 1") {
+		t.Errorf("body not rendered:\n%s", srv.data)
+	}
+}
+
+func TestSender_EmailBadTemplate(t *testing.T) {
+	ch := &domain.NotificationChannel{
+		ID:    "mail",
+		Type:  domain.ChannelTypeEmail,
+		Email: &domain.EmailConfig{Host: "127.0.0.1", Port: 1, From: "a@b", To: []string{"c@d"}, BodyTemplate: "{{.Nope"},
+	}
+	err := newTestSender().Send(context.Background(), ch, testNotification())
+	if err == nil || !strings.Contains(err.Error(), "parse body template") {
+		t.Errorf("expected template error, got %v", err)
+	}
+}
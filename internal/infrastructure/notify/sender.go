@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = time.Second
+	maxRetryDelay     = 30 * time.Second
+	httpTimeout       = 10 * time.Second
+)
+
+// Sender delivers notifications through the configured channel type, retrying
+// transient failures with exponential backoff and enforcing per-channel rate
+// limits.
+type Sender struct {
+	http       *http.Client
+	retryDelay time.Duration
+	log        *slog.Logger
+
+	mu       sync.Mutex
+	limiters map[string]*rateLimiter
+}
+
+func NewSender(log *slog.Logger) *Sender {
+	return &Sender{
+		http:       &http.Client{Timeout: httpTimeout},
+		retryDelay: defaultRetryDelay,
+		log:        log,
+		limiters:   make(map[string]*rateLimiter),
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, ch *domain.NotificationChannel, n *domain.Notification) error {
+	if !s.allow(ch) {
+		s.log.WarnContext(ctx, "notification rate limited", "channel_id", ch.ID, "limit_per_minute", ch.RateLimitPerMinute)
+		return domain.ErrRateLimited
+	}
+
+	retries := ch.MaxRetries
+	if retries <= 0 {
+		retries = defaultMaxRetries
+	}
+	delay := s.retryDelay
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, maxRetryDelay)
+		}
+		err = s.deliver(ctx, ch, n)
+		if err == nil {
+			s.log.DebugContext(ctx, "notification delivered", "channel_id", ch.ID, "type", ch.Type, "attempt", attempt+1)
+			return nil
+		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			break
+		}
+		s.log.DebugContext(ctx, "notification delivery failed", "channel_id", ch.ID, "type", ch.Type, "attempt", attempt+1, "error", err)
+	}
+	s.log.WarnContext(ctx, "notification delivery gave up", "channel_id", ch.ID, "type", ch.Type, "error", err)
+	return err
+}
+
+func (s *Sender) deliver(ctx context.Context, ch *domain.NotificationChannel, n *domain.Notification) error {
+	switch ch.Type {
+	case domain.ChannelTypeWebhook:
+		if ch.Webhook == nil {
+			return permanent(errors.New("webhook channel without webhook config"))
+		}
+		return s.sendWebhook(ctx, ch.Webhook, n)
+	case domain.ChannelTypeEmail:
+		if ch.Email == nil {
+			return permanent(errors.New("email channel without email config"))
+		}
+		return sendEmail(ctx, ch.Email, n)
+	case domain.ChannelTypeSlack, domain.ChannelTypeMattermost, domain.ChannelTypeDiscord:
+		if ch.Chat == nil {
+			return permanent(errors.New("chat channel without chat config"))
+		}
+		return s.sendChat(ctx, ch.Type, ch.Chat, n)
+	default:
+		return permanent(fmt.Errorf("unsupported channel type %q", ch.Type))
+	}
+}
+
+func (s *Sender) allow(ch *domain.NotificationChannel) bool {
+	if ch.RateLimitPerMinute <= 0 {
+		return true
+	}
+	s.mu.Lock()
+	l, ok := s.limiters[ch.ID]
+	if !ok {
+		l = &rateLimiter{}
+		s.limiters[ch.ID] = l
+	}
+	s.mu.Unlock()
+	return l.allow(ch.RateLimitPerMinute, time.Now())
+}
+
+// rateLimiter is a sliding one-minute window.
+type rateLimiter struct {
+	mu   sync.Mutex
+	sent []time.Time
+}
+
+func (l *rateLimiter) allow(perMinute int, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cutoff := now.Add(-time.Minute)
+	kept := l.sent[:0]
+	for _, t := range l.sent {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	l.sent = kept
+	if len(l.sent) >= perMinute {
+		return false
+	}
+	l.sent = append(l.sent, now)
+	return true
+}
+
+// permanentError marks failures that retrying will not fix (bad config,
+// 4xx responses).
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func permanent(err error) error { return &permanentError{err: err} }
+
+func checkHTTPStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err := fmt.Errorf("unexpected status %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return permanent(err)
+	}
+	return err
+}
+
+var _ domain.Notifier = (*Sender)(nil)
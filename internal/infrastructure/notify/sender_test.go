@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func newTestSender() *Sender {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	s := NewSender(log)
+	s.retryDelay = time.Millisecond
+	return s
+}
+
+func testNotification() *domain.Notification {
+	return &domain.Notification{
+		Title:         "container stopped",
+		Message:       "web exited with code 1",
+		Severity:      domain.SeverityCritical,
+		ContainerID:   "abc123",
+		ContainerName: "web",
+		Timestamp:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestSender_WebhookSignature(t *testing.T) {
+	var gotBody []byte
+	var gotSig, gotTS, gotCustom string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSig = r.Header.Get(HeaderSignature)
+		gotTS = r.Header.Get(HeaderTimestamp)
+		gotCustom = r.Header.Get("X-Env")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ch := &domain.NotificationChannel{
+		ID:      "wh",
+		Type:    domain.ChannelTypeWebhook,
+		Webhook: &domain.WebhookConfig{URL: srv.URL, Secret: "s3cret", Headers: map[string]string{"X-Env": "prod"}},
+	}
+	if err := newTestSender().Send(context.Background(), ch, testNotification()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotSig != "sha256="+Sign("s3cret", gotTS, gotBody) {
+		t.Errorf("signature mismatch: %q", gotSig)
+	}
+	if gotCustom != "prod" {
+		t.Errorf("custom header: got %q", gotCustom)
+	}
+	var n domain.Notification
+	if err := json.Unmarshal(gotBody, &n); err != nil {
+		t.Fatalf("body is not a notification: %v", err)
+	}
+	if n.ContainerName != "web" || n.Severity != domain.SeverityCritical {
+		t.Errorf("got %+v", n)
+	}
+}
+
+func TestSender_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ch := &domain.NotificationChannel{ID: "wh", Type: domain.ChannelTypeWebhook, Webhook: &domain.WebhookConfig{URL: srv.URL}}
+	if err := newTestSender().Send(context.Background(), ch, testNotification()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestSender_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	ch := &domain.NotificationChannel{ID: "wh", Type: domain.ChannelTypeWebhook, Webhook: &domain.WebhookConfig{URL: srv.URL}}
+	if err := newTestSender().Send(context.Background(), ch, testNotification()); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestSender_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := newTestSender()
+	ch := &domain.NotificationChannel{
+		ID:                 "chat",
+		Type:               domain.ChannelTypeSlack,
+		Chat:               &domain.ChatConfig{URL: srv.URL},
+		RateLimitPerMinute: 2,
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Send(context.Background(), ch, testNotification()); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	if err := s.Send(context.Background(), ch, testNotification()); !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestChatPayload(t *testing.T) {
+	n := testNotification()
+	cfg := &domain.ChatConfig{Username: "dockscope", Channel: "#ops"}
+
+	slack := chatPayload(domain.ChannelTypeSlack, cfg, n)
+	if slack["text"] == "" || slack["channel"] != "#ops" || slack["username"] != "dockscope" {
+		t.Errorf("slack payload: %v", slack)
+	}
+	discord := chatPayload(domain.ChannelTypeDiscord, cfg, n)
+	if discord["content"] == "" || discord["text"] != "" {
+		t.Errorf("discord payload: %v", discord)
+	}
+	if _, ok := discord["channel"]; ok {
+		t.Errorf("discord payload should not carry channel: %v", discord)
+	}
+}
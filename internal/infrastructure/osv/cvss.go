@@ -0,0 +1,115 @@
+package osv
+
+import (
+	"math"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3BaseScore computes the base score of a "CVSS:3.x/AV:N/AC:L/..."
+// vector, as in section 7.1 of the CVSS v3.1 specification.
+func cvss3BaseScore(vector string) (float64, bool) {
+	parts := strings.Split(vector, "/")
+	if len(parts) < 9 || !strings.HasPrefix(parts[0], "CVSS:3.") {
+		return 0, false
+	}
+	m := make(map[string]string)
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, ":"); ok {
+			m[k] = v
+		}
+	}
+	scopeChanged := m["S"] == "C"
+	if m["S"] != "U" && !scopeChanged {
+		return 0, false
+	}
+	w := make(map[string]float64)
+	for metric, values := range cvss3Weights {
+		v, ok := values[m[metric]]
+		if !ok {
+			return 0, false
+		}
+		w[metric] = v
+	}
+	switch m["PR"] {
+	case "N":
+		w["PR"] = 0.85
+	case "L":
+		w["PR"] = 0.62
+		if scopeChanged {
+			w["PR"] = 0.68
+		}
+	case "H":
+		w["PR"] = 0.27
+		if scopeChanged {
+			w["PR"] = 0.5
+		}
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-w["C"])*(1-w["I"])*(1-w["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * w["AV"] * w["AC"] * w["PR"] * w["UI"]
+	if scopeChanged {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10)), true
+	}
+	return roundUp(math.Min(impact+exploitability, 10)), true
+}
+
+// roundUp is the specification's Roundup, which avoids floating point
+// surprises such as 4.000000001 rounding to 4.1.
+func roundUp(x float64) float64 {
+	n := int64(math.Round(x * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
+
+func severityFromScore(score float64) string {
+	switch {
+	case score >= 9:
+		return domain.VulnSeverityCritical
+	case score >= 7:
+		return domain.VulnSeverityHigh
+	case score >= 4:
+		return domain.VulnSeverityMedium
+	case score > 0:
+		return domain.VulnSeverityLow
+	}
+	return domain.VulnSeverityUnknown
+}
+
+// severityFromLabel maps the free-form severities advisories carry in
+// database_specific or ecosystem_specific (GitHub's "MODERATE", Ubuntu's
+// "negligible", Red Hat's "Important", ...).
+func severityFromLabel(label string) string {
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "critical":
+		return domain.VulnSeverityCritical
+	case "high", "important":
+		return domain.VulnSeverityHigh
+	case "medium", "moderate":
+		return domain.VulnSeverityMedium
+	case "low", "negligible", "unimportant":
+		return domain.VulnSeverityLow
+	}
+	return domain.VulnSeverityUnknown
+}
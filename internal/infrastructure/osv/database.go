@@ -0,0 +1,490 @@
+// Package osv matches package inventories against advisories in the OSV
+// format (https://ossf.github.io/osv-schema/) read from a local directory,
+// so images can be checked for known vulnerabilities without network access.
+package osv
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	maxAdvisoryBytes = 16 << 20
+	maxLoadErrors    = 20
+)
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type osvSpecific struct {
+	Severity any `json:"severity"`
+}
+
+type osvEvent struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+	Limit        string `json:"limit"`
+}
+
+func (e osvEvent) version() string {
+	return e.Introduced + e.Fixed + e.LastAffected + e.Limit
+}
+
+type osvRange struct {
+	Type   string     `json:"type"`
+	Events []osvEvent `json:"events"`
+}
+
+type osvAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Severity          []osvSeverity `json:"severity"`
+	Ranges            []osvRange    `json:"ranges"`
+	Versions          []string      `json:"versions"`
+	EcosystemSpecific osvSpecific   `json:"ecosystem_specific"`
+	DatabaseSpecific  osvSpecific   `json:"database_specific"`
+}
+
+type osvAdvisory struct {
+	ID               string        `json:"id"`
+	Summary          string        `json:"summary"`
+	Aliases          []string      `json:"aliases"`
+	Withdrawn        string        `json:"withdrawn"`
+	Severity         []osvSeverity `json:"severity"`
+	Affected         []osvAffected `json:"affected"`
+	DatabaseSpecific osvSpecific   `json:"database_specific"`
+}
+
+// advisory is the part of an OSV record findings report.
+type advisory struct {
+	id       string
+	summary  string
+	aliases  []string
+	severity string
+	score    float64
+}
+
+// entry is one affected package of an advisory.
+type entry struct {
+	adv *advisory
+	// releases are the ":"-separated parts of the ecosystem after its
+	// name, e.g. "12" in "Debian:12".
+	releases []string
+	ranges   []osvRange
+	versions []string
+	severity string
+	score    float64
+}
+
+// Database holds the advisories of a directory of OSV JSON files, or zips
+// of them as osv.dev publishes per ecosystem, indexed by ecosystem and
+// package name.
+type Database struct {
+	dir string
+	log *slog.Logger
+
+	reload sync.Mutex
+	mu     sync.RWMutex
+	index  map[string]map[string][]*entry
+	status domain.VulnerabilityDBStatus
+}
+
+func NewDatabase(dir string, log *slog.Logger) *Database {
+	return &Database{
+		dir:    dir,
+		log:    log,
+		index:  make(map[string]map[string][]*entry),
+		status: domain.VulnerabilityDBStatus{Dir: dir, Errors: []string{}},
+	}
+}
+
+func (d *Database) Status() *domain.VulnerabilityDBStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	st := d.status
+	return &st
+}
+
+// Reload reads the directory again and swaps the index in once done, so
+// matching keeps using the previous advisories meanwhile. Unreadable files
+// are reported in the status and skipped.
+func (d *Database) Reload(ctx context.Context) (*domain.VulnerabilityDBStatus, error) {
+	d.reload.Lock()
+	defer d.reload.Unlock()
+
+	if info, err := os.Stat(d.dir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("osv directory %s: %w", d.dir, domain.ErrNotFound)
+	}
+	l := &loader{index: make(map[string]map[string][]*entry), status: domain.VulnerabilityDBStatus{Dir: d.dir, Errors: []string{}}}
+	err := filepath.WalkDir(d.dir, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			l.fail(path, err)
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			f, err := os.Open(path)
+			if err != nil {
+				l.fail(path, err)
+				return nil
+			}
+			defer f.Close()
+			l.load(path, f)
+		case ".zip":
+			l.loadZip(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.status.LoadedAt = time.Now().UTC()
+
+	d.mu.Lock()
+	d.index, d.status = l.index, l.status
+	d.mu.Unlock()
+	d.log.InfoContext(ctx, "osv database loaded", "dir", d.dir, "files", l.status.Files, "advisories", l.status.Advisories, "errors", len(l.status.Errors))
+	st := l.status
+	return &st, nil
+}
+
+type loader struct {
+	index  map[string]map[string][]*entry
+	status domain.VulnerabilityDBStatus
+	failed int
+}
+
+func (l *loader) fail(name string, err error) {
+	l.failed++
+	switch {
+	case l.failed <= maxLoadErrors:
+		l.status.Errors = append(l.status.Errors, fmt.Sprintf("%s: %v", name, err))
+	case l.failed == maxLoadErrors+1:
+		l.status.Errors = append(l.status.Errors, "further errors omitted")
+	}
+}
+
+func (l *loader) loadZip(path string) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		l.fail(path, err)
+		return
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if !strings.HasSuffix(strings.ToLower(f.Name), ".json") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			l.fail(path+"!"+f.Name, err)
+			continue
+		}
+		l.load(path+"!"+f.Name, rc)
+		rc.Close()
+	}
+}
+
+// load accepts a single advisory or an array of them.
+func (l *loader) load(name string, r io.Reader) {
+	data, err := io.ReadAll(io.LimitReader(r, maxAdvisoryBytes))
+	if err != nil {
+		l.fail(name, err)
+		return
+	}
+	var advisories []osvAdvisory
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &advisories)
+	} else {
+		var a osvAdvisory
+		err = json.Unmarshal(data, &a)
+		advisories = []osvAdvisory{a}
+	}
+	if err != nil {
+		l.fail(name, err)
+		return
+	}
+	l.status.Files++
+	for i := range advisories {
+		if l.add(&advisories[i]) {
+			l.status.Advisories++
+		}
+	}
+}
+
+func (l *loader) add(a *osvAdvisory) bool {
+	if a.ID == "" || a.Withdrawn != "" || len(a.Affected) == 0 {
+		return false
+	}
+	adv := &advisory{id: a.ID, summary: a.Summary, aliases: a.Aliases}
+	adv.severity, adv.score = severityOf(a.Severity, a.DatabaseSpecific)
+	for _, af := range a.Affected {
+		eco, release, _ := strings.Cut(af.Package.Ecosystem, ":")
+		if eco == "" || af.Package.Name == "" {
+			continue
+		}
+		e := &entry{adv: adv, ranges: af.Ranges, versions: af.Versions}
+		if release != "" {
+			e.releases = strings.Split(release, ":")
+		}
+		e.severity, e.score = severityOf(af.Severity, af.EcosystemSpecific, af.DatabaseSpecific)
+		byName := l.index[eco]
+		if byName == nil {
+			byName = make(map[string][]*entry)
+			l.index[eco] = byName
+		}
+		name := normalizeName(eco, af.Package.Name)
+		byName[name] = append(byName[name], e)
+	}
+	return true
+}
+
+// severityOf prefers a CVSS v3 vector and falls back to a severity label.
+func severityOf(severities []osvSeverity, specific ...osvSpecific) (string, float64) {
+	for _, s := range severities {
+		if s.Type != "CVSS_V3" {
+			continue
+		}
+		if score, ok := cvss3BaseScore(s.Score); ok {
+			return severityFromScore(score), score
+		}
+	}
+	for _, s := range specific {
+		if label, ok := s.Severity.(string); ok {
+			if sev := severityFromLabel(label); sev != domain.VulnSeverityUnknown {
+				return sev, 0
+			}
+		}
+	}
+	return "", 0
+}
+
+var pypiSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName applies PEP 503 normalization to PyPI names; other
+// ecosystems compare names as they are.
+func normalizeName(ecosystem, name string) string {
+	if ecosystem == "PyPI" {
+		return pypiSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	}
+	return name
+}
+
+var rpmEcosystems = map[string]string{
+	"rhel":                "Red Hat",
+	"rocky":               "Rocky Linux",
+	"almalinux":           "AlmaLinux",
+	"sles":                "SUSE",
+	"sles_sap":            "SUSE",
+	"opensuse-leap":       "openSUSE",
+	"opensuse-tumbleweed": "openSUSE",
+	"mageia":              "Mageia",
+}
+
+// packageEcosystem returns the OSV ecosystem of a package, the releases
+// its advisories may name and the name they list it under: Debian, Ubuntu
+// and Alpine advisories name source packages.
+func packageEcosystem(p *domain.Package, distro *domain.Distro) (string, []string, string) {
+	switch p.Type {
+	case domain.PackageTypeGolang:
+		return "Go", nil, p.Name
+	case domain.PackageTypeNPM:
+		return "npm", nil, p.Name
+	case domain.PackageTypePyPI:
+		return "PyPI", nil, p.Name
+	}
+	if distro == nil {
+		return "", nil, ""
+	}
+	source := p.Name
+	if p.SourceName != "" {
+		source = p.SourceName
+	}
+	major, _, _ := strings.Cut(distro.VersionID, ".")
+	switch {
+	case p.Type == domain.PackageTypeDeb && distro.ID == "debian":
+		return "Debian", []string{major}, source
+	case p.Type == domain.PackageTypeDeb && distro.ID == "ubuntu":
+		return "Ubuntu", []string{distro.VersionID}, source
+	case p.Type == domain.PackageTypeAPK && distro.ID == "alpine":
+		parts := strings.SplitN(distro.VersionID, ".", 3)
+		return "Alpine", []string{"v" + strings.Join(parts[:min(len(parts), 2)], ".")}, source
+	case p.Type == domain.PackageTypeRPM && rpmEcosystems[distro.ID] != "":
+		return rpmEcosystems[distro.ID], []string{major, strings.ToLower(strings.ReplaceAll(distro.VersionID, "-", " "))}, p.Name
+	}
+	return "", nil, ""
+}
+
+// matchesRelease accepts advisories for the whole ecosystem and those whose
+// release part names the distro release: "Debian:12", "Alpine:v3.19",
+// "Red Hat:enterprise_linux:9::appstream" or "SUSE:Linux Enterprise
+// Server 15 SP5".
+func (e *entry) matchesRelease(releases []string) bool {
+	if len(e.releases) == 0 {
+		return true
+	}
+	for _, part := range e.releases {
+		part = strings.ToLower(part)
+		for _, r := range releases {
+			if r != "" && (part == strings.ToLower(r) || strings.HasSuffix(part, " "+strings.ToLower(r))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// affects reports whether version is affected and, if so, the nearest
+// fixed version above it.
+func (e *entry) affects(ecosystem, version string) (bool, string) {
+	cmp := ecosystemComparators[ecosystem]
+	for _, v := range e.versions {
+		if v == version || (cmp != nil && cmp(v, version) == 0) {
+			return true, ""
+		}
+	}
+	for _, r := range e.ranges {
+		c := cmp
+		if r.Type == "SEMVER" {
+			c = compareSemver
+		} else if r.Type != "ECOSYSTEM" {
+			continue
+		}
+		if c == nil {
+			continue
+		}
+		if ok, fixed := inRange(c, r.Events, version); ok {
+			return true, fixed
+		}
+	}
+	return false, ""
+}
+
+// inRange evaluates the events in version order, as the OSV schema
+// describes: an introduced event at or below version turns it affected,
+// a fixed or limit event at or below it (or a last_affected below it)
+// turns it back.
+func inRange(cmp compareFunc, events []osvEvent, version string) (bool, string) {
+	sorted := append([]osvEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Introduced == "0" || b.Introduced == "0" {
+			return a.Introduced == "0" && b.Introduced != "0"
+		}
+		return cmp(a.version(), b.version()) < 0
+	})
+	affected := false
+	fixed := ""
+	for _, ev := range sorted {
+		switch {
+		case ev.Introduced != "":
+			if ev.Introduced == "0" || cmp(version, ev.Introduced) >= 0 {
+				affected = true
+			}
+		case ev.Fixed != "":
+			if cmp(version, ev.Fixed) >= 0 {
+				affected = false
+			} else if affected && fixed == "" {
+				fixed = ev.Fixed
+			}
+		case ev.LastAffected != "":
+			if cmp(version, ev.LastAffected) > 0 {
+				affected = false
+			}
+		case ev.Limit != "":
+			if cmp(version, ev.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	if !affected {
+		fixed = ""
+	}
+	return affected, fixed
+}
+
+func (d *Database) Match(distro *domain.Distro, pkgs []domain.Package) []domain.VulnerabilityFinding {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	findings := []domain.VulnerabilityFinding{}
+	seen := make(map[string]bool)
+	for i := range pkgs {
+		p := &pkgs[i]
+		if p.Version == "" {
+			continue
+		}
+		eco, releases, name := packageEcosystem(p, distro)
+		if eco == "" {
+			continue
+		}
+		for _, e := range d.index[eco][normalizeName(eco, name)] {
+			if !e.matchesRelease(releases) {
+				continue
+			}
+			ok, fixed := e.affects(eco, p.Version)
+			key := e.adv.id + "\x00" + p.PURL + "\x00" + p.Location
+			if !ok || seen[key] {
+				continue
+			}
+			seen[key] = true
+			f := domain.VulnerabilityFinding{
+				ID:           e.adv.id,
+				Aliases:      e.adv.aliases,
+				Summary:      e.adv.summary,
+				Severity:     e.adv.severity,
+				Score:        e.adv.score,
+				Package:      p.Name,
+				Version:      p.Version,
+				PackageType:  p.Type,
+				PURL:         p.PURL,
+				Location:     p.Location,
+				FixedVersion: fixed,
+			}
+			if e.severity != "" {
+				f.Severity, f.Score = e.severity, e.score
+			}
+			if f.Severity == "" {
+				f.Severity = domain.VulnSeverityUnknown
+			}
+			findings = append(findings, f)
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if ra, rb := domain.VulnSeverityRank(a.Severity), domain.VulnSeverityRank(b.Severity); ra != rb {
+			return ra < rb
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.PURL < b.PURL
+	})
+	return findings
+}
+
+var _ domain.VulnerabilityDatabase = (*Database)(nil)
@@ -0,0 +1,149 @@
+package osv
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const debianAdvisory = `{
+  "id": "DSA-5600-1",
+  "summary": "openssl security update",
+  "aliases": ["CVE-2024-0727"],
+  "affected": [{
+    "package": {"ecosystem": "Debian:12", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "3.0.13-1~deb12u1"}]}]
+  }]
+}`
+
+const ghsaAdvisories = `[
+  {
+    "id": "GHSA-1111",
+    "summary": "Prototype pollution in lodash",
+    "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+    "affected": [{
+      "package": {"ecosystem": "npm", "name": "lodash"},
+      "ranges": [{"type": "SEMVER", "events": [{"introduced": "4.0.0"}, {"fixed": "4.17.21"}]}]
+    }]
+  },
+  {
+    "id": "GHSA-2222",
+    "database_specific": {"severity": "MODERATE"},
+    "affected": [{
+      "package": {"ecosystem": "PyPI", "name": "Django"},
+      "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "4.2"}, {"last_affected": "4.2.7"}]}]
+    }]
+  },
+  {
+    "id": "GHSA-3333",
+    "withdrawn": "2024-01-01T00:00:00Z",
+    "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "versions": ["4.17.20"]}]
+  }
+]`
+
+const goAdvisory = `{
+  "id": "GO-2024-0001",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "golang.org/x/net"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "0.17.0"}, {"introduced": "0.18.0"}, {"fixed": "0.19.0"}]}],
+    "ecosystem_specific": {"severity": "HIGH"}
+  }]
+}`
+
+func writeTestDB(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "DSA-5600-1.json"), []byte(debianAdvisory), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ghsa.json"), []byte(ghsaAdvisories), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	zf, err := os.Create(filepath.Join(dir, "Go.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	w, _ := zw.Create("GO-2024-0001.json")
+	w.Write([]byte(goAdvisory))
+	zw.Close()
+	zf.Close()
+	return dir
+}
+
+func newTestDatabase(t *testing.T, dir string) *Database {
+	db := NewDatabase(dir, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	if _, err := db.Reload(context.Background()); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	return db
+}
+
+func TestDatabase_Reload(t *testing.T) {
+	dir := writeTestDB(t)
+	db := newTestDatabase(t, dir)
+	st := db.Status()
+	if st.Files != 3 || st.Advisories != 4 || len(st.Errors) != 1 || st.LoadedAt.IsZero() {
+		t.Errorf("status = %+v", st)
+	}
+
+	os.Remove(filepath.Join(dir, "ghsa.json"))
+	if st, err := db.Reload(context.Background()); err != nil || st.Advisories != 2 {
+		t.Errorf("after removal: %+v, %v", st, err)
+	}
+
+	missing := NewDatabase(filepath.Join(dir, "nope"), db.log)
+	if _, err := missing.Reload(context.Background()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabase_Match(t *testing.T) {
+	db := newTestDatabase(t, writeTestDB(t))
+	debian := &domain.Distro{ID: "debian", VersionID: "12"}
+	pkgs := []domain.Package{
+		{Name: "libssl3", SourceName: "openssl", Version: "3.0.11-1~deb12u2", Type: domain.PackageTypeDeb, PURL: "pkg:deb/debian/libssl3"},
+		{Name: "openssl", Version: "3.0.13-1~deb12u1", Type: domain.PackageTypeDeb, PURL: "pkg:deb/debian/openssl"},
+		{Name: "lodash", Version: "4.17.20", Type: domain.PackageTypeNPM, PURL: "pkg:npm/lodash@4.17.20"},
+		{Name: "django", Version: "4.2.7", Type: domain.PackageTypePyPI, PURL: "pkg:pypi/django@4.2.7"},
+		{Name: "golang.org/x/net", Version: "v0.18.0", Type: domain.PackageTypeGolang, PURL: "pkg:golang/golang.org/x/net@v0.18.0"},
+		{Name: "golang.org/x/net", Version: "v0.17.0", Type: domain.PackageTypeGolang, PURL: "pkg:golang/golang.org/x/net@v0.17.0"},
+	}
+	findings := db.Match(debian, pkgs)
+	got := make(map[string]domain.VulnerabilityFinding)
+	for _, f := range findings {
+		got[f.ID+" "+f.Version] = f
+	}
+	if len(findings) != 4 {
+		t.Fatalf("findings = %+v", findings)
+	}
+	if f := got["DSA-5600-1 3.0.11-1~deb12u2"]; f.Package != "libssl3" || f.FixedVersion != "3.0.13-1~deb12u1" || f.Severity != domain.VulnSeverityUnknown {
+		t.Errorf("libssl3 finding = %+v", f)
+	}
+	if f := got["GHSA-1111 4.17.20"]; f.Severity != domain.VulnSeverityCritical || f.Score != 9.8 || f.FixedVersion != "4.17.21" {
+		t.Errorf("lodash finding = %+v", f)
+	}
+	if f := got["GHSA-2222 4.2.7"]; f.Severity != domain.VulnSeverityMedium || f.FixedVersion != "" {
+		t.Errorf("django finding = %+v", f)
+	}
+	if f := got["GO-2024-0001 v0.18.0"]; f.Severity != domain.VulnSeverityHigh || f.FixedVersion != "0.19.0" {
+		t.Errorf("x/net finding = %+v", f)
+	}
+	if findings[0].ID != "GHSA-1111" {
+		t.Errorf("expected the critical finding first, got %s", findings[0].ID)
+	}
+
+	// The Debian advisory is for bookworm only.
+	if f := db.Match(&domain.Distro{ID: "debian", VersionID: "11"}, pkgs[:1]); len(f) != 0 {
+		t.Errorf("bullseye findings = %+v", f)
+	}
+}
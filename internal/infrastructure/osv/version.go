@@ -0,0 +1,461 @@
+package osv
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// compareFunc orders two versions of one ecosystem like strings.Compare.
+type compareFunc func(a, b string) int
+
+// ecosystemComparators maps OSV ecosystem names (the part before any ":")
+// to their version ordering.
+var ecosystemComparators = map[string]compareFunc{
+	"Debian":      compareDpkg,
+	"Ubuntu":      compareDpkg,
+	"Alpine":      compareAPK,
+	"Red Hat":     compareRPM,
+	"Rocky Linux": compareRPM,
+	"AlmaLinux":   compareRPM,
+	"SUSE":        compareRPM,
+	"openSUSE":    compareRPM,
+	"Mageia":      compareRPM,
+	"Go":          compareSemver,
+	"npm":         compareSemver,
+	"PyPI":        comparePEP440,
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// compareDigits compares two runs of decimal digits of any length.
+func compareDigits(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return sign(len(a) - len(b))
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+func isAlpha(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+
+func digitPrefix(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// compareDpkg implements dpkg's [epoch:]upstream[-revision] ordering, where
+// "~" sorts before everything, even the end of the string.
+func compareDpkg(a, b string) int {
+	ea, a := splitEpoch(a)
+	eb, b := splitEpoch(b)
+	if ea != eb {
+		return sign(ea - eb)
+	}
+	ua, ra := splitRevision(a)
+	ub, rb := splitRevision(b)
+	if c := dpkgVerrevcmp(ua, ub); c != 0 {
+		return c
+	}
+	return dpkgVerrevcmp(ra, rb)
+}
+
+func splitEpoch(v string) (int, string) {
+	if e, rest, ok := strings.Cut(v, ":"); ok {
+		if n, err := strconv.Atoi(e); err == nil {
+			return n, rest
+		}
+	}
+	return 0, v
+}
+
+func splitRevision(v string) (string, string) {
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+func dpkgOrder(c byte) int {
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	}
+	return int(c) + 256
+}
+
+func dpkgVerrevcmp(a, b string) int {
+	for a != "" || b != "" {
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			var ac, bc int
+			if a != "" {
+				ac = dpkgOrder(a[0])
+			}
+			if b != "" {
+				bc = dpkgOrder(b[0])
+			}
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			a, b = a[1:], b[1:]
+		}
+		var da, db string
+		da, a = digitPrefix(a)
+		db, b = digitPrefix(b)
+		if c := compareDigits(da, db); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareRPM orders [epoch:]version[-release] with rpmvercmp.
+func compareRPM(a, b string) int {
+	ea, a := splitEpoch(a)
+	eb, b := splitEpoch(b)
+	if ea != eb {
+		return sign(ea - eb)
+	}
+	va, ra := splitRevision(a)
+	vb, rb := splitRevision(b)
+	if c := rpmvercmp(va, vb); c != 0 {
+		return c
+	}
+	return rpmvercmp(ra, rb)
+}
+
+// rpmvercmp compares alternating digit and letter segments; digits are
+// newer than letters, "~" older than anything and "^" newer than the end
+// but older than any other segment.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	isSep := func(c byte) bool { return !isDigit(c) && !isAlpha(c) && c != '~' && c != '^' }
+	for a != "" || b != "" {
+		for a != "" && isSep(a[0]) {
+			a = a[1:]
+		}
+		for b != "" && isSep(b[0]) {
+			b = b[1:]
+		}
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			switch {
+			case a == "":
+				return -1
+			case b == "":
+				return 1
+			case !strings.HasPrefix(a, "^"):
+				return 1
+			case !strings.HasPrefix(b, "^"):
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+		if a == "" || b == "" {
+			break
+		}
+		var sa, sb string
+		numeric := isDigit(a[0])
+		if numeric {
+			sa, a = digitPrefix(a)
+			sb, b = digitPrefix(b)
+		} else {
+			sa, a = alphaPrefix(a)
+			sb, b = alphaPrefix(b)
+		}
+		if sb == "" {
+			// Different segment types: the numeric one is newer.
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		var c int
+		if numeric {
+			c = compareDigits(sa, sb)
+		} else {
+			c = strings.Compare(sa, sb)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+func alphaPrefix(s string) (string, string) {
+	i := 0
+	for i < len(s) && isAlpha(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// apkSuffixes ranks Alpine's pre-release (negative) and post-release
+// suffixes around a plain release.
+var apkSuffixes = map[string]int{
+	"alpha": -4, "beta": -3, "pre": -2, "rc": -1,
+	"cvs": 1, "svn": 2, "git": 3, "hg": 4, "p": 5,
+}
+
+type apkVersion struct {
+	numbers  []string
+	letter   byte
+	suffixes [][2]int
+	revision int
+}
+
+func parseAPK(v string) apkVersion {
+	var out apkVersion
+	if i := strings.LastIndex(v, "-r"); i >= 0 {
+		if n, err := strconv.Atoi(v[i+2:]); err == nil {
+			out.revision = n
+			v = v[:i]
+		}
+	}
+	parts := strings.Split(v, "_")
+	for _, n := range strings.Split(parts[0], ".") {
+		digits, rest := digitPrefix(n)
+		out.numbers = append(out.numbers, digits)
+		if rest != "" {
+			out.letter = rest[0]
+		}
+	}
+	for _, s := range parts[1:] {
+		name, num := alphaPrefix(s)
+		n, _ := strconv.Atoi(num)
+		out.suffixes = append(out.suffixes, [2]int{apkSuffixes[name], n})
+	}
+	return out
+}
+
+// compareAPK follows apk-tools: numbers, then the letter after the last
+// number, then suffixes such as _rc1 or _p2, then the -rN revision.
+func compareAPK(a, b string) int {
+	va, vb := parseAPK(a), parseAPK(b)
+	for i := 0; i < max(len(va.numbers), len(vb.numbers)); i++ {
+		var na, nb string
+		if i < len(va.numbers) {
+			na = va.numbers[i]
+		}
+		if i < len(vb.numbers) {
+			nb = vb.numbers[i]
+		}
+		if c := compareDigits(na, nb); c != 0 {
+			return c
+		}
+	}
+	if va.letter != vb.letter {
+		return sign(int(va.letter) - int(vb.letter))
+	}
+	for i := 0; i < max(len(va.suffixes), len(vb.suffixes)); i++ {
+		var sa, sb [2]int
+		if i < len(va.suffixes) {
+			sa = va.suffixes[i]
+		}
+		if i < len(vb.suffixes) {
+			sb = vb.suffixes[i]
+		}
+		if sa != sb {
+			if sa[0] != sb[0] {
+				return sign(sa[0] - sb[0])
+			}
+			return sign(sa[1] - sb[1])
+		}
+	}
+	return sign(va.revision - vb.revision)
+}
+
+// compareSemver orders semantic versions, with or without a "v" prefix;
+// build metadata is ignored and missing minor or patch numbers are zero.
+func compareSemver(a, b string) int {
+	ma, pa := splitSemver(a)
+	mb, pb := splitSemver(b)
+	for i := range 3 {
+		if c := compareDigits(ma[i], mb[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case pa == "" && pb == "":
+		return 0
+	case pa == "":
+		return 1
+	case pb == "":
+		return -1
+	}
+	ia, ib := strings.Split(pa, "."), strings.Split(pb, ".")
+	for i := 0; i < min(len(ia), len(ib)); i++ {
+		_, errA := strconv.ParseUint(ia[i], 10, 64)
+		_, errB := strconv.ParseUint(ib[i], 10, 64)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = compareDigits(ia[i], ib[i])
+		case errA == nil:
+			c = -1
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(ia[i], ib[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return sign(len(ia) - len(ib))
+}
+
+func splitSemver(v string) ([3]string, string) {
+	v = strings.TrimPrefix(v, "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	var main [3]string
+	for i, p := range strings.SplitN(v, ".", 3) {
+		main[i] = p
+	}
+	return main, pre
+}
+
+type pep440Version struct {
+	epoch   int
+	release []int
+	pre     [2]int
+	post    int
+	dev     int
+}
+
+// parsePEP440 handles the normalized and common alternative spellings:
+// 1!2.0, 2.0a1, 2.0.rc1, 2.0.post1, 2.0-1, 2.0.dev3 and local versions.
+func parsePEP440(v string) pep440Version {
+	v = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), "v"))
+	v, _, _ = strings.Cut(v, "+")
+	out := pep440Version{post: -1, dev: math.MaxInt}
+	if e, rest, ok := strings.Cut(v, "!"); ok {
+		out.epoch, _ = strconv.Atoi(e)
+		v = rest
+	}
+	rest := v
+	for rest != "" {
+		digits, after := digitPrefix(rest)
+		if digits == "" {
+			break
+		}
+		n, _ := strconv.Atoi(digits)
+		out.release = append(out.release, n)
+		rest = after
+		if strings.HasPrefix(rest, ".") && len(rest) > 1 && isDigit(rest[1]) {
+			rest = rest[1:]
+			continue
+		}
+		break
+	}
+	preRanks := []struct {
+		name string
+		rank int
+	}{{"alpha", 0}, {"a", 0}, {"beta", 1}, {"b", 1}, {"rc", 2}, {"c", 2}, {"preview", 2}, {"pre", 2}}
+	hasPre := false
+	for rest != "" {
+		rest = strings.TrimLeft(rest, ".-_")
+		label, after := alphaPrefix(rest)
+		digits, after := digitPrefix(after)
+		n, _ := strconv.Atoi(digits)
+		switch {
+		case label == "" && digits != "":
+			out.post = n // "1.0-1" is a post release
+		case label == "post" || label == "rev" || label == "r":
+			out.post = n
+		case label == "dev":
+			out.dev = n
+		case label != "":
+			for _, p := range preRanks {
+				if label == p.name {
+					out.pre = [2]int{p.rank, n}
+					hasPre = true
+					break
+				}
+			}
+		default:
+			after = ""
+		}
+		rest = after
+	}
+	switch {
+	case !hasPre && out.post < 0 && out.dev != math.MaxInt:
+		out.pre = [2]int{-1, 0} // 1.0.dev1 sorts before 1.0a1
+	case !hasPre:
+		out.pre = [2]int{3, 0}
+	}
+	return out
+}
+
+func comparePEP440(a, b string) int {
+	va, vb := parsePEP440(a), parsePEP440(b)
+	if va.epoch != vb.epoch {
+		return sign(va.epoch - vb.epoch)
+	}
+	for i := 0; i < max(len(va.release), len(vb.release)); i++ {
+		var ra, rb int
+		if i < len(va.release) {
+			ra = va.release[i]
+		}
+		if i < len(vb.release) {
+			rb = vb.release[i]
+		}
+		if ra != rb {
+			return sign(ra - rb)
+		}
+	}
+	if va.pre != vb.pre {
+		if va.pre[0] != vb.pre[0] {
+			return sign(va.pre[0] - vb.pre[0])
+		}
+		return sign(va.pre[1] - vb.pre[1])
+	}
+	if va.post != vb.post {
+		return sign(va.post - vb.post)
+	}
+	switch {
+	case va.dev == vb.dev:
+		return 0
+	case va.dev < vb.dev:
+		return -1
+	}
+	return 1
+}
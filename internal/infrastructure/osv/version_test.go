@@ -0,0 +1,62 @@
+package osv
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cmp  compareFunc
+		a, b string
+		want int
+	}{
+		{"dpkg tilde", compareDpkg, "1.0~rc1-1", "1.0-1", -1},
+		{"dpkg epoch", compareDpkg, "1:0.9-1", "2.0-1", 1},
+		{"dpkg revision", compareDpkg, "2.36-9+deb12u4", "2.36-9+deb12u10", -1},
+		{"dpkg letters", compareDpkg, "1.0a", "1.0+", -1},
+		{"dpkg equal", compareDpkg, "0:1.2-3", "1.2-3", 0},
+		{"rpm release", compareRPM, "3.0.7-27.el9", "3.0.7-24.el9", 1},
+		{"rpm epoch", compareRPM, "1:3.0.7-27.el9", "3.1.0-1.el9", 1},
+		{"rpm tilde", compareRPM, "1.0~beta-1", "1.0-1", -1},
+		{"rpm caret", compareRPM, "1.0^git1-1", "1.0-1", 1},
+		{"rpm numeric beats alpha", compareRPM, "1.0.1", "1.0.a", 1},
+		{"apk revision", compareAPK, "3.1.4-r5", "3.1.4-r10", -1},
+		{"apk rc", compareAPK, "1.2.4_rc1-r0", "1.2.4-r0", -1},
+		{"apk patch", compareAPK, "1.2.4_p1-r0", "1.2.4-r0", 1},
+		{"apk letter", compareAPK, "1.1.1t-r0", "1.1.1u-r0", -1},
+		{"semver prerelease", compareSemver, "1.2.3-rc.1", "1.2.3", -1},
+		{"semver v prefix", compareSemver, "v0.17.0", "0.9.1", 1},
+		{"semver numeric ids", compareSemver, "1.0.0-alpha.2", "1.0.0-alpha.10", -1},
+		{"semver build", compareSemver, "1.0.0+build1", "1.0.0", 0},
+		{"pep440 pre", comparePEP440, "2.0rc1", "2.0", -1},
+		{"pep440 post", comparePEP440, "2.0.post1", "2.0", 1},
+		{"pep440 dev", comparePEP440, "2.0.dev1", "2.0a1", -1},
+		{"pep440 padding", comparePEP440, "4.2", "4.2.0", 0},
+		{"pep440 epoch", comparePEP440, "1!1.0", "2.0", 1},
+	} {
+		if got := tc.cmp(tc.a, tc.b); got != tc.want {
+			t.Errorf("%s: compare(%q, %q) = %d, want %d", tc.name, tc.a, tc.b, got, tc.want)
+		}
+		if got := tc.cmp(tc.b, tc.a); got != -tc.want {
+			t.Errorf("%s: compare(%q, %q) = %d, want %d", tc.name, tc.b, tc.a, got, -tc.want)
+		}
+	}
+}
+
+func TestCVSS3BaseScore(t *testing.T) {
+	for vector, want := range map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H": 10,
+		"CVSS:3.0/AV:N/AC:H/PR:N/UI:N/S:U/C:H/I:N/A:N": 5.9,
+		"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:N/I:N/A:H": 5.5,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:R/S:C/C:L/I:L/A:N": 5.4,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N": 0,
+	} {
+		got, ok := cvss3BaseScore(vector)
+		if !ok || got != want {
+			t.Errorf("cvss3BaseScore(%s) = %v, %v, want %v", vector, got, ok, want)
+		}
+	}
+	if _, ok := cvss3BaseScore("CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N"); ok {
+		t.Error("expected CVSS v4 vectors to be rejected")
+	}
+}
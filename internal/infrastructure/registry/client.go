@@ -0,0 +1,340 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	httpTimeout      = 30 * time.Second
+	maxResponseBytes = 4 << 20
+	// tokenLeeway renews bearer tokens a little before they expire.
+	tokenLeeway = 10 * time.Second
+)
+
+// Client speaks the Docker Registry HTTP API v2. It answers bearer token
+// challenges (fetching and caching a token per registry, user and scope) and
+// basic auth challenges with the registry's credentials.
+type Client struct {
+	http     *http.Client
+	insecure *http.Client
+	log      *slog.Logger
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]bearerToken
+	// basic records the registries that asked for basic auth, so later
+	// requests send it up front.
+	basic map[string]bool
+}
+
+type bearerToken struct {
+	token   string
+	expires time.Time
+}
+
+func NewClient(log *slog.Logger) *Client {
+	insecure := http.DefaultTransport.(*http.Transport).Clone()
+	insecure.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return &Client{
+		http:     &http.Client{Timeout: httpTimeout},
+		insecure: &http.Client{Timeout: httpTimeout, Transport: insecure},
+		log:      log,
+		now:      time.Now,
+		tokens:   make(map[string]bearerToken),
+		basic:    make(map[string]bool),
+	}
+}
+
+func (c *Client) Repositories(ctx context.Context, reg *domain.Registry, limit int, last string) (*domain.RegistryRepositories, error) {
+	var body struct {
+		Repositories []string `json:"repositories"`
+	}
+	next, err := c.getPage(ctx, reg, "/v2/_catalog", "registry:catalog:*", limit, last, &body)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RegistryRepositories{Repositories: nonNil(body.Repositories), Next: next}, nil
+}
+
+func (c *Client) Tags(ctx context.Context, reg *domain.Registry, repo string, limit int, last string) (*domain.RegistryTags, error) {
+	var body struct {
+		Tags []string `json:"tags"`
+	}
+	next, err := c.getPage(ctx, reg, "/v2/"+repo+"/tags/list", pullScope(repo), limit, last, &body)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RegistryTags{Repository: repo, Tags: nonNil(body.Tags), Next: next}, nil
+}
+
+// getPage decodes a paginated listing into v and returns the "last" value of
+// the next page from the Link header, if any.
+func (c *Client) getPage(ctx context.Context, reg *domain.Registry, path, scope string, limit int, last string, v any) (string, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("n", strconv.Itoa(limit))
+	}
+	if last != "" {
+		q.Set("last", last)
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	resp, err := c.get(ctx, reg, path, scope, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(v); err != nil {
+		return "", fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nextPage(resp.Header.Get("Link")), nil
+}
+
+// get performs an authenticated GET and returns the response only for 2xx
+// statuses; registry errors are mapped to domain errors.
+func (c *Client) get(ctx context.Context, reg *domain.Registry, path, scope string, accept []string) (*http.Response, error) {
+	base := strings.TrimSuffix(reg.URL, "/")
+	resp, err := c.do(ctx, reg, base+path, accept, c.authorization(reg, scope))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		drain(resp)
+		auth, err := c.answer(ctx, reg, challenge, scope)
+		if err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, reg, base+path, accept, auth); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode/100 != 2 {
+		defer drain(resp)
+		return nil, statusError(path, resp)
+	}
+	return resp, nil
+}
+
+func (c *Client) do(ctx context.Context, reg *domain.Registry, rawURL string, accept []string, auth string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("registry %s: %w: %v", reg.Name, domain.ErrInvalidArgument, err)
+	}
+	req.Header.Set("User-Agent", "DockScope")
+	for _, a := range accept {
+		req.Header.Add("Accept", a)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	client := c.http
+	if reg.Insecure {
+		client = c.insecure
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("registry %s: %w: %v", reg.Name, domain.ErrUnavailable, err)
+	}
+	return resp, nil
+}
+
+// authorization returns a cached Authorization header for scope, if any.
+func (c *Client) authorization(reg *domain.Registry, scope string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.basic[reg.URL] && reg.Username != "" {
+		return basicAuth(reg)
+	}
+	if tok, ok := c.tokens[tokenKey(reg, scope)]; ok && c.now().Before(tok.expires) {
+		return "Bearer " + tok.token
+	}
+	return ""
+}
+
+// answer builds the Authorization header for a WWW-Authenticate challenge.
+func (c *Client) answer(ctx context.Context, reg *domain.Registry, challenge, scope string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if reg.Username == "" {
+			return "", fmt.Errorf("registry %s requires credentials: %w", reg.Name, domain.ErrUnauthorized)
+		}
+		c.mu.Lock()
+		c.basic[reg.URL] = true
+		c.mu.Unlock()
+		return basicAuth(reg), nil
+	case "bearer":
+		tok, err := c.fetchToken(ctx, reg, params, scope)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + tok, nil
+	default:
+		return "", fmt.Errorf("registry %s: unsupported auth challenge %q: %w", reg.Name, challenge, domain.ErrUnauthorized)
+	}
+}
+
+func (c *Client) fetchToken(ctx context.Context, reg *domain.Registry, params map[string]string, scope string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("registry %s: invalid token realm %q: %w", reg.Name, params["realm"], domain.ErrUnauthorized)
+	}
+	q := realm.Query()
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	realm.RawQuery = q.Encode()
+
+	auth := ""
+	if reg.Username != "" {
+		auth = basicAuth(reg)
+	}
+	resp, err := c.do(ctx, reg, realm.String(), nil, auth)
+	if err != nil {
+		return "", err
+	}
+	defer drain(resp)
+	if resp.StatusCode/100 != 2 {
+		return "", statusError("token", resp)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("registry %s: decode token: %w", reg.Name, err)
+	}
+	tok := body.Token
+	if tok == "" {
+		tok = body.AccessToken
+	}
+	if tok == "" {
+		return "", fmt.Errorf("registry %s: token endpoint returned no token: %w", reg.Name, domain.ErrUnauthorized)
+	}
+	ttl := time.Duration(body.ExpiresIn) * time.Second
+	if ttl <= 0 {
+		// The spec's default when expires_in is missing.
+		ttl = 60 * time.Second
+	}
+	c.mu.Lock()
+	c.tokens[tokenKey(reg, scope)] = bearerToken{token: tok, expires: c.now().Add(ttl - tokenLeeway)}
+	c.mu.Unlock()
+	return tok, nil
+}
+
+// statusError maps a registry error response to a domain error, keeping the
+// registry's own message.
+func statusError(what string, resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	msg := resp.Status
+	if json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&body) == nil && len(body.Errors) > 0 {
+		msg = body.Errors[0].Code + ": " + body.Errors[0].Message
+	}
+	var kind error
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		kind = domain.ErrUnauthorized
+	case http.StatusNotFound:
+		kind = domain.ErrNotFound
+	case http.StatusTooManyRequests:
+		kind = domain.ErrRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		kind = domain.ErrUnavailable
+	default:
+		return fmt.Errorf("%s: %s", what, msg)
+	}
+	return fmt.Errorf("%s: %s: %w", what, msg, kind)
+}
+
+// parseChallenge parses `Bearer realm="...",service="...",scope="..."`.
+func parseChallenge(h string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+	params = make(map[string]string)
+	for rest != "" {
+		var key, val string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				val, rest = rest[1:], ""
+			} else {
+				val, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			val, rest, _ = strings.Cut(rest, ",")
+		}
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			params[key] = val
+		}
+	}
+	return strings.ToLower(scheme), params
+}
+
+// nextPage extracts "last" from a `</v2/_catalog?last=x&n=y>; rel="next"`
+// Link header.
+func nextPage(link string) string {
+	target, rel, ok := strings.Cut(link, ";")
+	if !ok || !strings.Contains(rel, `rel="next"`) {
+		return ""
+	}
+	u, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("last")
+}
+
+func pullScope(repo string) string {
+	return "repository:" + repo + ":pull"
+}
+
+func tokenKey(reg *domain.Registry, scope string) string {
+	return reg.URL + "\x00" + reg.Username + "\x00" + scope
+}
+
+func basicAuth(reg *domain.Registry) string {
+	req := http.Request{Header: http.Header{}}
+	req.SetBasicAuth(reg.Username, reg.Password)
+	return req.Header.Get("Authorization")
+}
+
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+	resp.Body.Close()
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+var _ domain.RegistryClient = (*Client)(nil)
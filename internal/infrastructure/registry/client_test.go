@@ -0,0 +1,221 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// fakeRegistry is a registry:2 stand-in protected by bearer tokens: "app" is
+// a two-platform manifest list, "tool" a single image.
+type fakeRegistry struct {
+	*httptest.Server
+	tokenRequests atomic.Int32
+	gotScopes     []string
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	f := &fakeRegistry{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		f.tokenRequests.Add(1)
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "alice" || pass != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.gotScopes = append(f.gotScopes, r.URL.Query().Get("scope"))
+		json.NewEncoder(w).Encode(map[string]any{"token": "tok-" + r.URL.Query().Get("scope"), "expires_in": 300})
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		scope := "registry:catalog:*"
+		if r.URL.Path != "/v2/_catalog" {
+			repo := strings.TrimPrefix(r.URL.Path, "/v2/")
+			for _, sep := range []string{"/tags/", "/manifests/", "/blobs/"} {
+				if i := strings.Index(repo, sep); i >= 0 {
+					repo = repo[:i]
+				}
+			}
+			scope = "repository:" + repo + ":pull"
+		}
+		if r.Header.Get("Authorization") != "Bearer tok-"+scope {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+f.URL+`/token",service="fake",scope="`+scope+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.serve(w, r)
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeRegistry) serve(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v2/_catalog":
+		repos := []string{"app", "team/tool"}
+		if r.URL.Query().Get("last") == "" && r.URL.Query().Get("n") == "1" {
+			w.Header().Set("Link", `</v2/_catalog?last=app&n=1>; rel="next"`)
+			repos = repos[:1]
+		} else if r.URL.Query().Get("last") == "app" {
+			repos = repos[1:]
+		}
+		json.NewEncoder(w).Encode(map[string]any{"repositories": repos})
+	case "/v2/app/tags/list":
+		json.NewEncoder(w).Encode(map[string]any{"name": "app", "tags": []string{"1.0", "latest"}})
+	case "/v2/app/manifests/latest":
+		w.Header().Set("Content-Type", mediaTypeOCIIndex)
+		w.Header().Set("Docker-Content-Digest", "sha256:index")
+		json.NewEncoder(w).Encode(map[string]any{
+			"mediaType": mediaTypeOCIIndex,
+			"manifests": []map[string]any{
+				{"digest": "sha256:amd", "size": 500, "platform": map[string]string{"os": "linux", "architecture": "amd64"}},
+				{"digest": "sha256:arm", "size": 500, "platform": map[string]string{"os": "linux", "architecture": "arm64", "variant": "v8"}},
+			},
+		})
+	case "/v2/app/manifests/sha256:amd", "/v2/app/manifests/sha256:arm":
+		arch := strings.TrimPrefix(r.URL.Path, "/v2/app/manifests/sha256:")
+		w.Header().Set("Content-Type", mediaTypeOCIManifest)
+		json.NewEncoder(w).Encode(map[string]any{
+			"mediaType": mediaTypeOCIManifest,
+			"config":    map[string]any{"digest": "sha256:cfg-" + arch, "size": 10},
+			"layers": []map[string]any{
+				{"digest": "sha256:base", "size": 1000},
+				{"digest": "sha256:app-" + arch, "size": 200},
+			},
+		})
+	case "/v2/team/tool/manifests/v1":
+		w.Header().Set("Content-Type", mediaTypeDockerManifest)
+		json.NewEncoder(w).Encode(map[string]any{
+			"mediaType": mediaTypeDockerManifest,
+			"config":    map[string]any{"digest": "sha256:toolcfg", "size": 20},
+			"layers":    []map[string]any{{"digest": "sha256:tool", "size": 300}},
+		})
+	case "/v2/team/tool/blobs/sha256:toolcfg":
+		json.NewEncoder(w).Encode(map[string]any{"architecture": "amd64", "os": "linux", "created": "2026-03-01T10:00:00Z"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"errors": []map[string]string{{"code": "MANIFEST_UNKNOWN", "message": "manifest unknown"}}})
+	}
+}
+
+func newTestClient() *Client {
+	return NewClient(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+}
+
+func TestClient_RepositoriesAndTags(t *testing.T) {
+	f := newFakeRegistry(t)
+	c := newTestClient()
+	reg := &domain.Registry{Name: "local", URL: f.URL, Username: "alice", Password: "s3cret"}
+	ctx := context.Background()
+
+	page, err := c.Repositories(ctx, reg, 1, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(page.Repositories, []string{"app"}) || page.Next != "app" {
+		t.Fatalf("first page = %+v", page)
+	}
+	page, err = c.Repositories(ctx, reg, 1, page.Next)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(page.Repositories, []string{"team/tool"}) || page.Next != "" {
+		t.Fatalf("second page = %+v", page)
+	}
+
+	tags, err := c.Tags(ctx, reg, "app", 0, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(tags.Tags, []string{"1.0", "latest"}) {
+		t.Errorf("tags = %v", tags.Tags)
+	}
+	// One token per scope, reused for the second catalog page.
+	if got := f.tokenRequests.Load(); got != 2 {
+		t.Errorf("token requests = %d, want 2 (%v)", got, f.gotScopes)
+	}
+}
+
+func TestClient_ManifestList(t *testing.T) {
+	f := newFakeRegistry(t)
+	c := newTestClient()
+	reg := &domain.Registry{Name: "local", URL: f.URL, Username: "alice", Password: "s3cret"}
+
+	m, err := c.Manifest(context.Background(), reg, "app", "latest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Digest != "sha256:index" || m.MediaType != mediaTypeOCIIndex || len(m.Manifests) != 2 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+	arm := m.Manifests[1]
+	if arm.Platform == nil || arm.Platform.Architecture != "arm64" || arm.Platform.Variant != "v8" {
+		t.Errorf("arm platform = %+v", arm.Platform)
+	}
+	if arm.Size != 1210 || len(arm.Layers) != 2 {
+		t.Errorf("arm size = %d, layers = %d", arm.Size, len(arm.Layers))
+	}
+	// The shared base layer counts once.
+	if m.Size != 1000+2*210 {
+		t.Errorf("list size = %d", m.Size)
+	}
+	host := strings.TrimPrefix(f.URL, "http://")
+	if m.PullRef != host+"/app:latest" || arm.PullRef != host+"/app@sha256:arm" {
+		t.Errorf("pull refs = %q, %q", m.PullRef, arm.PullRef)
+	}
+}
+
+func TestClient_ImageManifest(t *testing.T) {
+	f := newFakeRegistry(t)
+	c := newTestClient()
+	reg := &domain.Registry{Name: "local", URL: f.URL, Username: "alice", Password: "s3cret"}
+
+	m, err := c.Manifest(context.Background(), reg, "team/tool", "v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Size != 320 || m.Platform == nil || m.Platform.OS != "linux" || m.CreatedAt == nil {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+
+	if _, err := c.Manifest(context.Background(), reg, "team/tool", "v2"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	f := newFakeRegistry(t)
+	c := newTestClient()
+	reg := &domain.Registry{Name: "local", URL: f.URL, Username: "alice", Password: "wrong"}
+
+	if _, err := c.Tags(context.Background(), reg, "app", 0, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	f.Close()
+	if _, err := c.Tags(context.Background(), reg, "app", 0, ""); !errors.Is(err, domain.ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a/b:pull,push"`)
+	if scheme != "bearer" {
+		t.Errorf("scheme = %q", scheme)
+	}
+	want := map[string]string{"realm": "https://auth.example.com/token", "service": "registry.example.com", "scope": "repository:a/b:pull,push"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %v", params)
+	}
+}
@@ -0,0 +1,154 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+)
+
+var manifestAccept = []string{mediaTypeOCIIndex, mediaTypeDockerList, mediaTypeOCIManifest, mediaTypeDockerManifest}
+
+type descriptorJSON struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+		Variant      string `json:"variant"`
+	} `json:"platform"`
+}
+
+type manifestJSON struct {
+	MediaType string           `json:"mediaType"`
+	Config    *descriptorJSON  `json:"config"`
+	Layers    []descriptorJSON `json:"layers"`
+	Manifests []descriptorJSON `json:"manifests"`
+}
+
+// Manifest resolves ref (a tag or digest) in repo. For a manifest list every
+// platform manifest is fetched too, to report its layers and size.
+func (c *Client) Manifest(ctx context.Context, reg *domain.Registry, repo, ref string) (*domain.RegistryManifest, error) {
+	m, body, err := c.fetchManifest(ctx, reg, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	m.Repository = repo
+	m.Reference = ref
+	m.PullRef = reg.ImageRef(repo, ref)
+
+	switch m.MediaType {
+	case mediaTypeDockerManifest, mediaTypeOCIManifest:
+		if m.Config != nil {
+			if err := c.readConfig(ctx, reg, repo, m); err != nil {
+				c.log.DebugContext(ctx, "registry image config unavailable", "registry", reg.Name, "repo", repo, "digest", m.Config.Digest, "error", err)
+			}
+		}
+		return m, nil
+	case mediaTypeDockerList, mediaTypeOCIIndex:
+	default:
+		return nil, fmt.Errorf("manifest %s:%s has unsupported media type %q: %w", repo, ref, m.MediaType, domain.ErrInvalidArgument)
+	}
+
+	var index manifestJSON
+	if err := json.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("decode manifest list %s:%s: %w", repo, ref, err)
+	}
+	seen := make(map[string]bool)
+	m.Manifests = make([]domain.RegistryManifest, 0, len(index.Manifests))
+	for _, d := range index.Manifests {
+		child, _, err := c.fetchManifest(ctx, reg, repo, d.Digest)
+		if err != nil {
+			return nil, err
+		}
+		child.PullRef = reg.ImageRef(repo, d.Digest)
+		if d.Platform != nil {
+			child.Platform = &domain.Platform{OS: d.Platform.OS, Architecture: d.Platform.Architecture, Variant: d.Platform.Variant}
+		}
+		blobs := append([]domain.RegistryDescriptor{}, child.Layers...)
+		if child.Config != nil {
+			blobs = append(blobs, *child.Config)
+		}
+		for _, b := range blobs {
+			if !seen[b.Digest] {
+				seen[b.Digest] = true
+				m.Size += b.Size
+			}
+		}
+		m.Manifests = append(m.Manifests, *child)
+	}
+	return m, nil
+}
+
+// fetchManifest returns the manifest with its config and layers filled in
+// (for image manifests) and the raw body.
+func (c *Client) fetchManifest(ctx context.Context, reg *domain.Registry, repo, ref string) (*domain.RegistryManifest, []byte, error) {
+	resp, err := c.get(ctx, reg, "/v2/"+repo+"/manifests/"+ref, pullScope(repo), manifestAccept)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read manifest %s:%s: %w", repo, ref, err)
+	}
+	var raw manifestJSON
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, nil, fmt.Errorf("decode manifest %s:%s: %w", repo, ref, err)
+	}
+
+	m := &domain.RegistryManifest{Digest: resp.Header.Get("Docker-Content-Digest"), MediaType: raw.MediaType}
+	if m.Digest == "" {
+		sum := sha256.Sum256(body)
+		m.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	if ct, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); m.MediaType == "" && err == nil {
+		m.MediaType = ct
+	}
+	if raw.Config != nil {
+		m.Config = &domain.RegistryDescriptor{MediaType: raw.Config.MediaType, Digest: raw.Config.Digest, Size: raw.Config.Size}
+		m.Size += raw.Config.Size
+	}
+	for _, l := range raw.Layers {
+		m.Layers = append(m.Layers, domain.RegistryDescriptor{MediaType: l.MediaType, Digest: l.Digest, Size: l.Size})
+		m.Size += l.Size
+	}
+	return m, body, nil
+}
+
+// readConfig fills in the platform and creation date from the image config.
+func (c *Client) readConfig(ctx context.Context, reg *domain.Registry, repo string, m *domain.RegistryManifest) error {
+	resp, err := c.get(ctx, reg, "/v2/"+repo+"/blobs/"+m.Config.Digest, pullScope(repo), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var cfg struct {
+		Architecture string    `json:"architecture"`
+		OS           string    `json:"os"`
+		Variant      string    `json:"variant"`
+		Created      time.Time `json:"created"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&cfg); err != nil {
+		return err
+	}
+	m.Platform = &domain.Platform{OS: cfg.OS, Architecture: cfg.Architecture, Variant: cfg.Variant}
+	if !cfg.Created.IsZero() {
+		m.CreatedAt = &cfg.Created
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package sbom builds software inventories of images from the files in their
+// layers, without network access, and renders them as CycloneDX or SPDX.
+package sbom
+
+import (
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	maxMetadataBytes = 64 << 20
+	maxDatabaseBytes = 512 << 20
+	maxBinaryBytes   = 256 << 20
+)
+
+var rpmDatabases = map[string]func([]byte) ([][]byte, error){
+	"rpmdb.sqlite": sqliteRPMBlobs,
+	"Packages":     bdbRPMBlobs,
+	"Packages.db":  ndbRPMBlobs,
+}
+
+// Cataloger recognizes OS package databases (dpkg, apk, rpm), Go binaries and
+// npm and pip lockfiles. Read parses a single file into a []domain.Package,
+// a *domain.Distro for os-release, or the licenses of a Debian copyright
+// file; Catalog combines them.
+type Cataloger struct{}
+
+func NewCataloger() *Cataloger {
+	return &Cataloger{}
+}
+
+type debianCopyright struct {
+	pkg      string
+	licenses []string
+}
+
+func (c *Cataloger) Wants(f domain.LayerFile) bool {
+	p := f.Path
+	dir, base := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case p == "etc/os-release" || p == "usr/lib/os-release":
+		return f.Size <= maxMetadataBytes
+	case p == "var/lib/dpkg/status" || p == "lib/apk/db/installed":
+		return f.Size <= maxMetadataBytes
+	case dir == "var/lib/dpkg/status.d":
+		return !strings.HasSuffix(base, ".md5sums") && f.Size <= maxMetadataBytes
+	case isDebianCopyright(p):
+		return f.Size <= maxMetadataBytes
+	case dir == "var/lib/rpm" || dir == "usr/lib/sysimage/rpm":
+		return rpmDatabases[base] != nil && f.Size <= maxDatabaseBytes
+	case base == "package-lock.json":
+		return !strings.Contains(p, "node_modules/") && f.Size <= maxMetadataBytes
+	case base == "requirements.txt":
+		return f.Size <= maxMetadataBytes
+	}
+	// Shared libraries are often executable too, but never Go programs.
+	return f.Executable && f.Size <= maxBinaryBytes && !strings.Contains(base, ".so")
+}
+
+func (c *Cataloger) Read(f domain.LayerFile, r io.Reader) (any, error) {
+	p := f.Path
+	dir, base := path.Split(p)
+	dir = strings.TrimSuffix(dir, "/")
+	switch {
+	case p == "etc/os-release" || p == "usr/lib/os-release":
+		return parseOSRelease(r)
+	case p == "var/lib/dpkg/status" || dir == "var/lib/dpkg/status.d":
+		return parseDpkgStatus(r, "/"+p)
+	case p == "lib/apk/db/installed":
+		return parseAPKInstalled(r, "/"+p)
+	case isDebianCopyright(p):
+		licenses, err := parseDebianCopyright(r)
+		if err != nil || len(licenses) == 0 {
+			return nil, err
+		}
+		return &debianCopyright{pkg: path.Base(dir), licenses: licenses}, nil
+	case dir == "var/lib/rpm" || dir == "usr/lib/sysimage/rpm":
+		data, err := io.ReadAll(io.LimitReader(r, maxDatabaseBytes))
+		if err != nil {
+			return nil, err
+		}
+		blobs, err := rpmDatabases[base](data)
+		if err != nil {
+			return nil, err
+		}
+		return rpmPackages(blobs, "/"+p), nil
+	case base == "package-lock.json":
+		return parsePackageLock(r, "/"+p)
+	case base == "requirements.txt":
+		return parseRequirements(r, "/"+p)
+	}
+	return readGoBinary(r, "/"+p)
+}
+
+// Catalog pairs packages with their licenses and distro and returns them
+// sorted by type, name and version.
+func (c *Cataloger) Catalog(results map[string]any) (*domain.Distro, []domain.Package) {
+	var distro *domain.Distro
+	if d, ok := results["etc/os-release"].(*domain.Distro); ok {
+		distro = d
+	} else if d, ok := results["usr/lib/os-release"].(*domain.Distro); ok {
+		distro = d
+	}
+
+	copyrights := make(map[string][]string)
+	pkgs := []domain.Package{}
+	for _, v := range results {
+		switch v := v.(type) {
+		case []domain.Package:
+			pkgs = append(pkgs, v...)
+		case *debianCopyright:
+			copyrights[v.pkg] = v.licenses
+		}
+	}
+	for i := range pkgs {
+		p := &pkgs[i]
+		if p.Type == domain.PackageTypeDeb && len(p.Licenses) == 0 {
+			p.Licenses = copyrights[p.Name]
+		}
+		p.PURL = packageURL(p, distro)
+	}
+	sort.Slice(pkgs, func(i, j int) bool {
+		a, b := pkgs[i], pkgs[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Location < b.Location
+	})
+	return distro, pkgs
+}
+
+func isDebianCopyright(p string) bool {
+	rest, ok := strings.CutPrefix(p, "usr/share/doc/")
+	name, file, _ := strings.Cut(rest, "/")
+	return ok && name != "" && file == "copyright"
+}
+
+var _ domain.PackageCataloger = (*Cataloger)(nil)
@@ -0,0 +1,206 @@
+package sbom
+
+import (
+	"encoding/json"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const dpkgStatus = `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Source: glibc (2.36-9+deb12u4)
+Version: 2.36-9+deb12u4
+Description: GNU C Library: Shared libraries
+ Contains the standard libraries.
+
+Package: bash
+Status: install ok installed
+Architecture: amd64
+Version: 5.2.15-2+b2
+
+Package: oldpkg
+Status: deinstall ok config-files
+Version: 1.0
+`
+
+const apkInstalled = `C:Q1abc=
+P:musl
+V:1.2.4-r2
+A:x86_64
+L:MIT
+o:musl
+
+P:libcrypto3
+V:3.1.4-r5
+A:x86_64
+L:Apache-2.0
+o:openssl
+`
+
+// catalog feeds files through the cataloger as a scan would.
+func catalog(t *testing.T, files map[string]string) (*domain.Distro, []domain.Package) {
+	t.Helper()
+	c := NewCataloger()
+	results := make(map[string]any)
+	for p, content := range files {
+		f := domain.LayerFile{Path: p, Type: domain.FileTypeFile, Size: int64(len(content))}
+		if !c.Wants(f) {
+			continue
+		}
+		v, err := c.Read(f, strings.NewReader(content))
+		if err != nil {
+			t.Fatalf("read %s: %v", p, err)
+		}
+		if v != nil {
+			results[p] = v
+		}
+	}
+	return c.Catalog(results)
+}
+
+func purls(pkgs []domain.Package) []string {
+	out := make([]string, len(pkgs))
+	for i, p := range pkgs {
+		out[i] = p.PURL
+	}
+	return out
+}
+
+func TestCatalog_Debian(t *testing.T) {
+	distro, pkgs := catalog(t, map[string]string{
+		"etc/os-release":               "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_ID=\"12\"\n",
+		"var/lib/dpkg/status":          dpkgStatus,
+		"usr/share/doc/bash/copyright": "Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/\n\nFiles: *\nLicense: GPL-3+\n",
+		"etc/passwd":                   "root:x:0:0::/root:/bin/bash\n",
+	})
+	if distro == nil || distro.ID != "debian" || distro.VersionID != "12" {
+		t.Fatalf("distro = %+v", distro)
+	}
+	want := []string{
+		"pkg:deb/debian/bash@5.2.15-2%2Bb2?arch=amd64&distro=debian-12",
+		"pkg:deb/debian/libc6@2.36-9%2Bdeb12u4?arch=amd64&distro=debian-12",
+	}
+	if got := purls(pkgs); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("purls = %v", got)
+	}
+	if pkgs[0].Licenses[0] != "GPL-3+" || pkgs[1].SourceName != "glibc" {
+		t.Errorf("packages = %+v", pkgs)
+	}
+}
+
+func TestCatalog_Alpine(t *testing.T) {
+	_, pkgs := catalog(t, map[string]string{
+		"etc/os-release":       "ID=alpine\nVERSION_ID=3.19.1\n",
+		"lib/apk/db/installed": apkInstalled,
+	})
+	want := []string{
+		"pkg:apk/alpine/libcrypto3@3.1.4-r5?arch=x86_64&distro=alpine-3.19.1",
+		"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.19.1",
+	}
+	if got := purls(pkgs); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("purls = %v", got)
+	}
+	if pkgs[0].SourceName != "openssl" || pkgs[1].SourceName != "" || pkgs[1].Licenses[0] != "MIT" {
+		t.Errorf("packages = %+v", pkgs)
+	}
+}
+
+func TestCatalog_Lockfiles(t *testing.T) {
+	lockV3, _ := json.Marshal(map[string]any{
+		"lockfileVersion": 3,
+		"packages": map[string]any{
+			"":                                   map[string]any{"name": "app", "version": "1.0.0"},
+			"node_modules/express":               map[string]any{"version": "4.18.2", "license": "MIT"},
+			"node_modules/@babel/core":           map[string]any{"version": "7.23.0"},
+			"node_modules/a/node_modules/ms":     map[string]any{"version": "2.0.0"},
+			"node_modules/local":                 map[string]any{"link": true, "resolved": "packages/local"},
+			"node_modules/lodash-es-alias":       map[string]any{"name": "lodash-es", "version": "4.17.21"},
+			"node_modules/express/node_modules/": map[string]any{},
+		},
+	})
+	lockV1, _ := json.Marshal(map[string]any{
+		"lockfileVersion": 1,
+		"dependencies": map[string]any{
+			"debug": map[string]any{"version": "2.6.9", "dependencies": map[string]any{"ms": map[string]any{"version": "2.0.0"}}},
+		},
+	})
+	_, pkgs := catalog(t, map[string]string{
+		"app/package-lock.json":                string(lockV3),
+		"legacy/package-lock.json":             string(lockV1),
+		"app/node_modules/x/package-lock.json": string(lockV1),
+		"srv/requirements.txt":                 "# deps\nDjango==4.2.7\nrequests[socks] >= 2.31 ; python_version > '3.8'\ngunicorn===21.2.0  # pinned\n-r base.txt\ngit+https://github.com/x/y.git\nzope.interface==6.*\n",
+	})
+	want := []string{
+		"pkg:npm/%40babel/core@7.23.0",
+		"pkg:npm/debug@2.6.9",
+		"pkg:npm/express@4.18.2",
+		"pkg:npm/lodash-es@4.17.21",
+		"pkg:npm/ms@2.0.0",
+		"pkg:npm/ms@2.0.0",
+		"pkg:pypi/django@4.2.7",
+		"pkg:pypi/gunicorn@21.2.0",
+		"pkg:pypi/requests",
+		"pkg:pypi/zope.interface",
+	}
+	if got := purls(pkgs); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("purls = %v", got)
+	}
+	if pkgs[2].Licenses[0] != "MIT" || pkgs[4].Location == pkgs[5].Location {
+		t.Errorf("packages = %+v", pkgs)
+	}
+}
+
+func TestReadGoBinary(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("needs an ELF test binary")
+	}
+	f, err := os.Open(os.Args[0])
+	if err != nil {
+		t.Fatalf("open test binary: %v", err)
+	}
+	defer f.Close()
+	pkgs, err := readGoBinary(f, "/usr/bin/app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	version, _, _ := strings.Cut(strings.TrimPrefix(runtime.Version(), "go"), " ")
+	var found bool
+	for _, p := range pkgs {
+		if p.Name == "stdlib" && p.Version == version && p.Type == domain.PackageTypeGolang {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("stdlib %s not among %+v", version, pkgs)
+	}
+
+	if pkgs, err := readGoBinary(strings.NewReader("#!/bin/sh\necho hi\n"), "/usr/bin/hi"); err != nil || pkgs != nil {
+		t.Errorf("script: got %v, %v", pkgs, err)
+	}
+}
+
+func TestCatalogerWants(t *testing.T) {
+	c := NewCataloger()
+	for _, tc := range []struct {
+		f    domain.LayerFile
+		want bool
+	}{
+		{domain.LayerFile{Path: "usr/lib/sysimage/rpm/rpmdb.sqlite", Size: 1 << 20}, true},
+		{domain.LayerFile{Path: "var/lib/rpm/Packages", Size: 1 << 20}, true},
+		{domain.LayerFile{Path: "var/lib/rpm/Index.db", Size: 1 << 20}, false},
+		{domain.LayerFile{Path: "var/lib/dpkg/status.d/base", Size: 100}, true},
+		{domain.LayerFile{Path: "var/lib/dpkg/status.d/base.md5sums", Size: 100}, false},
+		{domain.LayerFile{Path: "usr/local/bin/app", Size: 10 << 20, Executable: true}, true},
+		{domain.LayerFile{Path: "usr/lib/libssl.so.3", Size: 1 << 20, Executable: true}, false},
+		{domain.LayerFile{Path: "usr/local/bin/data", Size: 10 << 20}, false},
+	} {
+		if got := c.Wants(tc.f); got != tc.want {
+			t.Errorf("Wants(%s) = %v, want %v", tc.f.Path, got, tc.want)
+		}
+	}
+}
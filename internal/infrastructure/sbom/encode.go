@@ -0,0 +1,238 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const toolName = "dockscope"
+
+// Encoder renders SBOMs as CycloneDX 1.5 or SPDX 2.3 JSON.
+type Encoder struct{}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+func (e *Encoder) Encode(sbom *domain.SBOM, format string) ([]byte, error) {
+	switch format {
+	case domain.SBOMFormatCycloneDX:
+		return json.MarshalIndent(cycloneDXDocument(sbom), "", "  ")
+	case domain.SBOMFormatSPDX:
+		return json.MarshalIndent(spdxDocument(sbom), "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported sbom format %q", format)
+	}
+}
+
+type cdxDocument struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cdxComponent `json:"components"`
+	} `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Licenses   []cdxLicense  `json:"licenses,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxLicense struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func cycloneDXDocument(sbom *domain.SBOM) *cdxDocument {
+	doc := &cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + documentUUID(sbom),
+		Version:      1,
+		Components:   []cdxComponent{},
+	}
+	doc.Metadata.Timestamp = sbom.GeneratedAt.UTC().Format(time.RFC3339)
+	doc.Metadata.Tools.Components = []cdxComponent{{Type: "application", Name: toolName}}
+	doc.Metadata.Component = cdxComponent{
+		Type:    "container",
+		BOMRef:  sbom.ImageID,
+		Name:    imageName(sbom),
+		Version: sbom.ImageID,
+	}
+	if sbom.Distro != nil {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:    "operating-system",
+			BOMRef:  "os:" + sbom.Distro.ID,
+			Name:    sbom.Distro.ID,
+			Version: sbom.Distro.VersionID,
+		})
+	}
+	seen := make(map[string]bool)
+	for _, p := range sbom.Packages {
+		c := cdxComponent{
+			Type:    "library",
+			BOMRef:  p.PURL,
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    p.PURL,
+			Properties: []cdxProperty{
+				{Name: toolName + ":package:type", Value: p.Type},
+				{Name: toolName + ":location", Value: p.Location},
+			},
+		}
+		// bom-refs must be unique; the same package found in two places
+		// gets its location appended.
+		if seen[c.BOMRef] {
+			c.BOMRef += "#" + p.Location
+		}
+		seen[c.BOMRef] = true
+		if p.SourceName != "" {
+			c.Properties = append(c.Properties, cdxProperty{Name: toolName + ":package:source", Value: p.SourceName})
+		}
+		for _, l := range p.Licenses {
+			var lic cdxLicense
+			lic.License.Name = l
+			c.Licenses = append(c.Licenses, lic)
+		}
+		doc.Components = append(doc.Components, c)
+	}
+	return doc
+}
+
+type spdxDoc struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages      []spdxPackage      `json:"packages"`
+	Relationships []spdxRelationship `json:"relationships"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	LicenseComments  string            `json:"licenseComments,omitempty"`
+	CopyrightText    string            `json:"copyrightText"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxDocument describes the image as the root package, containing one
+// package per inventory entry. Package licenses as recorded by package
+// managers are rarely valid SPDX expressions, so they go in licenseComments
+// and licenseDeclared stays NOASSERTION.
+func spdxDocument(sbom *domain.SBOM) *spdxDoc {
+	name := imageName(sbom)
+	doc := &spdxDoc{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + toolName + "-" + purlEscape(name) + "-" + documentUUID(sbom),
+	}
+	doc.CreationInfo.Created = sbom.GeneratedAt.UTC().Format(time.RFC3339)
+	doc.CreationInfo.Creators = []string{"Tool: " + toolName}
+
+	const imageID = "SPDXRef-Image"
+	doc.Packages = append(doc.Packages, spdxPackage{
+		Name:             name,
+		SPDXID:           imageID,
+		VersionInfo:      sbom.ImageID,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+		PrimaryPurpose:   "CONTAINER",
+	})
+	doc.Relationships = append(doc.Relationships, spdxRelationship{"SPDXRef-DOCUMENT", "DESCRIBES", imageID})
+	for i, p := range sbom.Packages {
+		sp := spdxPackage{
+			Name:             p.Name,
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			VersionInfo:      p.Version,
+			DownloadLocation: spdxNoAssertion,
+			LicenseConcluded: spdxNoAssertion,
+			LicenseDeclared:  spdxNoAssertion,
+			LicenseComments:  strings.Join(p.Licenses, "; "),
+			CopyrightText:    spdxNoAssertion,
+			SourceInfo:       "found in " + p.Location,
+			PrimaryPurpose:   "LIBRARY",
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  p.PURL,
+			}},
+		}
+		doc.Packages = append(doc.Packages, sp)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{imageID, "CONTAINS", sp.SPDXID})
+	}
+	return doc
+}
+
+func imageName(sbom *domain.SBOM) string {
+	if len(sbom.RepoTags) > 0 && sbom.RepoTags[0] != "<none>:<none>" {
+		return sbom.RepoTags[0]
+	}
+	return sbom.ImageID
+}
+
+// documentUUID derives a version 4 style UUID from the image and generation
+// time, so re-encoding a cached SBOM yields the same document identity.
+func documentUUID(sbom *domain.SBOM) string {
+	h := sha256.Sum256([]byte(sbom.ImageID + "\x00" + sbom.GeneratedAt.UTC().Format(time.RFC3339Nano)))
+	h[6] = h[6]&0x0f | 0x40
+	h[8] = h[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+var _ domain.SBOMEncoder = (*Encoder)(nil)
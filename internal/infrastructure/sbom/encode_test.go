@@ -0,0 +1,76 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func testSBOM() *domain.SBOM {
+	return &domain.SBOM{
+		ImageID:     "sha256:abc",
+		RepoTags:    []string{"app:1.0"},
+		Distro:      &domain.Distro{ID: "alpine", VersionID: "3.19.1"},
+		GeneratedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		Packages: []domain.Package{
+			{Name: "musl", Version: "1.2.4-r2", Type: domain.PackageTypeAPK, Licenses: []string{"MIT"}, PURL: "pkg:apk/alpine/musl@1.2.4-r2", Location: "/lib/apk/db/installed"},
+			{Name: "ms", Version: "2.0.0", Type: domain.PackageTypeNPM, PURL: "pkg:npm/ms@2.0.0", Location: "/a/package-lock.json"},
+			{Name: "ms", Version: "2.0.0", Type: domain.PackageTypeNPM, PURL: "pkg:npm/ms@2.0.0", Location: "/b/package-lock.json"},
+		},
+	}
+}
+
+func TestEncodeCycloneDX(t *testing.T) {
+	out, err := NewEncoder().Encode(testSBOM(), domain.SBOMFormatCycloneDX)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc cdxDocument
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc.BOMFormat != "CycloneDX" || doc.Metadata.Component.Name != "app:1.0" || doc.Metadata.Timestamp != "2026-05-01T12:00:00Z" {
+		t.Errorf("unexpected header: %+v", doc)
+	}
+	if len(doc.Components) != 4 || doc.Components[0].Type != "operating-system" {
+		t.Fatalf("components = %+v", doc.Components)
+	}
+	if doc.Components[1].Licenses[0].License.Name != "MIT" {
+		t.Errorf("musl = %+v", doc.Components[1])
+	}
+	if doc.Components[2].BOMRef == doc.Components[3].BOMRef {
+		t.Errorf("duplicate bom-ref %q", doc.Components[2].BOMRef)
+	}
+
+	again, _ := NewEncoder().Encode(testSBOM(), domain.SBOMFormatCycloneDX)
+	if string(again) != string(out) {
+		t.Error("encoding the same SBOM twice differs")
+	}
+}
+
+func TestEncodeSPDX(t *testing.T) {
+	out, err := NewEncoder().Encode(testSBOM(), domain.SBOMFormatSPDX)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc spdxDoc
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" || len(doc.Packages) != 4 || len(doc.Relationships) != 4 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	musl := doc.Packages[1]
+	if musl.ExternalRefs[0].ReferenceLocator != "pkg:apk/alpine/musl@1.2.4-r2" || musl.LicenseComments != "MIT" || musl.LicenseDeclared != "NOASSERTION" {
+		t.Errorf("musl = %+v", musl)
+	}
+	if doc.Relationships[0].RelationshipType != "DESCRIBES" || doc.Relationships[1].RelatedSPDXElement != musl.SPDXID {
+		t.Errorf("relationships = %+v", doc.Relationships)
+	}
+
+	if _, err := NewEncoder().Encode(testSBOM(), "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,49 @@
+package sbom
+
+import (
+	"bytes"
+	"debug/buildinfo"
+	"io"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+var elfMagic = []byte("\x7fELF")
+
+// readGoBinary lists the modules a Go program was built from, plus the
+// standard library as "stdlib" at the toolchain version. Other executables
+// yield nothing; only ELF files are read in full.
+func readGoBinary(r io.Reader, location string) ([]domain.Package, error) {
+	magic := make([]byte, len(elfMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, elfMagic) {
+		return nil, nil
+	}
+	data, err := io.ReadAll(io.MultiReader(bytes.NewReader(magic), io.LimitReader(r, maxBinaryBytes)))
+	if err != nil {
+		return nil, err
+	}
+	info, err := buildinfo.Read(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil
+	}
+
+	var pkgs []domain.Package
+	add := func(path, version string) {
+		if path == "" || version == "" || version == "(devel)" {
+			return
+		}
+		pkgs = append(pkgs, domain.Package{Name: path, Version: version, Type: domain.PackageTypeGolang, Location: location})
+	}
+	add(info.Main.Path, info.Main.Version)
+	for _, dep := range info.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		add(dep.Path, dep.Version)
+	}
+	// "go1.22.4" or "go1.22.4 X:boringcrypto".
+	version, _, _ := strings.Cut(info.GoVersion, " ")
+	add("stdlib", strings.TrimPrefix(version, "go"))
+	return pkgs, nil
+}
@@ -0,0 +1,120 @@
+package sbom
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type npmLockPackage struct {
+	Name         string                    `json:"name"`
+	Version      string                    `json:"version"`
+	License      any                       `json:"license"`
+	Link         bool                      `json:"link"`
+	Dependencies map[string]npmLockPackage `json:"dependencies"`
+}
+
+// parsePackageLock reads the "packages" map of lockfile versions 2 and 3,
+// falling back to the nested "dependencies" of version 1.
+func parsePackageLock(r io.Reader, location string) ([]domain.Package, error) {
+	var lock struct {
+		Packages     map[string]npmLockPackage `json:"packages"`
+		Dependencies map[string]npmLockPackage `json:"dependencies"`
+	}
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var pkgs []domain.Package
+	add := func(name string, p npmLockPackage) {
+		if name == "" || p.Version == "" || p.Link || seen[name+"@"+p.Version] {
+			return
+		}
+		seen[name+"@"+p.Version] = true
+		pkg := domain.Package{Name: name, Version: p.Version, Type: domain.PackageTypeNPM, Location: location}
+		if l, ok := p.License.(string); ok && l != "" {
+			pkg.Licenses = []string{l}
+		}
+		pkgs = append(pkgs, pkg)
+	}
+
+	if len(lock.Packages) > 0 {
+		keys := make([]string, 0, len(lock.Packages))
+		for k := range lock.Packages {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// The root project is "", dependencies "node_modules/a" or
+			// "node_modules/a/node_modules/@scope/b".
+			i := strings.LastIndex(k, "node_modules/")
+			if i < 0 {
+				continue
+			}
+			p := lock.Packages[k]
+			name := k[i+len("node_modules/"):]
+			if p.Name != "" {
+				// Aliased installs ("npm i x@npm:y") record the real name.
+				name = p.Name
+			}
+			add(name, p)
+		}
+		return pkgs, nil
+	}
+	var walk func(deps map[string]npmLockPackage)
+	walk = func(deps map[string]npmLockPackage) {
+		names := make([]string, 0, len(deps))
+		for n := range deps {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			add(n, deps[n])
+			walk(deps[n].Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return pkgs, nil
+}
+
+// requirementPattern matches "name[extras] <specifiers> ; markers", capturing
+// the name and an exact "==" or "===" pin.
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(?:===?\s*([^\s,;]+))?`)
+
+// parseRequirements lists the requirements of a pip requirements file; a
+// requirement without an exact pin has no version. Options, includes and
+// URL or path requirements are skipped.
+func parseRequirements(r io.Reader, location string) ([]domain.Package, error) {
+	var pkgs []domain.Package
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		version := strings.TrimSuffix(m[2], `\`)
+		if strings.Contains(version, "*") {
+			version = ""
+		}
+		pkgs = append(pkgs, domain.Package{
+			Name:     m[1],
+			Version:  version,
+			Type:     domain.PackageTypePyPI,
+			Location: location,
+		})
+	}
+	return pkgs, sc.Err()
+}
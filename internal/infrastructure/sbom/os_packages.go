@@ -0,0 +1,145 @@
+package sbom
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func parseOSRelease(r io.Reader) (*domain.Distro, error) {
+	d := &domain.Distro{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		key, val, ok := strings.Cut(strings.TrimSpace(sc.Text()), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(val); err == nil {
+			val = unquoted
+		} else {
+			val = strings.Trim(val, `'"`)
+		}
+		switch key {
+		case "ID":
+			d.ID = strings.ToLower(val)
+		case "VERSION_ID":
+			d.VersionID = val
+		case "PRETTY_NAME":
+			d.Name = val
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if d.ID == "" {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// paragraphs splits a deb822 or apk database into its stanzas, calling fn
+// with each stanza's fields. Continuation lines of deb822 fields are dropped
+// as no field read here spans lines.
+func paragraphs(r io.Reader, sep string, fn func(map[string]string)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 4<<20)
+	fields := make(map[string]string)
+	flush := func() {
+		if len(fields) > 0 {
+			fn(fields)
+			fields = make(map[string]string)
+		}
+	}
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		if key, val, ok := strings.Cut(line, sep); ok {
+			fields[key] = strings.TrimSpace(val)
+		}
+	}
+	flush()
+	return sc.Err()
+}
+
+// parseDpkgStatus reads /var/lib/dpkg/status, or one of the per-package
+// files distroless images keep in /var/lib/dpkg/status.d.
+func parseDpkgStatus(r io.Reader, location string) ([]domain.Package, error) {
+	var pkgs []domain.Package
+	err := paragraphs(r, ":", func(f map[string]string) {
+		if f["Package"] == "" || f["Version"] == "" {
+			return
+		}
+		// Removed packages keep a "deinstall ok config-files" stanza.
+		if st := f["Status"]; st != "" && !strings.HasSuffix(st, " installed") {
+			return
+		}
+		p := domain.Package{
+			Name:     f["Package"],
+			Version:  f["Version"],
+			Type:     domain.PackageTypeDeb,
+			Arch:     f["Architecture"],
+			Location: location,
+		}
+		// "Source: glibc (2.36-9)" when the versions differ.
+		if src, _, _ := strings.Cut(f["Source"], " "); src != p.Name {
+			p.SourceName = src
+		}
+		pkgs = append(pkgs, p)
+	})
+	return pkgs, err
+}
+
+// parseDebianCopyright collects the License fields of a machine-readable
+// (DEP-5) copyright file; free-form files yield nothing.
+func parseDebianCopyright(r io.Reader) ([]string, error) {
+	var licenses []string
+	seen := make(map[string]bool)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 4<<20)
+	for sc.Scan() {
+		val, ok := strings.CutPrefix(sc.Text(), "License:")
+		if !ok {
+			continue
+		}
+		if val = strings.TrimSpace(val); val != "" && !seen[val] {
+			seen[val] = true
+			licenses = append(licenses, val)
+		}
+	}
+	return licenses, sc.Err()
+}
+
+// parseAPKInstalled reads Alpine's /lib/apk/db/installed, whose stanzas hold
+// one-letter fields such as "P:busybox".
+func parseAPKInstalled(r io.Reader, location string) ([]domain.Package, error) {
+	var pkgs []domain.Package
+	err := paragraphs(r, ":", func(f map[string]string) {
+		if f["P"] == "" || f["V"] == "" {
+			return
+		}
+		p := domain.Package{
+			Name:     f["P"],
+			Version:  f["V"],
+			Type:     domain.PackageTypeAPK,
+			Arch:     f["A"],
+			Location: location,
+		}
+		if f["L"] != "" {
+			p.Licenses = []string{f["L"]}
+		}
+		if f["o"] != p.Name {
+			p.SourceName = f["o"]
+		}
+		pkgs = append(pkgs, p)
+	})
+	return pkgs, err
+}
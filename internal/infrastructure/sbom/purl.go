@@ -0,0 +1,85 @@
+package sbom
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// packageURL builds the package's purl (github.com/package-url/purl-spec).
+// OS packages are namespaced by distro and qualified with arch and
+// distro release, e.g. "pkg:deb/debian/bash@5.2.15-2?arch=amd64&distro=debian-12".
+func packageURL(p *domain.Package, distro *domain.Distro) string {
+	var namespace []string
+	name, version := p.Name, p.Version
+	qualifiers := map[string]string{}
+	switch p.Type {
+	case domain.PackageTypeDeb, domain.PackageTypeAPK, domain.PackageTypeRPM:
+		if distro != nil {
+			namespace = []string{distro.ID}
+			if distro.VersionID != "" {
+				qualifiers["distro"] = distro.ID + "-" + distro.VersionID
+			}
+		}
+		qualifiers["arch"] = p.Arch
+		if p.Type == domain.PackageTypeRPM {
+			// The epoch is a qualifier rather than part of the version.
+			if epoch, rest, ok := strings.Cut(version, ":"); ok {
+				qualifiers["epoch"], version = epoch, rest
+			}
+		}
+	case domain.PackageTypeGolang:
+		parts := strings.Split(name, "/")
+		namespace, name = parts[:len(parts)-1], parts[len(parts)-1]
+	case domain.PackageTypeNPM:
+		if scope, rest, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+			namespace, name = []string{scope}, rest
+		}
+	case domain.PackageTypePyPI:
+		name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	}
+
+	var b strings.Builder
+	b.WriteString("pkg:" + p.Type + "/")
+	for _, ns := range namespace {
+		b.WriteString(purlEscape(ns) + "/")
+	}
+	b.WriteString(purlEscape(name))
+	if version != "" {
+		b.WriteString("@" + purlEscape(version))
+	}
+	keys := make([]string, 0, len(qualifiers))
+	for k, v := range qualifiers {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		b.WriteString(sep + k + "=" + purlEscape(qualifiers[k]))
+	}
+	return b.String()
+}
+
+// purlEscape percent-encodes everything but unreserved characters.
+func purlEscape(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
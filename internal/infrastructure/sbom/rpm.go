@@ -0,0 +1,143 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// Header tags and types from rpm's rpmtag.h.
+const (
+	rpmTagName      = 1000
+	rpmTagVersion   = 1001
+	rpmTagRelease   = 1002
+	rpmTagEpoch     = 1003
+	rpmTagLicense   = 1014
+	rpmTagArch      = 1022
+	rpmTagSourceRPM = 1044
+
+	rpmTypeInt32  = 4
+	rpmTypeString = 6
+	rpmTypeI18N   = 9
+)
+
+var errInvalidRPMHeader = errors.New("invalid rpm header")
+
+type rpmHeader struct {
+	name, version, release, license, arch, sourceRPM string
+	epoch                                            int
+}
+
+// parseRPMHeader reads a header blob as rpm stores it in its database: the
+// index entry count and data size, the index entries, then the data store.
+func parseRPMHeader(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, errInvalidRPMHeader
+	}
+	il := int(binary.BigEndian.Uint32(blob))
+	dl := int(binary.BigEndian.Uint32(blob[4:]))
+	dataStart := 8 + il*16
+	if il <= 0 || il > len(blob)/16 || dl < 0 || dataStart+dl > len(blob) {
+		return nil, errInvalidRPMHeader
+	}
+	data := blob[dataStart : dataStart+dl]
+	h := &rpmHeader{}
+	for i := 0; i < il; i++ {
+		e := blob[8+i*16:]
+		tag := binary.BigEndian.Uint32(e)
+		typ := binary.BigEndian.Uint32(e[4:])
+		off := int(int32(binary.BigEndian.Uint32(e[8:])))
+		if off < 0 || off >= len(data) {
+			continue
+		}
+		var str string
+		switch typ {
+		case rpmTypeString, rpmTypeI18N:
+			str = string(data[off:])
+			if end := bytes.IndexByte(data[off:], 0); end >= 0 {
+				str = string(data[off : off+end])
+			}
+		case rpmTypeInt32:
+			if tag == rpmTagEpoch && off+4 <= len(data) {
+				h.epoch = int(int32(binary.BigEndian.Uint32(data[off:])))
+			}
+			continue
+		default:
+			continue
+		}
+		switch tag {
+		case rpmTagName:
+			h.name = str
+		case rpmTagVersion:
+			h.version = str
+		case rpmTagRelease:
+			h.release = str
+		case rpmTagLicense:
+			h.license = str
+		case rpmTagArch:
+			h.arch = str
+		case rpmTagSourceRPM:
+			h.sourceRPM = str
+		}
+	}
+	if h.name == "" || h.version == "" {
+		return nil, errInvalidRPMHeader
+	}
+	return h, nil
+}
+
+// rpmPackages skips unreadable headers and the gpg-pubkey pseudo-packages
+// rpm records imported signing keys as.
+func rpmPackages(blobs [][]byte, location string) []domain.Package {
+	var pkgs []domain.Package
+	for _, blob := range blobs {
+		h, err := parseRPMHeader(blob)
+		if err != nil || h.name == "gpg-pubkey" {
+			continue
+		}
+		p := domain.Package{
+			Name:     h.name,
+			Version:  rpmEVR(h.epoch, h.version, h.release),
+			Type:     domain.PackageTypeRPM,
+			Arch:     h.arch,
+			Location: location,
+		}
+		if h.license != "" {
+			p.Licenses = []string{h.license}
+		}
+		if src := rpmSourceName(h.sourceRPM); src != p.Name {
+			p.SourceName = src
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs
+}
+
+func rpmEVR(epoch int, version, release string) string {
+	v := version
+	if release != "" {
+		v += "-" + release
+	}
+	if epoch > 0 {
+		v = strconv.Itoa(epoch) + ":" + v
+	}
+	return v
+}
+
+// rpmSourceName turns "bash-5.1.8-6.el9.src.rpm" into "bash".
+func rpmSourceName(sourceRPM string) string {
+	s := strings.TrimSuffix(sourceRPM, ".rpm")
+	s = s[:max(strings.LastIndex(s, "."), 0)]
+	for range 2 {
+		i := strings.LastIndex(s, "-")
+		if i < 0 {
+			return ""
+		}
+		s = s[:i]
+	}
+	return s
+}
@@ -0,0 +1,255 @@
+package sbom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rpmHeaderBlob encodes a header the way rpm stores it in its database.
+func rpmHeaderBlob(name, version, release string, epoch int, license string) []byte {
+	type entry struct {
+		tag, typ uint32
+		value    any
+	}
+	entries := []entry{
+		{rpmTagName, rpmTypeString, name},
+		{rpmTagVersion, rpmTypeString, version},
+		{rpmTagRelease, rpmTypeString, release},
+		{rpmTagLicense, rpmTypeString, license},
+		{rpmTagArch, rpmTypeString, "x86_64"},
+		{rpmTagSourceRPM, rpmTypeString, strings.TrimSuffix(name, "-libs") + "-" + version + "-" + release + ".src.rpm"},
+	}
+	if epoch > 0 {
+		entries = append(entries, entry{rpmTagEpoch, rpmTypeInt32, int32(epoch)})
+	}
+	var index, data bytes.Buffer
+	for _, e := range entries {
+		if _, ok := e.value.(int32); ok {
+			for data.Len()%4 != 0 {
+				data.WriteByte(0)
+			}
+		}
+		binary.Write(&index, binary.BigEndian, [4]uint32{e.tag, e.typ, uint32(data.Len()), 1})
+		switch v := e.value.(type) {
+		case string:
+			data.WriteString(v + "\x00")
+		case int32:
+			binary.Write(&data, binary.BigEndian, v)
+		}
+	}
+	var blob bytes.Buffer
+	binary.Write(&blob, binary.BigEndian, [2]uint32{uint32(len(entries)), uint32(data.Len())})
+	blob.Write(index.Bytes())
+	blob.Write(data.Bytes())
+	return blob.Bytes()
+}
+
+func testRPMBlobs() [][]byte {
+	// The padded license makes the first header span overflow pages.
+	long := string(bytes.Repeat([]byte("GPL-2.0-or-later AND "), 40)) + "MIT"
+	return [][]byte{
+		rpmHeaderBlob("bash", "5.1.8", "6.el9", 0, long),
+		rpmHeaderBlob("openssl-libs", "3.0.7", "27.el9", 1, "Apache-2.0"),
+		rpmHeaderBlob("gpg-pubkey", "fd431d51", "4ae0493b", 0, "pubkey"),
+	}
+}
+
+func checkRPMPackages(t *testing.T, blobs [][]byte) {
+	t.Helper()
+	pkgs := rpmPackages(blobs, "/var/lib/rpm/x")
+	if len(pkgs) != 2 {
+		t.Fatalf("got %d packages, want 2: %+v", len(pkgs), pkgs)
+	}
+	if pkgs[0].Name != "bash" || pkgs[0].Version != "5.1.8-6.el9" || pkgs[0].Arch != "x86_64" || len(pkgs[0].Licenses[0]) < 800 {
+		t.Errorf("bash = %+v", pkgs[0])
+	}
+	if pkgs[1].Version != "1:3.0.7-27.el9" || pkgs[1].SourceName != "openssl" {
+		t.Errorf("openssl-libs = %+v", pkgs[1])
+	}
+}
+
+func sqliteVarintBytes(v int) []byte {
+	if v < 0x80 {
+		return []byte{byte(v)}
+	}
+	return []byte{byte(v>>7) | 0x80, byte(v & 0x7f)}
+}
+
+// sqliteRecordBytes encodes NULL (nil), small int, text and blob values.
+func sqliteRecordBytes(values ...any) []byte {
+	var types, body []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+			types = append(types, 0)
+		case int:
+			types = append(types, 1)
+			body = append(body, byte(v))
+		case string:
+			types = append(types, sqliteVarintBytes(13+2*len(v))...)
+			body = append(body, v...)
+		case []byte:
+			types = append(types, sqliteVarintBytes(12+2*len(v))...)
+			body = append(body, v...)
+		}
+	}
+	return append(append(sqliteVarintBytes(len(types)+1), types...), body...)
+}
+
+// testSQLiteDB lays out a 512-byte-page database: page 1 is the schema, page
+// 2 the Packages table, and later pages hold payload overflow.
+func testSQLiteDB(blobs [][]byte) []byte {
+	const pageSize = 512
+	pages := [][]byte{make([]byte, pageSize), make([]byte, pageSize)}
+	copy(pages[0], "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(pages[0][16:], pageSize)
+
+	leaf := func(pg []byte, hdrOff int, payloads [][]byte) {
+		pg[hdrOff] = 0x0d
+		binary.BigEndian.PutUint16(pg[hdrOff+3:], uint16(len(payloads)))
+		off := hdrOff + 8 + 2*len(payloads)
+		for i, p := range payloads {
+			binary.BigEndian.PutUint16(pg[hdrOff+8+2*i:], uint16(off))
+			cell := append(sqliteVarintBytes(len(p)), byte(i+1))
+			local := len(p)
+			if x := pageSize - 35; local > x {
+				m := (pageSize-12)*32/255 - 23
+				local = m + (len(p)-m)%(pageSize-4)
+				if local > x {
+					local = m
+				}
+			}
+			cell = append(cell, p[:local]...)
+			if rest := p[local:]; len(rest) > 0 {
+				cell = binary.BigEndian.AppendUint32(cell, uint32(len(pages)+1))
+				for len(rest) > 0 {
+					ov := make([]byte, pageSize)
+					n := copy(ov[4:], rest)
+					if rest = rest[n:]; len(rest) > 0 {
+						binary.BigEndian.PutUint32(ov, uint32(len(pages)+2))
+					}
+					pages = append(pages, ov)
+				}
+			}
+			off += copy(pg[off:], cell)
+		}
+	}
+	leaf(pages[0], 100, [][]byte{sqliteRecordBytes("table", "Packages", "Packages", 2, "CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")})
+	var rows [][]byte
+	for _, b := range blobs {
+		rows = append(rows, sqliteRecordBytes(nil, b))
+	}
+	leaf(pages[1], 0, rows)
+	return bytes.Join(pages, nil)
+}
+
+func TestSQLiteRPMBlobs(t *testing.T) {
+	blobs := testRPMBlobs()
+	got, err := sqliteRPMBlobs(testSQLiteDB(blobs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, blobs) {
+		t.Fatalf("got %d blobs, want the %d stored", len(got), len(blobs))
+	}
+	checkRPMPackages(t, got)
+}
+
+// testBDB lays out a little-endian hash database: the meta page, one hash
+// page with an off-page item and an inline one, then overflow pages.
+func testBDB(blobs [][]byte) []byte {
+	const pageSize = 512
+	le := binary.LittleEndian
+	pages := [][]byte{make([]byte, pageSize), make([]byte, pageSize)}
+	le.PutUint32(pages[0][12:], bdbHashMagic)
+	le.PutUint32(pages[0][20:], pageSize)
+
+	hash := pages[1]
+	hash[25] = bdbPageHash
+	end := pageSize
+	put := func(i int, item []byte) {
+		end -= len(item)
+		copy(hash[end:], item)
+		le.PutUint16(hash[bdbPageHeaderSize+2*i:], uint16(end))
+	}
+	for i, blob := range blobs {
+		put(2*i, []byte{bdbItemKeyData, byte(i + 1), 0, 0, 0})
+		if len(blob) < 200 {
+			put(2*i+1, append([]byte{bdbItemKeyData}, blob...))
+			continue
+		}
+		item := make([]byte, 12)
+		item[0] = bdbItemOffPage
+		le.PutUint32(item[4:], uint32(len(pages)))
+		le.PutUint32(item[8:], uint32(len(blob)))
+		put(2*i+1, item)
+		for rest := blob; len(rest) > 0; {
+			ov := make([]byte, pageSize)
+			ov[25] = bdbPageOverflow
+			n := copy(ov[bdbPageHeaderSize:], rest)
+			le.PutUint16(ov[22:], uint16(n))
+			if rest = rest[n:]; len(rest) > 0 {
+				le.PutUint32(ov[16:], uint32(len(pages)+1))
+			}
+			pages = append(pages, ov)
+		}
+	}
+	le.PutUint16(hash[20:], uint16(2*len(blobs)))
+	le.PutUint32(pages[0][32:], uint32(len(pages)-1))
+	return bytes.Join(pages, nil)
+}
+
+func TestBDBRPMBlobs(t *testing.T) {
+	blobs := testRPMBlobs()[:2]
+	got, err := bdbRPMBlobs(testBDB(blobs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, blobs) {
+		t.Fatalf("got %d blobs, want the %d stored", len(got), len(blobs))
+	}
+	checkRPMPackages(t, got)
+}
+
+func TestNDBRPMBlobs(t *testing.T) {
+	le := binary.LittleEndian
+	db := make([]byte, ndbPageSize)
+	le.PutUint32(db, ndbHeaderMagic)
+	le.PutUint32(db[12:], 1)
+	blobs := testRPMBlobs()
+	for i, blob := range blobs {
+		slot := db[16*(i+1):]
+		le.PutUint32(slot, ndbSlotMagic)
+		le.PutUint32(slot[4:], uint32(i+1))
+		le.PutUint32(slot[8:], uint32(len(db)/ndbBlockSize))
+		hdr := make([]byte, 16)
+		le.PutUint32(hdr, ndbBlobMagic)
+		le.PutUint32(hdr[12:], uint32(len(blob)))
+		db = append(append(db, hdr...), blob...)
+		for len(db)%ndbBlockSize != 0 {
+			db = append(db, 0)
+		}
+	}
+	got, err := ndbRPMBlobs(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, blobs) {
+		t.Fatalf("got %d blobs, want the %d stored", len(got), len(blobs))
+	}
+}
+
+func TestRPMSourceName(t *testing.T) {
+	for in, want := range map[string]string{
+		"bash-5.1.8-6.el9.src.rpm":        "bash",
+		"python3.11-3.11.2-2.el9.src.rpm": "python3.11",
+		"":                                "",
+	} {
+		if got := rpmSourceName(in); got != want {
+			t.Errorf("rpmSourceName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package sbom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// rpm has kept its package headers in three database formats: SQLite
+// (rpmdb.sqlite, Fedora 33+ and RHEL 9), Berkeley DB hash (Packages, older
+// RHEL, CentOS and Amazon Linux) and NDB (Packages.db, SUSE). Each reader
+// below returns the raw header blobs and understands just enough of its
+// format for that.
+
+// sqliteRPMBlobs returns the blob column of the Packages table.
+func sqliteRPMBlobs(db []byte) ([][]byte, error) {
+	s, err := openSQLite(db)
+	if err != nil {
+		return nil, err
+	}
+	root := 0
+	err = s.walkTable(1, func(rec []any) {
+		if len(rec) >= 4 && rec[0] == "table" && rec[1] == "Packages" {
+			if n, ok := rec[3].(int64); ok {
+				root = int(n)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if root == 0 {
+		return nil, errors.New("sqlite: no Packages table")
+	}
+	var blobs [][]byte
+	err = s.walkTable(root, func(rec []any) {
+		if len(rec) >= 2 {
+			if b, ok := rec[1].([]byte); ok {
+				blobs = append(blobs, b)
+			}
+		}
+	})
+	return blobs, err
+}
+
+type sqliteFile struct {
+	data     []byte
+	pageSize int
+	usable   int
+}
+
+func openSQLite(data []byte) (*sqliteFile, error) {
+	if len(data) < 100 || string(data[:16]) != "SQLite format 3\x00" {
+		return nil, errors.New("sqlite: bad header")
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("sqlite: bad page size %d", pageSize)
+	}
+	return &sqliteFile{data: data, pageSize: pageSize, usable: pageSize - int(data[20])}, nil
+}
+
+func (s *sqliteFile) page(n int) ([]byte, error) {
+	start := (n - 1) * s.pageSize
+	if n < 1 || start+s.pageSize > len(s.data) {
+		return nil, fmt.Errorf("sqlite: page %d out of range", n)
+	}
+	return s.data[start : start+s.pageSize], nil
+}
+
+// walkTable calls fn with the decoded record of every row of the table
+// b-tree rooted at page root.
+func (s *sqliteFile) walkTable(root int, fn func([]any)) error {
+	visited := make(map[int]bool)
+	var walk func(n int) error
+	walk = func(n int) error {
+		if visited[n] {
+			return fmt.Errorf("sqlite: page %d visited twice", n)
+		}
+		visited[n] = true
+		pg, err := s.page(n)
+		if err != nil {
+			return err
+		}
+		hdr := pg
+		if n == 1 {
+			hdr = pg[100:]
+		}
+		cells := int(binary.BigEndian.Uint16(hdr[3:]))
+		switch hdr[0] {
+		case 0x05: // interior table page
+			ptrs := hdr[12:]
+			for i := 0; i < cells && 2*i+2 <= len(ptrs); i++ {
+				off := int(binary.BigEndian.Uint16(ptrs[2*i:]))
+				if off+4 > len(pg) {
+					return errors.New("sqlite: bad cell offset")
+				}
+				if err := walk(int(binary.BigEndian.Uint32(pg[off:]))); err != nil {
+					return err
+				}
+			}
+			return walk(int(binary.BigEndian.Uint32(hdr[8:])))
+		case 0x0d: // leaf table page
+			ptrs := hdr[8:]
+			for i := 0; i < cells && 2*i+2 <= len(ptrs); i++ {
+				payload, err := s.leafPayload(pg, int(binary.BigEndian.Uint16(ptrs[2*i:])))
+				if err != nil {
+					return err
+				}
+				rec, err := sqliteRecord(payload)
+				if err != nil {
+					return err
+				}
+				fn(rec)
+			}
+			return nil
+		default:
+			return fmt.Errorf("sqlite: page %d is not a table page", n)
+		}
+	}
+	return walk(root)
+}
+
+// leafPayload reads a leaf cell's payload, following its overflow pages.
+func (s *sqliteFile) leafPayload(pg []byte, off int) ([]byte, error) {
+	if off >= len(pg) {
+		return nil, errors.New("sqlite: bad cell offset")
+	}
+	size, n := sqliteVarint(pg[off:])
+	off += n
+	_, n = sqliteVarint(pg[off:]) // rowid
+	off += n
+	if size < 0 || size > int64(len(s.data)) {
+		return nil, errors.New("sqlite: bad payload size")
+	}
+	total := int(size)
+	// The spilling rule of the file format: X, M and K as in the spec.
+	local := total
+	if x := s.usable - 35; total > x {
+		m := (s.usable-12)*32/255 - 23
+		k := m + (total-m)%(s.usable-4)
+		local = m
+		if k <= x {
+			local = k
+		}
+	}
+	if off+local > len(pg) {
+		return nil, errors.New("sqlite: cell overflows page")
+	}
+	payload := make([]byte, 0, total)
+	payload = append(payload, pg[off:off+local]...)
+	if local == total {
+		return payload, nil
+	}
+	if off+local+4 > len(pg) {
+		return nil, errors.New("sqlite: cell overflows page")
+	}
+	next := int(binary.BigEndian.Uint32(pg[off+local:]))
+	for len(payload) < total {
+		ov, err := s.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := min(total-len(payload), s.usable-4)
+		payload = append(payload, ov[4:4+chunk]...)
+		next = int(binary.BigEndian.Uint32(ov))
+	}
+	return payload, nil
+}
+
+func sqliteVarint(b []byte) (int64, int) {
+	var v int64
+	for i := 0; i < 9 && i < len(b); i++ {
+		if i == 8 {
+			return v<<8 | int64(b[i]), 9
+		}
+		v = v<<7 | int64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v, len(b)
+}
+
+// sqliteRecord decodes a record into nil, int64, float placeholders (nil),
+// string and []byte values.
+func sqliteRecord(p []byte) ([]any, error) {
+	hdrLen, n := sqliteVarint(p)
+	if hdrLen < int64(n) || hdrLen > int64(len(p)) {
+		return nil, errors.New("sqlite: bad record header")
+	}
+	var types []int64
+	for off := n; off < int(hdrLen); {
+		t, n := sqliteVarint(p[off:int(hdrLen)])
+		types = append(types, t)
+		off += n
+	}
+	body := p[hdrLen:]
+	rec := make([]any, 0, len(types))
+	for _, t := range types {
+		var size int
+		switch {
+		case t >= 1 && t <= 4:
+			size = int(t)
+		case t == 5:
+			size = 6
+		case t == 6 || t == 7:
+			size = 8
+		case t >= 12:
+			size = int((t - 12) / 2)
+		}
+		if size > len(body) {
+			return nil, errors.New("sqlite: record overflows payload")
+		}
+		v := body[:size]
+		body = body[size:]
+		switch {
+		case t >= 1 && t <= 6:
+			var n int64
+			for _, b := range v {
+				n = n<<8 | int64(b)
+			}
+			// Sign-extend.
+			shift := 64 - 8*size
+			rec = append(rec, n<<shift>>shift)
+		case t == 8:
+			rec = append(rec, int64(0))
+		case t == 9:
+			rec = append(rec, int64(1))
+		case t >= 12 && t%2 == 0:
+			rec = append(rec, v)
+		case t >= 13:
+			rec = append(rec, string(v))
+		default:
+			rec = append(rec, nil)
+		}
+	}
+	return rec, nil
+}
+
+// Berkeley DB hash database constants from db_page.h.
+const (
+	bdbHashMagic      = 0x061561
+	bdbPageHeaderSize = 26
+	bdbPageHash       = 13
+	bdbPageHashOld    = 2 // P_HASH_UNSORTED
+	bdbPageOverflow   = 7
+	bdbItemKeyData    = 1
+	bdbItemOffPage    = 3
+)
+
+// bdbRPMBlobs returns every data item of a Berkeley DB hash database; rpm's
+// Packages maps header numbers to header blobs, which are large enough to
+// usually live on overflow pages.
+func bdbRPMBlobs(db []byte) ([][]byte, error) {
+	if len(db) < 72 {
+		return nil, errors.New("bdb: file too short")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(db[12:]) != bdbHashMagic {
+		order = binary.BigEndian
+		if order.Uint32(db[12:]) != bdbHashMagic {
+			return nil, errors.New("bdb: not a hash database")
+		}
+	}
+	pageSize := int(order.Uint32(db[20:]))
+	lastPage := int(order.Uint32(db[32:]))
+	if pageSize < 512 || pageSize > 65536 {
+		return nil, fmt.Errorf("bdb: bad page size %d", pageSize)
+	}
+	page := func(n int) []byte {
+		start := n * pageSize
+		if n < 0 || start+pageSize > len(db) {
+			return nil
+		}
+		return db[start : start+pageSize]
+	}
+
+	var blobs [][]byte
+	for n := 1; n <= lastPage; n++ {
+		pg := page(n)
+		if pg == nil {
+			break
+		}
+		if typ := pg[25]; typ != bdbPageHash && typ != bdbPageHashOld {
+			continue
+		}
+		entries := int(order.Uint16(pg[20:]))
+		index := func(i int) int { return int(order.Uint16(pg[bdbPageHeaderSize+2*i:])) }
+		if bdbPageHeaderSize+2*entries > len(pg) {
+			continue
+		}
+		// Entries alternate key and data; items are packed from the end
+		// of the page, so an item ends where the previous one starts.
+		for i := 1; i < entries; i += 2 {
+			off, end := index(i), index(i-1)
+			if off >= end || end > len(pg) {
+				continue
+			}
+			switch pg[off] {
+			case bdbItemKeyData:
+				blobs = append(blobs, pg[off+1:end])
+			case bdbItemOffPage:
+				if off+12 > len(pg) {
+					continue
+				}
+				blob, err := bdbOverflow(page, order, int(order.Uint32(pg[off+4:])), int(order.Uint32(pg[off+8:])))
+				if err != nil {
+					return nil, err
+				}
+				blobs = append(blobs, blob)
+			}
+		}
+	}
+	return blobs, nil
+}
+
+func bdbOverflow(page func(int) []byte, order binary.ByteOrder, n, length int) ([]byte, error) {
+	blob := make([]byte, 0, length)
+	for seen := 0; n != 0 && len(blob) < length; seen++ {
+		pg := page(n)
+		if pg == nil || pg[25] != bdbPageOverflow || seen > length {
+			return nil, fmt.Errorf("bdb: bad overflow page %d", n)
+		}
+		// On overflow pages the free-area offset holds the data length.
+		used := int(order.Uint16(pg[22:]))
+		if bdbPageHeaderSize+used > len(pg) {
+			return nil, fmt.Errorf("bdb: bad overflow page %d", n)
+		}
+		blob = append(blob, pg[bdbPageHeaderSize:bdbPageHeaderSize+used]...)
+		n = int(order.Uint32(pg[16:]))
+	}
+	if len(blob) < length {
+		return nil, errors.New("bdb: truncated overflow chain")
+	}
+	return blob[:length], nil
+}
+
+// NDB constants from rpm's backend/ndb/rpmpkg.c.
+const (
+	ndbHeaderMagic = 'R' | 'p'<<8 | 'm'<<16 | 'P'<<24
+	ndbSlotMagic   = 'S' | 'l'<<8 | 'o'<<16 | 't'<<24
+	ndbBlobMagic   = 'B' | 'l'<<8 | 'b'<<16 | 'S'<<24
+	ndbPageSize    = 4096
+	ndbBlockSize   = 16
+)
+
+// ndbRPMBlobs reads the slot pages at the start of an NDB Packages.db, each
+// slot locating one header blob by block offset.
+func ndbRPMBlobs(db []byte) ([][]byte, error) {
+	le := binary.LittleEndian
+	if len(db) < ndbPageSize || le.Uint32(db) != ndbHeaderMagic {
+		return nil, errors.New("ndb: bad header")
+	}
+	slotPages := int(le.Uint32(db[12:]))
+	if slotPages <= 0 || slotPages*ndbPageSize > len(db) {
+		return nil, errors.New("ndb: bad slot page count")
+	}
+	var blobs [][]byte
+	// The first slot is taken by the file header.
+	for off := 16; off+16 <= slotPages*ndbPageSize; off += 16 {
+		slot := db[off:]
+		if le.Uint32(slot) != ndbSlotMagic || le.Uint32(slot[4:]) == 0 {
+			continue
+		}
+		start := int(le.Uint32(slot[8:])) * ndbBlockSize
+		if start+16 > len(db) || le.Uint32(db[start:]) != ndbBlobMagic {
+			continue
+		}
+		length := int(le.Uint32(db[start+12:]))
+		if start+16+length > len(db) {
+			continue
+		}
+		blobs = append(blobs, db[start+16:start+16+length])
+	}
+	return blobs, nil
+}
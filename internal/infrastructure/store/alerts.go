@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxStoredAlerts = 1000
+
+type AlertStore struct {
+	log *cappedLog[domain.Alert]
+}
+
+func NewAlertStore(dataDir string) *AlertStore {
+	return &AlertStore{log: newCappedLog[domain.Alert](filepath.Join(dataDir, "alerts.json"), maxStoredAlerts)}
+}
+
+func (s *AlertStore) Append(ctx context.Context, a *domain.Alert) error {
+	return s.log.append(a)
+}
+
+// List returns up to limit alerts, newest first.
+func (s *AlertStore) List(ctx context.Context, limit int) ([]*domain.Alert, error) {
+	return s.log.newest(limit)
+}
+
+var _ domain.AlertRepository = (*AlertStore)(nil)
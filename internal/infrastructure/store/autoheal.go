@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxStoredAutoHealActions = 1000
+
+type AutoHealAuditStore struct {
+	log *cappedLog[domain.AutoHealAction]
+}
+
+func NewAutoHealAuditStore(dataDir string) *AutoHealAuditStore {
+	return &AutoHealAuditStore{log: newCappedLog[domain.AutoHealAction](filepath.Join(dataDir, "autoheal_audit.json"), maxStoredAutoHealActions)}
+}
+
+func (s *AutoHealAuditStore) Append(ctx context.Context, a *domain.AutoHealAction) error {
+	return s.log.append(a)
+}
+
+// List returns up to limit actions, newest first.
+func (s *AutoHealAuditStore) List(ctx context.Context, limit int) ([]*domain.AutoHealAction, error) {
+	return s.log.newest(limit)
+}
+
+var _ domain.AutoHealAuditRepository = (*AutoHealAuditStore)(nil)
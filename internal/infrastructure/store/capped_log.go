@@ -0,0 +1,58 @@
+package store
+
+import "sync"
+
+// cappedLog is an append-only list stored in one JSON file that keeps only
+// the newest max entries.
+type cappedLog[T any] struct {
+	mu      sync.Mutex
+	file    jsonFile
+	max     int
+	loaded  bool
+	entries []*T
+}
+
+func newCappedLog[T any](path string, max int) *cappedLog[T] {
+	return &cappedLog[T]{file: jsonFile{path: path}, max: max}
+}
+
+func (l *cappedLog[T]) load() error {
+	if l.loaded {
+		return nil
+	}
+	if err := l.file.load(&l.entries); err != nil {
+		return err
+	}
+	l.loaded = true
+	return nil
+}
+
+func (l *cappedLog[T]) append(e *T) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.load(); err != nil {
+		return err
+	}
+	l.entries = append(l.entries, e)
+	if n := len(l.entries) - l.max; n > 0 {
+		l.entries = append([]*T(nil), l.entries[n:]...)
+	}
+	return l.file.save(l.entries)
+}
+
+// newest returns up to limit entries, newest first. limit <= 0 means all.
+func (l *cappedLog[T]) newest(limit int) ([]*T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > len(l.entries) {
+		limit = len(l.entries)
+	}
+	out := make([]*T, 0, limit)
+	for i := len(l.entries) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, l.entries[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const credentialKeySize = 32
+
+// sealedCredential is the at-rest form of a credential: only the registry
+// host is stored in clear, everything else is sealed with AES-256-GCM using
+// the host as additional data, so entries cannot be swapped between hosts.
+type sealedCredential struct {
+	Registry string `json:"registry"`
+	Nonce    []byte `json:"nonce"`
+	Data     []byte `json:"data"`
+}
+
+// CredentialStore keeps registry credentials encrypted in
+// registry_credentials.json.
+type CredentialStore struct {
+	items *collection[sealedCredential]
+	aead  cipher.AEAD
+}
+
+// NewCredentialStore takes a 32-byte key, see LoadCredentialKey.
+func NewCredentialStore(dataDir string, key []byte) (*CredentialStore, error) {
+	aead, err := newCredentialAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialStore{
+		items: newCollection(filepath.Join(dataDir, "registry_credentials.json"), func(c *sealedCredential) string { return c.Registry }),
+		aead:  aead,
+	}, nil
+}
+
+func newCredentialAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != credentialKeySize {
+		return nil, fmt.Errorf("credential key must be %d bytes, got %d", credentialKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// DefaultCredentialKeyPath is where LoadCredentialKey keeps the key when
+// none is given. It sits next to the data it protects, so anyone with a copy
+// of dataDir can decrypt it.
+func DefaultCredentialKeyPath(dataDir string) string {
+	return filepath.Join(dataDir, "credentials.key")
+}
+
+// LoadCredentialKey returns the key given inline (base64 or hex), or read
+// from keyFile. With neither, it uses DefaultCredentialKeyPath, creating it
+// with a random key on first use; generated reports that case.
+func LoadCredentialKey(inline, keyFile, dataDir string) (key []byte, generated bool, err error) {
+	if inline != "" {
+		key, err = parseCredentialKey(inline)
+		return key, false, err
+	}
+	if keyFile != "" {
+		key, err = readCredentialKey(keyFile)
+		return key, false, err
+	}
+
+	path := DefaultCredentialKeyPath(dataDir)
+	key, err = readCredentialKey(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return key, false, err
+	}
+	key = make([]byte, credentialKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return nil, false, err
+	}
+	// O_EXCL so two instances starting together cannot each write a key.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		key, err = readCredentialKey(path)
+		return key, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	_, err = f.WriteString(base64.StdEncoding.EncodeToString(key) + "\n")
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, false, err
+	}
+	return key, true, nil
+}
+
+func readCredentialKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseCredentialKey(string(data))
+}
+
+func parseCredentialKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == credentialKeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(s); err == nil && len(key) == credentialKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("credential key must be %d bytes, base64 or hex encoded", credentialKeySize)
+}
+
+func (s *CredentialStore) List(ctx context.Context) ([]*domain.StoredRegistryCredential, error) {
+	sealed, err := s.items.list()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.StoredRegistryCredential, 0, len(sealed))
+	for _, sc := range sealed {
+		c, err := s.open(sc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (s *CredentialStore) Get(ctx context.Context, registry string) (*domain.StoredRegistryCredential, error) {
+	sc, err := s.items.get(registry)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(sc)
+}
+
+func (s *CredentialStore) Save(ctx context.Context, c *domain.StoredRegistryCredential) error {
+	plain, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return s.items.put(&sealedCredential{
+		Registry: c.Registry,
+		Nonce:    nonce,
+		Data:     s.aead.Seal(nil, nonce, plain, []byte(c.Registry)),
+	})
+}
+
+func (s *CredentialStore) Delete(ctx context.Context, registry string) error {
+	return s.items.delete(registry)
+}
+
+// CredentialFor returns the credential for the registry hosting imageRef, or
+// nil when there is none.
+func (s *CredentialStore) CredentialFor(ctx context.Context, imageRef string) (*domain.RegistryCredential, error) {
+	c, err := s.Get(ctx, domain.ImageRegistryHost(imageRef))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c.RegistryCredential, nil
+}
+
+func (s *CredentialStore) open(sc *sealedCredential) (*domain.StoredRegistryCredential, error) {
+	plain, err := s.aead.Open(nil, sc.Nonce, sc.Data, []byte(sc.Registry))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credential for %s (wrong credential key?): %w", sc.Registry, err)
+	}
+	var c domain.StoredRegistryCredential
+	if err := json.Unmarshal(plain, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+var (
+	_ domain.RegistryCredentialRepository = (*CredentialStore)(nil)
+	_ domain.RegistryCredentialProvider   = (*CredentialStore)(nil)
+)
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestCredentialStore_EncryptsAtRest(t *testing.T) {
+	dir := t.TempDir()
+	key, generated, err := LoadCredentialKey("", "", dir)
+	if err != nil || !generated {
+		t.Fatalf("LoadCredentialKey = %v, %v", generated, err)
+	}
+	again, generated, err := LoadCredentialKey("", "", dir)
+	if err != nil || generated || !bytes.Equal(key, again) {
+		t.Fatalf("generated key not reused: %v", err)
+	}
+	if info, err := os.Stat(filepath.Join(dir, "credentials.key")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("key file: %v, %v", info, err)
+	}
+
+	s, err := NewCredentialStore(dir, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	cred := &domain.StoredRegistryCredential{RegistryCredential: domain.RegistryCredential{Registry: "registry.local:5000", Username: "ci-bot", Password: "hunter2"}}
+	if err := s.Save(ctx, cred); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "registry_credentials.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("hunter2")) || bytes.Contains(raw, []byte("ci-bot")) {
+		t.Errorf("credential stored in clear: %s", raw)
+	}
+
+	got, err := s.CredentialFor(ctx, "registry.local:5000/team/app:v1")
+	if err != nil || got == nil || got.Password != "hunter2" {
+		t.Fatalf("CredentialFor = %+v, %v", got, err)
+	}
+	if got, err := s.CredentialFor(ctx, "nginx:latest"); got != nil || err != nil {
+		t.Errorf("expected no Docker Hub credential, got %+v, %v", got, err)
+	}
+
+	other, _ := NewCredentialStore(dir, bytes.Repeat([]byte{1}, credentialKeySize))
+	if _, err := other.Get(ctx, "registry.local:5000"); err == nil || errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected a decryption error with the wrong key, got %v", err)
+	}
+}
+
+func TestParseCredentialKey(t *testing.T) {
+	for _, in := range []string{
+		"AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=\n",
+		"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+	} {
+		key, err := parseCredentialKey(in)
+		if err != nil || key[31] != 31 {
+			t.Errorf("%q: %v", in, err)
+		}
+	}
+	if _, err := parseCredentialKey("too short"); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestRegistryStore_SealsCredentials(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewRegistryStore(dir, bytes.Repeat([]byte{7}, credentialKeySize))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	reg := &domain.Registry{Name: "local", URL: "https://registry.local:5000", Username: "ci-bot", Password: "hunter2"}
+	if err := s.Save(ctx, reg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "registries.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("hunter2")) || bytes.Contains(raw, []byte("ci-bot")) {
+		t.Errorf("registry credentials stored in clear: %s", raw)
+	}
+	if !bytes.Contains(raw, []byte("https://registry.local:5000")) {
+		t.Errorf("registry URL missing: %s", raw)
+	}
+
+	got, err := s.Get(ctx, "local")
+	if err != nil || got.Username != "ci-bot" || got.Password != "hunter2" {
+		t.Fatalf("Get = %+v, %v", got, err)
+	}
+	list, err := s.List(ctx)
+	if err != nil || len(list) != 1 || list[0].Password != "hunter2" {
+		t.Fatalf("List = %+v, %v", list, err)
+	}
+}
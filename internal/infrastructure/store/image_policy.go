@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxStoredImagePolicyDecisions = 1000
+
+// ImagePolicyStore keeps the image policy in image_policy.json.
+type ImagePolicyStore struct {
+	mu   sync.Mutex
+	file jsonFile
+}
+
+func NewImagePolicyStore(dataDir string) *ImagePolicyStore {
+	return &ImagePolicyStore{file: jsonFile{path: filepath.Join(dataDir, "image_policy.json")}}
+}
+
+func (s *ImagePolicyStore) Get(ctx context.Context) (*domain.ImagePolicy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := domain.ImagePolicy{Mode: domain.ImagePolicyOff}
+	if err := s.file.load(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (s *ImagePolicyStore) Save(ctx context.Context, p *domain.ImagePolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.save(p)
+}
+
+type ImagePolicyDecisionStore struct {
+	log *cappedLog[domain.ImagePolicyDecision]
+}
+
+func NewImagePolicyDecisionStore(dataDir string) *ImagePolicyDecisionStore {
+	return &ImagePolicyDecisionStore{log: newCappedLog[domain.ImagePolicyDecision](filepath.Join(dataDir, "image_policy_decisions.json"), maxStoredImagePolicyDecisions)}
+}
+
+func (s *ImagePolicyDecisionStore) Append(ctx context.Context, d *domain.ImagePolicyDecision) error {
+	return s.log.append(d)
+}
+
+// List returns up to limit decisions, newest first.
+func (s *ImagePolicyDecisionStore) List(ctx context.Context, limit int) ([]*domain.ImagePolicyDecision, error) {
+	return s.log.newest(limit)
+}
+
+var (
+	_ domain.ImagePolicyRepository         = (*ImagePolicyStore)(nil)
+	_ domain.ImagePolicyDecisionRepository = (*ImagePolicyDecisionStore)(nil)
+)
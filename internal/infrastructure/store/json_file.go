@@ -0,0 +1,145 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// jsonFile persists a single value as indented JSON, replacing the file
+// atomically on every save.
+type jsonFile struct {
+	path string
+}
+
+func (f *jsonFile) load(v any) error {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (f *jsonFile) save(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// collection is a keyed set of records stored in one JSON file.
+type collection[T any] struct {
+	mu   sync.Mutex
+	file jsonFile
+	key  func(*T) string
+}
+
+func newCollection[T any](path string, key func(*T) string) *collection[T] {
+	return &collection[T]{file: jsonFile{path: path}, key: key}
+}
+
+func (c *collection[T]) readAll() (map[string]*T, error) {
+	var items []*T
+	if err := c.file.load(&items); err != nil {
+		return nil, err
+	}
+	out := make(map[string]*T, len(items))
+	for _, it := range items {
+		out[c.key(it)] = it
+	}
+	return out, nil
+}
+
+func (c *collection[T]) writeAll(items map[string]*T) error {
+	return c.file.save(sortedValues(items))
+}
+
+func sortedValues[T any](items map[string]*T) []*T {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]*T, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, items[k])
+	}
+	return out
+}
+
+func (c *collection[T]) list() ([]*T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items, err := c.readAll()
+	if err != nil {
+		return nil, err
+	}
+	return sortedValues(items), nil
+}
+
+func (c *collection[T]) get(key string) (*T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items, err := c.readAll()
+	if err != nil {
+		return nil, err
+	}
+	it, ok := items[key]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return it, nil
+}
+
+func (c *collection[T]) put(it *T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items, err := c.readAll()
+	if err != nil {
+		return err
+	}
+	items[c.key(it)] = it
+	return c.writeAll(items)
+}
+
+func (c *collection[T]) delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	items, err := c.readAll()
+	if err != nil {
+		return err
+	}
+	if _, ok := items[key]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(items, key)
+	return c.writeAll(items)
+}
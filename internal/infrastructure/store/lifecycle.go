@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultLifecycleEventsPerContainer = 200
+
+// LifecycleHistory keeps the latest lifecycle events of each container in
+// memory. Docker's own RestartCount survives DockScope restarts; this
+// history does not.
+type LifecycleHistory struct {
+	mu     sync.RWMutex
+	max    int
+	events map[string][]domain.LifecycleEvent
+}
+
+func NewLifecycleHistory() *LifecycleHistory {
+	return &LifecycleHistory{
+		max:    defaultLifecycleEventsPerContainer,
+		events: make(map[string][]domain.LifecycleEvent),
+	}
+}
+
+func (h *LifecycleHistory) Record(ctx context.Context, containerID string, ev domain.LifecycleEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	list := append(h.events[containerID], ev)
+	if n := len(list) - h.max; n > 0 {
+		list = append([]domain.LifecycleEvent(nil), list[n:]...)
+	}
+	h.events[containerID] = list
+	return nil
+}
+
+func (h *LifecycleHistory) History(ctx context.Context, containerID string) ([]domain.LifecycleEvent, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]domain.LifecycleEvent(nil), h.events[containerID]...), nil
+}
+
+func (h *LifecycleHistory) All(ctx context.Context) (map[string][]domain.LifecycleEvent, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make(map[string][]domain.LifecycleEvent, len(h.events))
+	for id, list := range h.events {
+		out[id] = append([]domain.LifecycleEvent(nil), list...)
+	}
+	return out, nil
+}
+
+func (h *LifecycleHistory) Forget(ctx context.Context, containerID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.events, containerID)
+	return nil
+}
+
+var _ domain.LifecycleRepository = (*LifecycleHistory)(nil)
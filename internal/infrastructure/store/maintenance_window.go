@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type MaintenanceWindowStore struct {
+	items *collection[domain.MaintenanceWindow]
+}
+
+func NewMaintenanceWindowStore(dataDir string) *MaintenanceWindowStore {
+	return &MaintenanceWindowStore{
+		items: newCollection(filepath.Join(dataDir, "maintenance_windows.json"), func(w *domain.MaintenanceWindow) string { return w.ID }),
+	}
+}
+
+func (s *MaintenanceWindowStore) List(ctx context.Context) ([]*domain.MaintenanceWindow, error) {
+	return s.items.list()
+}
+
+func (s *MaintenanceWindowStore) Get(ctx context.Context, id string) (*domain.MaintenanceWindow, error) {
+	return s.items.get(id)
+}
+
+func (s *MaintenanceWindowStore) Save(ctx context.Context, w *domain.MaintenanceWindow) error {
+	return s.items.put(w)
+}
+
+func (s *MaintenanceWindowStore) Delete(ctx context.Context, id string) error {
+	return s.items.delete(id)
+}
+
+var _ domain.MaintenanceWindowRepository = (*MaintenanceWindowStore)(nil)
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type NotificationChannelStore struct {
+	items *collection[domain.NotificationChannel]
+}
+
+func NewNotificationChannelStore(dataDir string) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		items: newCollection(filepath.Join(dataDir, "notification_channels.json"), func(c *domain.NotificationChannel) string { return c.ID }),
+	}
+}
+
+func (s *NotificationChannelStore) List(ctx context.Context) ([]*domain.NotificationChannel, error) {
+	return s.items.list()
+}
+
+func (s *NotificationChannelStore) Get(ctx context.Context, id string) (*domain.NotificationChannel, error) {
+	return s.items.get(id)
+}
+
+func (s *NotificationChannelStore) Save(ctx context.Context, ch *domain.NotificationChannel) error {
+	return s.items.put(ch)
+}
+
+func (s *NotificationChannelStore) Delete(ctx context.Context, id string) error {
+	return s.items.delete(id)
+}
+
+var _ domain.NotificationChannelRepository = (*NotificationChannelStore)(nil)
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// sealedRegistry keeps the registry settings in clear and its username and
+// password sealed with the credential key, using the registry name as
+// additional data.
+type sealedRegistry struct {
+	domain.Registry
+	Nonce []byte `json:"nonce,omitempty"`
+	Auth  []byte `json:"auth,omitempty"`
+}
+
+type registryAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RegistryStore struct {
+	items *collection[sealedRegistry]
+	aead  cipher.AEAD
+}
+
+// NewRegistryStore takes the same key as NewCredentialStore.
+func NewRegistryStore(dataDir string, key []byte) (*RegistryStore, error) {
+	aead, err := newCredentialAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &RegistryStore{
+		items: newCollection(filepath.Join(dataDir, "registries.json"), func(r *sealedRegistry) string { return r.Name }),
+		aead:  aead,
+	}, nil
+}
+
+func (s *RegistryStore) List(ctx context.Context) ([]*domain.Registry, error) {
+	sealed, err := s.items.list()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*domain.Registry, 0, len(sealed))
+	for _, sr := range sealed {
+		r, err := s.open(sr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (s *RegistryStore) Get(ctx context.Context, name string) (*domain.Registry, error) {
+	sr, err := s.items.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(sr)
+}
+
+func (s *RegistryStore) Save(ctx context.Context, r *domain.Registry) error {
+	sr := &sealedRegistry{Registry: *r}
+	sr.Username, sr.Password = "", ""
+	if r.Username != "" || r.Password != "" {
+		plain, err := json.Marshal(registryAuth{Username: r.Username, Password: r.Password})
+		if err != nil {
+			return err
+		}
+		sr.Nonce = make([]byte, s.aead.NonceSize())
+		if _, err := rand.Read(sr.Nonce); err != nil {
+			return err
+		}
+		sr.Auth = s.aead.Seal(nil, sr.Nonce, plain, []byte(r.Name))
+	}
+	return s.items.put(sr)
+}
+
+func (s *RegistryStore) Delete(ctx context.Context, name string) error {
+	return s.items.delete(name)
+}
+
+// open unseals the credentials. Entries written before they were sealed
+// still carry them in clear and are returned as they are; the next save
+// seals them.
+func (s *RegistryStore) open(sr *sealedRegistry) (*domain.Registry, error) {
+	r := sr.Registry
+	if sr.Auth == nil {
+		return &r, nil
+	}
+	plain, err := s.aead.Open(nil, sr.Nonce, sr.Auth, []byte(sr.Name))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials of registry %s (wrong credential key?): %w", sr.Name, err)
+	}
+	var auth registryAuth
+	if err := json.Unmarshal(plain, &auth); err != nil {
+		return nil, err
+	}
+	r.Username, r.Password = auth.Username, auth.Password
+	return &r, nil
+}
+
+var _ domain.RegistryRepository = (*RegistryStore)(nil)
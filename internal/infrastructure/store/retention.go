@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxStoredRetentionRuns = 500
+
+type RetentionPolicyStore struct {
+	items *collection[domain.RetentionPolicy]
+}
+
+func NewRetentionPolicyStore(dataDir string) *RetentionPolicyStore {
+	return &RetentionPolicyStore{
+		items: newCollection(filepath.Join(dataDir, "retention_policies.json"), func(p *domain.RetentionPolicy) string { return p.ID }),
+	}
+}
+
+func (s *RetentionPolicyStore) List(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	return s.items.list()
+}
+
+func (s *RetentionPolicyStore) Get(ctx context.Context, id string) (*domain.RetentionPolicy, error) {
+	return s.items.get(id)
+}
+
+func (s *RetentionPolicyStore) Save(ctx context.Context, p *domain.RetentionPolicy) error {
+	return s.items.put(p)
+}
+
+func (s *RetentionPolicyStore) Delete(ctx context.Context, id string) error {
+	return s.items.delete(id)
+}
+
+type RetentionRunStore struct {
+	log *cappedLog[domain.RetentionRun]
+}
+
+func NewRetentionRunStore(dataDir string) *RetentionRunStore {
+	return &RetentionRunStore{log: newCappedLog[domain.RetentionRun](filepath.Join(dataDir, "retention_runs.json"), maxStoredRetentionRuns)}
+}
+
+func (s *RetentionRunStore) Append(ctx context.Context, r *domain.RetentionRun) error {
+	return s.log.append(r)
+}
+
+// List returns up to limit runs, newest first.
+func (s *RetentionRunStore) List(ctx context.Context, limit int) ([]*domain.RetentionRun, error) {
+	return s.log.newest(limit)
+}
+
+var (
+	_ domain.RetentionPolicyRepository = (*RetentionPolicyStore)(nil)
+	_ domain.RetentionRunRepository    = (*RetentionRunStore)(nil)
+)
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SilenceStore struct {
+	items *collection[domain.Silence]
+}
+
+func NewSilenceStore(dataDir string) *SilenceStore {
+	return &SilenceStore{
+		items: newCollection(filepath.Join(dataDir, "silences.json"), func(s *domain.Silence) string { return s.ID }),
+	}
+}
+
+func (s *SilenceStore) List(ctx context.Context) ([]*domain.Silence, error) {
+	return s.items.list()
+}
+
+func (s *SilenceStore) Save(ctx context.Context, sil *domain.Silence) error {
+	return s.items.put(sil)
+}
+
+func (s *SilenceStore) Delete(ctx context.Context, id string) error {
+	return s.items.delete(id)
+}
+
+var _ domain.SilenceRepository = (*SilenceStore)(nil)
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// VolumeBackupDir keeps volume backups as archive files in one directory;
+// the file name is the backup ID. Archives being written carry a ".tmp-"
+// prefix until complete.
+type VolumeBackupDir struct {
+	dir string
+}
+
+func NewVolumeBackupDir(dir string) *VolumeBackupDir {
+	return &VolumeBackupDir{dir: dir}
+}
+
+func (d *VolumeBackupDir) List(ctx context.Context) ([]*domain.VolumeBackup, error) {
+	entries, err := os.ReadDir(d.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []*domain.VolumeBackup{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := []*domain.VolumeBackup{}
+	for _, e := range entries {
+		if !e.Type().IsRegular() || strings.HasPrefix(e.Name(), ".tmp-") {
+			continue
+		}
+		b, err := domain.ParseVolumeBackupID(e.Name())
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		b.Size = info.Size()
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (d *VolumeBackupDir) Get(ctx context.Context, id string) (*domain.VolumeBackup, error) {
+	b, err := domain.ParseVolumeBackupID(id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(filepath.Join(d.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("volume backup %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.Size = info.Size()
+	return b, nil
+}
+
+func (d *VolumeBackupDir) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	if _, err := domain.ParseVolumeBackupID(id); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(d.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("volume backup %s: %w", id, domain.ErrNotFound)
+	}
+	return f, err
+}
+
+func (d *VolumeBackupDir) Save(ctx context.Context, b *domain.VolumeBackup, archive io.Reader) error {
+	if err := os.MkdirAll(d.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.dir, ".tmp-"+b.ID+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, archive)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	// IDs have one-second resolution; link instead of rename so a second
+	// backup of the same volume within that second fails rather than
+	// replacing the first.
+	if err := os.Link(tmp.Name(), filepath.Join(d.dir, b.ID)); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("volume backup %s already exists: %w", b.ID, domain.ErrConflict)
+		}
+		return err
+	}
+	b.Size = n
+	return nil
+}
+
+func (d *VolumeBackupDir) Delete(ctx context.Context, id string) error {
+	if _, err := domain.ParseVolumeBackupID(id); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(d.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("volume backup %s: %w", id, domain.ErrNotFound)
+	}
+	return err
+}
+
+type VolumeBackupScheduleStore struct {
+	items *collection[domain.VolumeBackupSchedule]
+}
+
+func NewVolumeBackupScheduleStore(dataDir string) *VolumeBackupScheduleStore {
+	return &VolumeBackupScheduleStore{
+		items: newCollection(filepath.Join(dataDir, "volume_backup_schedules.json"), func(s *domain.VolumeBackupSchedule) string { return s.ID }),
+	}
+}
+
+func (s *VolumeBackupScheduleStore) List(ctx context.Context) ([]*domain.VolumeBackupSchedule, error) {
+	return s.items.list()
+}
+
+func (s *VolumeBackupScheduleStore) Get(ctx context.Context, id string) (*domain.VolumeBackupSchedule, error) {
+	return s.items.get(id)
+}
+
+func (s *VolumeBackupScheduleStore) Save(ctx context.Context, sched *domain.VolumeBackupSchedule) error {
+	return s.items.put(sched)
+}
+
+func (s *VolumeBackupScheduleStore) Delete(ctx context.Context, id string) error {
+	return s.items.delete(id)
+}
+
+var (
+	_ domain.VolumeBackupRepository         = (*VolumeBackupDir)(nil)
+	_ domain.VolumeBackupScheduleRepository = (*VolumeBackupScheduleStore)(nil)
+)
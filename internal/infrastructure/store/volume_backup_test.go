@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestVolumeBackupDir_SaveDoesNotOverwrite(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	dir := NewVolumeBackupDir(root)
+	now := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+	id := domain.VolumeBackupID("app-data", now, domain.VolumeBackupTriggerManual)
+
+	if err := dir.Save(ctx, &domain.VolumeBackup{ID: id}, strings.NewReader("first")); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	err := dir.Save(ctx, &domain.VolumeBackup{ID: id}, strings.NewReader("second"))
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+
+	rc, err := dir.Open(ctx, id)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	if string(data) != "first" {
+		t.Errorf("backup overwritten: %q", data)
+	}
+	if entries, err := os.ReadDir(root); err != nil || len(entries) != 1 {
+		t.Errorf("expected only the first backup in the directory, got %v (%v)", entries, err)
+	}
+}
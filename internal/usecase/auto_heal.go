@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// AutoHealPolicy selects the containers the controller may restart and how
+// aggressively. Containers opt in with Label=true unless All is set.
+type AutoHealPolicy struct {
+	All         bool
+	Label       string
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	MaxAttempts int
+	Window      time.Duration
+}
+
+var DefaultAutoHealPolicy = AutoHealPolicy{
+	Label:       "dockscope.autoheal",
+	BaseDelay:   10 * time.Second,
+	MaxDelay:    5 * time.Minute,
+	MaxAttempts: 5,
+	Window:      time.Hour,
+}
+
+func (p AutoHealPolicy) enabledFor(labels map[string]string) bool {
+	if p.All {
+		return labels[p.Label] != "false"
+	}
+	return labels[p.Label] == "true"
+}
+
+// backoff is the wait before the attempt that follows n earlier attempts.
+func (p AutoHealPolicy) backoff(n int) time.Duration {
+	if n == 0 {
+		return 0
+	}
+	d := p.BaseDelay
+	for i := 1; i < n && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxDelay)
+}
+
+type healState struct {
+	attempts    []time.Time
+	cancel      func() bool
+	circuitOpen bool
+}
+
+// AutoHeal restarts opted-in containers that turn unhealthy, with
+// exponential backoff between attempts and a per-window attempt limit. A
+// failed restart is retried with the same backoff while the container stays
+// unhealthy. Once the limit is reached the circuit opens; when the oldest
+// attempt leaves the window, one more restart is tried if the container is
+// still unhealthy.
+type AutoHeal struct {
+	events     domain.ContainerEventSource
+	containers domain.ContainerRepository
+	inspector  domain.ContainerInspector
+	ctrl       domain.ContainerController
+	audit      domain.AutoHealAuditRepository
+	fire       *FireAlert
+	policy     AutoHealPolicy
+	log        *slog.Logger
+
+	now      func() time.Time
+	schedule func(d time.Duration, f func()) (cancel func() bool)
+
+	mu    sync.Mutex
+	state map[string]*healState
+}
+
+func NewAutoHeal(
+	events domain.ContainerEventSource,
+	containers domain.ContainerRepository,
+	inspector domain.ContainerInspector,
+	ctrl domain.ContainerController,
+	audit domain.AutoHealAuditRepository,
+	fire *FireAlert,
+	policy AutoHealPolicy,
+	log *slog.Logger,
+) *AutoHeal {
+	return &AutoHeal{
+		events:     events,
+		containers: containers,
+		inspector:  inspector,
+		ctrl:       ctrl,
+		audit:      audit,
+		fire:       fire,
+		policy:     policy,
+		log:        log,
+		now:        time.Now,
+		schedule: func(d time.Duration, f func()) func() bool {
+			return time.AfterFunc(d, f).Stop
+		},
+		state: make(map[string]*healState),
+	}
+}
+
+func (uc *AutoHeal) Execute(ctx context.Context) error {
+	uc.log.InfoContext(ctx, "auto-heal enabled", "all", uc.policy.All, "label", uc.policy.Label, "max_attempts", uc.policy.MaxAttempts, "window", uc.policy.Window)
+	uc.healExisting(ctx)
+	err := consumeContainerEvents(ctx, uc.events, uc.log, func(ev domain.ContainerEvent) {
+		uc.handle(ctx, ev)
+	})
+	uc.mu.Lock()
+	for _, st := range uc.state {
+		if st.cancel != nil {
+			st.cancel()
+		}
+	}
+	uc.mu.Unlock()
+	return err
+}
+
+// healExisting handles the containers that were already unhealthy before
+// the event stream started, since no health_status event will report them.
+func (uc *AutoHeal) healExisting(ctx context.Context) {
+	containers, err := uc.containers.ListActive(ctx, false)
+	if err != nil {
+		uc.log.WarnContext(ctx, "auto-heal: listing containers failed, only new health events are handled", "error", err)
+		return
+	}
+	for _, c := range containers {
+		if c.Health != domain.HealthUnhealthy {
+			continue
+		}
+		uc.handle(ctx, domain.ContainerEvent{
+			ContainerID:   c.ID,
+			ContainerName: containerDisplayName(c),
+			Image:         c.Image,
+			Action:        domain.EventHealthStatus,
+			Health:        domain.HealthUnhealthy,
+			Labels:        c.Labels,
+			Time:          uc.now(),
+		})
+	}
+}
+
+func (uc *AutoHeal) handle(ctx context.Context, ev domain.ContainerEvent) {
+	if ev.Action == domain.EventDestroy {
+		uc.forget(ev.ContainerID)
+		return
+	}
+	if ev.Action != domain.EventHealthStatus || !uc.policy.enabledFor(ev.Labels) {
+		return
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	st, ok := uc.state[ev.ContainerID]
+	if !ok {
+		st = &healState{}
+		uc.state[ev.ContainerID] = st
+	}
+
+	if ev.Health != domain.HealthUnhealthy {
+		if ev.Health == domain.HealthHealthy && st.cancel != nil {
+			st.cancel()
+			st.cancel = nil
+		}
+		return
+	}
+	if st.cancel != nil {
+		return
+	}
+	uc.scheduleLocked(ctx, ev, st)
+}
+
+// scheduleLocked schedules the next restart of an unhealthy container, or
+// opens the circuit once the window's attempts are used up. An open circuit
+// is probed when its oldest attempt leaves the window, since a container
+// that stays unhealthy sends no further health events. It must be called
+// with uc.mu held.
+func (uc *AutoHeal) scheduleLocked(ctx context.Context, ev domain.ContainerEvent, st *healState) {
+	now := uc.now()
+	uc.pruneLocked(st, now)
+
+	if len(st.attempts) >= uc.policy.MaxAttempts {
+		if !st.circuitOpen {
+			st.circuitOpen = true
+			uc.giveUp(ctx, ev, len(st.attempts))
+		}
+		retry := st.attempts[0].Add(uc.policy.Window).Sub(now)
+		uc.log.InfoContext(ctx, "auto-heal retry scheduled after circuit open", "container_id", ev.ContainerID, "delay", retry)
+		st.cancel = uc.schedule(retry, func() { uc.heal(ctx, ev) })
+		return
+	}
+	st.circuitOpen = false
+
+	delay := uc.policy.backoff(len(st.attempts))
+	uc.log.InfoContext(ctx, "auto-heal restart scheduled", "container_id", ev.ContainerID, "delay", delay)
+	st.cancel = uc.schedule(delay, func() { uc.heal(ctx, ev) })
+}
+
+func (uc *AutoHeal) heal(ctx context.Context, ev domain.ContainerEvent) {
+	if ctx.Err() != nil {
+		return
+	}
+	uc.mu.Lock()
+	st, ok := uc.state[ev.ContainerID]
+	if ok {
+		st.cancel = nil
+	}
+	uc.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	// The container may have recovered or gone away while we waited.
+	details, err := uc.inspector.Inspect(ctx, ev.ContainerID)
+	if err != nil {
+		uc.log.DebugContext(ctx, "auto-heal: inspect failed, skipping", "container_id", ev.ContainerID, "error", err)
+		return
+	}
+	if !details.State.Running || details.State.Health == nil || details.State.Health.Status != domain.HealthUnhealthy {
+		return
+	}
+
+	uc.mu.Lock()
+	uc.pruneLocked(st, uc.now())
+	st.attempts = append(st.attempts, uc.now())
+	attempt := len(st.attempts)
+	uc.mu.Unlock()
+
+	entry := &domain.AutoHealAction{
+		ID:            newID(),
+		ContainerID:   ev.ContainerID,
+		ContainerName: ev.ContainerName,
+		Action:        domain.ActionRestart,
+		Result:        domain.AutoHealRestarted,
+		Attempt:       attempt,
+		Time:          uc.now().UTC(),
+	}
+	err = uc.ctrl.ExecuteAction(ctx, ev.ContainerID, domain.ActionRestart)
+	if err != nil {
+		entry.Result = domain.AutoHealFailed
+		entry.Error = err.Error()
+		uc.log.WarnContext(ctx, "auto-heal restart failed", "container_id", ev.ContainerID, "attempt", attempt, "error", err)
+	} else {
+		uc.log.InfoContext(ctx, "auto-heal restarted container", "container_id", ev.ContainerID, "attempt", attempt)
+	}
+	uc.record(ctx, entry)
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+
+	// No health event follows a failed restart, so retry from here; heal
+	// checks again that the container is still unhealthy.
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.state[ev.ContainerID] == st && st.cancel == nil {
+		uc.scheduleLocked(ctx, ev, st)
+	}
+}
+
+// pruneLocked drops the attempts that fell out of the window. It must be
+// called with uc.mu held.
+func (uc *AutoHeal) pruneLocked(st *healState, now time.Time) {
+	cutoff := now.Add(-uc.policy.Window)
+	kept := st.attempts[:0]
+	for _, t := range st.attempts {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	st.attempts = kept
+}
+
+// forget drops the state of a removed container.
+func (uc *AutoHeal) forget(containerID string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if st, ok := uc.state[containerID]; ok {
+		if st.cancel != nil {
+			st.cancel()
+		}
+		delete(uc.state, containerID)
+	}
+}
+
+func (uc *AutoHeal) giveUp(ctx context.Context, ev domain.ContainerEvent, attempts int) {
+	uc.log.WarnContext(ctx, "auto-heal circuit open", "container_id", ev.ContainerID, "attempts", attempts)
+	uc.record(ctx, &domain.AutoHealAction{
+		ID:            newID(),
+		ContainerID:   ev.ContainerID,
+		ContainerName: ev.ContainerName,
+		Action:        domain.ActionRestart,
+		Result:        domain.AutoHealCircuitOpen,
+		Attempt:       attempts,
+		Time:          uc.now().UTC(),
+	})
+	err := uc.fire.Execute(ctx, &domain.Alert{
+		Rule:          domain.RuleAutoHealGaveUp,
+		Severity:      domain.SeverityCritical,
+		Title:         fmt.Sprintf("Auto-heal gave up on %s", ev.ContainerName),
+		Message:       fmt.Sprintf("%s is still unhealthy after %d restarts in %s", ev.ContainerName, attempts, uc.policy.Window),
+		ContainerID:   ev.ContainerID,
+		ContainerName: ev.ContainerName,
+		Labels:        ev.Labels,
+	})
+	if err != nil {
+		uc.log.WarnContext(ctx, "auto-heal alert failed", "container_id", ev.ContainerID, "error", err)
+	}
+}
+
+func (uc *AutoHeal) record(ctx context.Context, a *domain.AutoHealAction) {
+	if err := uc.audit.Append(ctx, a); err != nil {
+		uc.log.ErrorContext(ctx, "auto-heal audit write failed", "container_id", a.ContainerID, "error", err)
+	}
+}
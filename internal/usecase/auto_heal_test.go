@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockAuditRepo struct {
+	actions []*domain.AutoHealAction
+}
+
+func (m *mockAuditRepo) Append(ctx context.Context, a *domain.AutoHealAction) error {
+	m.actions = append(m.actions, a)
+	return nil
+}
+
+func (m *mockAuditRepo) List(ctx context.Context, limit int) ([]*domain.AutoHealAction, error) {
+	return m.actions, nil
+}
+
+func TestAutoHeal_BackoffAndCircuitBreaker(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockInspector{details: map[string]*domain.ContainerDetails{
+		"c1": {ID: "c1", State: domain.ContainerState{Running: true, Health: &domain.ContainerHealth{Status: domain.HealthUnhealthy}}},
+	}}
+	ctrl := &mockController{}
+	audit := &mockAuditRepo{}
+	alerts := &mockAlertRepo{}
+	fire := NewFireAlert(alerts, &mockSilenceRepo{}, &mockWindowRepo{}, NewDispatchNotification(newMockChannelRepo(), &mockNotifier{}, log), log)
+	policy := AutoHealPolicy{Label: "autoheal", BaseDelay: time.Second, MaxDelay: 3 * time.Second, MaxAttempts: 3, Window: time.Hour}
+	uc := NewAutoHeal(nil, &mockContainerRepo{}, inspector, ctrl, audit, fire, policy, log)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	var delays []time.Duration
+	var pending func()
+	uc.schedule = func(d time.Duration, f func()) func() bool {
+		delays = append(delays, d)
+		pending = f
+		return func() bool { pending = nil; return true }
+	}
+	ctx := context.Background()
+	unhealthy := domain.ContainerEvent{ContainerID: "c1", ContainerName: "web", Action: domain.EventHealthStatus, Health: domain.HealthUnhealthy, Labels: map[string]string{"autoheal": "true"}}
+
+	uc.handle(ctx, domain.ContainerEvent{ContainerID: "c2", Action: domain.EventHealthStatus, Health: domain.HealthUnhealthy})
+	if len(delays) != 0 {
+		t.Fatalf("container without opt-in label was scheduled")
+	}
+
+	for i := 0; i < 3; i++ {
+		uc.handle(ctx, unhealthy)
+		if pending == nil {
+			t.Fatalf("attempt %d: nothing scheduled", i+1)
+		}
+		f := pending
+		pending = nil
+		f()
+		now = now.Add(time.Minute)
+	}
+	want := []time.Duration{0, time.Second, 2 * time.Second}
+	for i, d := range want {
+		if delays[i] != d {
+			t.Errorf("delay %d: got %s, want %s", i, delays[i], d)
+		}
+	}
+	if ctrl.lastID != "c1" || ctrl.lastAction != domain.ActionRestart {
+		t.Errorf("controller called with %q %q", ctrl.lastID, ctrl.lastAction)
+	}
+
+	uc.handle(ctx, unhealthy)
+	uc.handle(ctx, unhealthy)
+	if len(audit.actions) != 4 || audit.actions[3].Result != domain.AutoHealCircuitOpen {
+		t.Fatalf("audit trail: %+v", audit.actions)
+	}
+	if len(alerts.alerts) != 1 || alerts.alerts[0].Rule != domain.RuleAutoHealGaveUp {
+		t.Errorf("expected one give-up alert, got %+v", alerts.alerts)
+	}
+	// The first attempt was 3m ago and leaves the window in 57m.
+	if pending == nil || len(delays) != 4 || delays[3] != 57*time.Minute {
+		t.Fatalf("expected a single retry when the oldest attempt expires, got %v", delays)
+	}
+
+	// No health event arrives while the container stays unhealthy; the
+	// retry restarts it once the window allows.
+	now = now.Add(57 * time.Minute)
+	f := pending
+	pending = nil
+	f()
+	if len(ctrl.actions) != 4 {
+		t.Fatalf("expected a restart after the window passed, got %v", ctrl.actions)
+	}
+	if last := audit.actions[len(audit.actions)-1]; last.Result != domain.AutoHealRestarted || last.Attempt != 3 {
+		t.Errorf("half-open restart audit: %+v", last)
+	}
+	if len(alerts.alerts) != 1 {
+		t.Errorf("circuit still open, no new give-up alert expected: %+v", alerts.alerts)
+	}
+}
+
+func TestAutoHeal_HealthyCancelsPendingRestart(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	uc := NewAutoHeal(nil, &mockContainerRepo{}, &mockInspector{}, &mockController{}, &mockAuditRepo{}, nil, AutoHealPolicy{All: true, MaxAttempts: 1, Window: time.Hour}, log)
+	cancelled := false
+	uc.schedule = func(d time.Duration, f func()) func() bool {
+		return func() bool { cancelled = true; return true }
+	}
+	ctx := context.Background()
+	uc.handle(ctx, domain.ContainerEvent{ContainerID: "c1", Action: domain.EventHealthStatus, Health: domain.HealthUnhealthy})
+	uc.handle(ctx, domain.ContainerEvent{ContainerID: "c1", Action: domain.EventHealthStatus, Health: domain.HealthHealthy})
+	if !cancelled {
+		t.Error("healthy transition should cancel the pending restart")
+	}
+}
+
+func TestAutoHeal_ExistingUnhealthyRetryAndDestroy(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "c1", Names: []string{"/web"}, Health: domain.HealthUnhealthy, Labels: map[string]string{"autoheal": "true"}},
+		{ID: "c2", Names: []string{"/db"}, Health: domain.HealthUnhealthy},
+		{ID: "c3", Names: []string{"/api"}, Health: domain.HealthHealthy, Labels: map[string]string{"autoheal": "true"}},
+	}}
+	inspector := &mockInspector{details: map[string]*domain.ContainerDetails{
+		"c1": {ID: "c1", State: domain.ContainerState{Running: true, Health: &domain.ContainerHealth{Status: domain.HealthUnhealthy}}},
+	}}
+	ctrl := &mockController{err: errors.New("daemon busy")}
+	audit := &mockAuditRepo{}
+	policy := AutoHealPolicy{Label: "autoheal", BaseDelay: time.Second, MaxDelay: time.Minute, MaxAttempts: 5, Window: time.Hour}
+	uc := NewAutoHeal(nil, containers, inspector, ctrl, audit, nil, policy, log)
+	var delays []time.Duration
+	var pending func()
+	uc.schedule = func(d time.Duration, f func()) func() bool {
+		delays = append(delays, d)
+		pending = f
+		return func() bool { pending = nil; return true }
+	}
+	ctx := context.Background()
+
+	uc.healExisting(ctx)
+	if len(delays) != 1 || pending == nil {
+		t.Fatalf("expected one restart scheduled for the opted-in unhealthy container, got %v", delays)
+	}
+
+	// Failed restarts are retried with backoff without a new health event.
+	for i := 0; i < 2; i++ {
+		f := pending
+		pending = nil
+		f()
+	}
+	if len(ctrl.actions) != 2 || ctrl.actions[0] != "c1:restart" {
+		t.Fatalf("restarts: %v", ctrl.actions)
+	}
+	if want := []time.Duration{0, time.Second, 2 * time.Second}; len(delays) != 3 || delays[1] != want[1] || delays[2] != want[2] {
+		t.Errorf("delays = %v, want %v", delays, want)
+	}
+	if len(audit.actions) != 2 || audit.actions[0].Result != domain.AutoHealFailed {
+		t.Errorf("audit trail: %+v", audit.actions)
+	}
+
+	uc.handle(ctx, domain.ContainerEvent{ContainerID: "c1", Action: domain.EventDestroy})
+	if pending != nil {
+		t.Error("destroy should cancel the pending restart")
+	}
+	if len(uc.state) != 0 {
+		t.Errorf("state not pruned on destroy: %v", uc.state)
+	}
+}
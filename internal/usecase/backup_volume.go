@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type BackupVolumeInput struct {
+	Volume string
+	// StopContainers stops the running containers that mount the volume
+	// until it is archived, for a consistent copy.
+	StopContainers bool
+	Trigger        string
+}
+
+// BackupVolume archives a volume into the backup directory as a
+// gzip-compressed tar with paths relative to the volume root.
+type BackupVolume struct {
+	files      domain.VolumeFileAccess
+	backups    domain.VolumeBackupRepository
+	containers domain.ContainerRepository
+	controller domain.ContainerController
+	log        *slog.Logger
+	now        func() time.Time
+}
+
+func NewBackupVolume(files domain.VolumeFileAccess, backups domain.VolumeBackupRepository, containers domain.ContainerRepository, controller domain.ContainerController, log *slog.Logger) *BackupVolume {
+	return &BackupVolume{files: files, backups: backups, containers: containers, controller: controller, log: log, now: time.Now}
+}
+
+func (uc *BackupVolume) Execute(ctx context.Context, input BackupVolumeInput) (*domain.VolumeBackup, error) {
+	b, rc, err := archiveVolume(ctx, uc.files, uc.containers, uc.controller, uc.now(), input, uc.log)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	if err := uc.backups.Save(ctx, b, rc); err != nil {
+		uc.log.ErrorContext(ctx, "volume backup failed", "volume", b.Volume, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "volume backed up", "volume", b.Volume, "backup_id", b.ID, "size", b.Size, "trigger", b.Trigger)
+	return b, nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type memVolumeBackups struct {
+	data map[string][]byte
+}
+
+func (m *memVolumeBackups) List(ctx context.Context) ([]*domain.VolumeBackup, error) {
+	var out []*domain.VolumeBackup
+	for id := range m.data {
+		b, _ := m.Get(ctx, id)
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func (m *memVolumeBackups) Get(ctx context.Context, id string) (*domain.VolumeBackup, error) {
+	data, ok := m.data[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	b, err := domain.ParseVolumeBackupID(id)
+	if err != nil {
+		return nil, err
+	}
+	b.Size = int64(len(data))
+	return b, nil
+}
+
+func (m *memVolumeBackups) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	data, ok := m.data[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memVolumeBackups) Save(ctx context.Context, b *domain.VolumeBackup, archive io.Reader) error {
+	data, err := io.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+	m.data[b.ID] = data
+	b.Size = int64(len(data))
+	return nil
+}
+
+func (m *memVolumeBackups) Delete(ctx context.Context, id string) error {
+	delete(m.data, id)
+	return nil
+}
+
+func TestBackupVolume_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	backups := &memVolumeBackups{data: map[string][]byte{}}
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "db", State: "running", Mounts: []domain.Mount{{Type: "volume", Name: "app-data", Target: "/var/lib/db"}}},
+		{ID: "web", State: "running", Mounts: []domain.Mount{{Type: "volume", Name: "web-data"}}},
+	}}
+	ctrl := &mockController{}
+	uc := NewBackupVolume(files, backups, containers, ctrl, log)
+	uc.now = func() time.Time { return time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC) }
+
+	b, err := uc.Execute(context.Background(), BackupVolumeInput{Volume: "app-data", StopContainers: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.ID != "app-data_20261018T020000Z_manual.tar.gz" || b.Size == 0 {
+		t.Errorf("backup = %+v", b)
+	}
+	if want := []string{"db:stop", "db:start"}; !reflect.DeepEqual(ctrl.actions, want) {
+		t.Errorf("actions = %v, want %v", ctrl.actions, want)
+	}
+	if files.open != 0 {
+		t.Errorf("%d archive streams left open", files.open)
+	}
+
+	// Paths are relative to the volume root.
+	gz, err := gzip.NewReader(bytes.NewReader(backups.data[b.ID]))
+	if err != nil {
+		t.Fatalf("invalid gzip: %v", err)
+	}
+	var names []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid tar: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	if want := []string{"data/", "data/a.txt", "data/link", "data/sub/", "data/sub/b.txt"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("archive = %v, want %v", names, want)
+	}
+}
+
+func TestRestoreVolume_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	id := domain.VolumeBackupID("app-data", time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC), domain.VolumeBackupTriggerSchedule)
+	backups := &memVolumeBackups{data: map[string][]byte{id: []byte("archive")}}
+	volumes := &mockVolumeManager{}
+	uc := NewRestoreVolume(files, backups, volumes, &mockContainerRepo{}, &mockController{}, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, RestoreVolumeInput{Volume: "app-data-copy", BackupID: id})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Created || volumes.created == nil || volumes.created.Name != "app-data-copy" || files.written != "/" {
+		t.Errorf("out = %+v, created = %+v, written = %q", out, volumes.created, files.written)
+	}
+
+	// Into the backup's own, existing volume.
+	volumes.created = nil
+	out, err = uc.Execute(ctx, RestoreVolumeInput{BackupID: id})
+	if err != nil || out.Volume != "app-data" || out.Created || volumes.created != nil {
+		t.Errorf("out = %+v, err = %v", out, err)
+	}
+
+	if _, err := uc.Execute(ctx, RestoreVolumeInput{Volume: "app-data"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput without a backup, got %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type BrowseVolumeFilesInput struct {
+	Volume string
+	Path   string
+}
+
+// maxBrowseArchiveBytes caps how much of a directory's archive a listing
+// reads.
+const maxBrowseArchiveBytes = 64 << 20
+
+type BrowseVolumeFilesOutput struct {
+	Volume string `json:"volume"`
+	// Entry is the path itself; Entries are its children when it is a
+	// directory.
+	Entry   *domain.VolumeFileEntry  `json:"entry"`
+	Entries []domain.VolumeFileEntry `json:"entries"`
+	// Partial is set when the listing stopped at maxBrowseArchiveBytes:
+	// Entries may be missing children and directory sizes are lower bounds.
+	Partial bool `json:"partial,omitempty"`
+}
+
+// BrowseVolumeFiles lists a directory of a volume. The archive API has no
+// listing, so the directory is read as a tar stream, which also gives each
+// subdirectory its total size; the stream holds the whole subtree, so only
+// its first maxBrowseArchiveBytes are read.
+type BrowseVolumeFiles struct {
+	files    domain.VolumeFileAccess
+	maxBytes int64
+	log      *slog.Logger
+}
+
+func NewBrowseVolumeFiles(files domain.VolumeFileAccess, log *slog.Logger) *BrowseVolumeFiles {
+	return &BrowseVolumeFiles{files: files, maxBytes: maxBrowseArchiveBytes, log: log}
+}
+
+func (uc *BrowseVolumeFiles) Execute(ctx context.Context, input BrowseVolumeFilesInput) (*BrowseVolumeFilesOutput, error) {
+	if input.Volume == "" {
+		return nil, invalidInput("missing volume name")
+	}
+	p := cleanVolumePath(input.Path)
+	// One helper gives both the entry and, for a directory, its contents.
+	rc, entry, err := uc.files.Read(ctx, input.Volume, p)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	out := &BrowseVolumeFilesOutput{Volume: input.Volume, Entry: entry, Entries: []domain.VolumeFileEntry{}}
+	if entry.Type != domain.FileTypeDir {
+		return out, nil
+	}
+
+	children := make(map[string]*domain.VolumeFileEntry)
+	sizes := make(map[string]int64)
+	var total int64
+	limited := &io.LimitedReader{R: rc, N: uc.maxBytes}
+	tr := tar.NewReader(limited)
+	for {
+		hdr, err := tr.Next()
+		if err != nil && limited.N <= 0 {
+			out.Partial = true
+			uc.log.DebugContext(ctx, "volume listing truncated", "volume", input.Volume, "path", p, "max_bytes", uc.maxBytes)
+			break
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			uc.log.ErrorContext(ctx, "volume listing failed", "volume", input.Volume, "path", p, "error", err)
+			return nil, err
+		}
+		rel := archiveRelName(hdr.Name)
+		if rel == "" {
+			continue
+		}
+		if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			total += hdr.Size
+		}
+		top, rest, _ := strings.Cut(rel, "/")
+		if rest == "" {
+			e := volumeFileEntry(hdr, path.Join(p, top))
+			children[top] = &e
+		} else if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			sizes[top] += hdr.Size
+		}
+	}
+	for name, e := range children {
+		if e.Type == domain.FileTypeDir {
+			e.Size = sizes[name]
+		}
+		out.Entries = append(out.Entries, *e)
+	}
+	sort.Slice(out.Entries, func(i, j int) bool { return out.Entries[i].Name < out.Entries[j].Name })
+	entry.Size = total
+	return out, nil
+}
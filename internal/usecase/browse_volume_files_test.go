@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// mockVolumeFiles serves one volume holding /data/a.txt (3 bytes),
+// /data/sub/b.txt (5 bytes) and the symlink /data/link -> a.txt.
+type mockVolumeFiles struct {
+	open    int
+	written string
+	// helpers counts the helper containers Stat and Read would start.
+	helpers int
+}
+
+var mockVolumeTree = []*tar.Header{
+	{Name: "data/", Typeflag: tar.TypeDir, Mode: 0o755},
+	{Name: "data/a.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 3},
+	{Name: "data/link", Typeflag: tar.TypeSymlink, Linkname: "a.txt"},
+	{Name: "data/sub/", Typeflag: tar.TypeDir, Mode: 0o755},
+	{Name: "data/sub/b.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+}
+
+func (m *mockVolumeFiles) Stat(ctx context.Context, volume, p string) (*domain.VolumeFileEntry, error) {
+	m.helpers++
+	return m.stat(volume, p)
+}
+
+func (m *mockVolumeFiles) stat(volume, p string) (*domain.VolumeFileEntry, error) {
+	if volume != "app-data" {
+		return nil, domain.ErrNotFound
+	}
+	for _, hdr := range mockVolumeTree {
+		if "/"+strings.TrimSuffix(hdr.Name, "/") == p {
+			e := volumeFileEntry(hdr, p)
+			return &e, nil
+		}
+	}
+	if p == "/" {
+		return &domain.VolumeFileEntry{Name: "/", Path: "/", Type: domain.FileTypeDir}, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+// Read archives p the way the daemon does: entries are named after the base
+// name of p.
+func (m *mockVolumeFiles) Read(ctx context.Context, volume, p string) (io.ReadCloser, *domain.VolumeFileEntry, error) {
+	m.helpers++
+	// Every volume holds the same tree here, so clones can read their copy.
+	entry, err := m.stat("app-data", p)
+	if err != nil {
+		return nil, nil, err
+	}
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	prefix := strings.TrimPrefix(p, "/")
+	if prefix == "" {
+		tw.WriteHeader(&tar.Header{Name: "volume/", Typeflag: tar.TypeDir, Mode: 0o755})
+	}
+	for _, hdr := range mockVolumeTree {
+		name := strings.TrimSuffix(hdr.Name, "/")
+		if prefix != "" && name != prefix && !strings.HasPrefix(name, prefix+"/") {
+			continue
+		}
+		h := *hdr
+		h.Name = path.Join(path.Base("/volume/"+prefix), strings.TrimPrefix(strings.TrimPrefix(name, prefix), "/"))
+		tw.WriteHeader(&h)
+		tw.Write(bytes.Repeat([]byte("x"), int(h.Size)))
+	}
+	tw.Close()
+	m.open++
+	return &tarFileBody{Reader: &buf, Closer: closerFunc(func() error { m.open--; return nil })}, entry, nil
+}
+
+func (m *mockVolumeFiles) Write(ctx context.Context, volume, dir string, archive io.Reader) error {
+	m.written = dir
+	if archive != nil {
+		_, err := io.Copy(io.Discard, archive)
+		return err
+	}
+	return nil
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func TestBrowseVolumeFiles_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	uc := NewBrowseVolumeFiles(files, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, BrowseVolumeFilesInput{Volume: "app-data", Path: "data/../data/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Entry.Path != "/data" || out.Entry.Size != 8 {
+		t.Errorf("entry = %+v", out.Entry)
+	}
+	var got []string
+	for _, e := range out.Entries {
+		got = append(got, e.Name+":"+e.Type)
+	}
+	if strings.Join(got, ",") != "a.txt:file,link:symlink,sub:dir" {
+		t.Errorf("entries = %v", got)
+	}
+	if sub := out.Entries[2]; sub.Path != "/data/sub" || sub.Size != 5 {
+		t.Errorf("sub = %+v", sub)
+	}
+	if files.open != 0 {
+		t.Errorf("%d archive streams left open", files.open)
+	}
+	if files.helpers != 1 || out.Partial {
+		t.Errorf("listing used %d helpers, partial %v", files.helpers, out.Partial)
+	}
+
+	out, err = uc.Execute(ctx, BrowseVolumeFilesInput{Volume: "app-data", Path: "/data/a.txt"})
+	if err != nil || out.Entry.Type != domain.FileTypeFile || len(out.Entries) != 0 {
+		t.Errorf("file browse = %+v, %v", out, err)
+	}
+	if _, err := uc.Execute(ctx, BrowseVolumeFilesInput{Volume: "app-data", Path: "/missing"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestBrowseVolumeFiles_Partial(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	uc := NewBrowseVolumeFiles(files, log)
+	// Room for the headers of the first entries only.
+	uc.maxBytes = 3 * 512
+
+	out, err := uc.Execute(context.Background(), BrowseVolumeFilesInput{Volume: "app-data", Path: "/data"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !out.Partial || len(out.Entries) == 0 || len(out.Entries) == 3 {
+		t.Errorf("partial = %v, entries = %+v", out.Partial, out.Entries)
+	}
+	if files.open != 0 {
+		t.Errorf("%d archive streams left open", files.open)
+	}
+}
+
+func TestDownloadVolumeFiles_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	uc := NewDownloadVolumeFiles(files, log)
+	ctx := context.Background()
+
+	dl, err := uc.Execute(ctx, DownloadVolumeFilesInput{Volume: "app-data", Path: "/data/sub/b.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(dl.Body)
+	dl.Body.Close()
+	if dl.Format != VolumeDownloadRaw || dl.Filename != "b.txt" || string(body) != "xxxxx" {
+		t.Errorf("raw download = %s %s %q", dl.Format, dl.Filename, body)
+	}
+
+	if _, err := uc.Execute(ctx, DownloadVolumeFilesInput{Volume: "app-data", Path: "/data", Format: VolumeDownloadRaw}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a raw directory, got %v", err)
+	}
+
+	// The volume root is named after the volume.
+	dl, err = uc.Execute(ctx, DownloadVolumeFilesInput{Volume: "app-data", Path: "/", Format: VolumeDownloadZip})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err = io.ReadAll(dl.Body)
+	dl.Body.Close()
+	if err != nil {
+		t.Fatalf("read zip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	want := "app-data/,app-data/data/,app-data/data/a.txt,app-data/data/link,app-data/data/sub/,app-data/data/sub/b.txt"
+	if dl.Filename != "app-data.zip" || strings.Join(names, ",") != want {
+		t.Errorf("zip %s = %v", dl.Filename, names)
+	}
+	if files.open != 0 {
+		t.Errorf("%d archive streams left open", files.open)
+	}
+}
+
+func TestUploadVolumeFiles_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	files := &mockVolumeFiles{}
+	uc := NewUploadVolumeFiles(files, log)
+	ctx := context.Background()
+
+	if err := uc.Execute(ctx, UploadVolumeFilesInput{Volume: "app-data", Dir: "/data/a.txt"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a file, got %v", err)
+	}
+	if err := uc.Execute(ctx, UploadVolumeFilesInput{Volume: "app-data", Dir: "../../data/sub"}); err != nil || files.written != "/data/sub" {
+		t.Errorf("upload into %q: %v", files.written, err)
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type BuildImageInput struct {
+	// Context is a tar (optionally compressed) build context. It is closed
+	// once the build ends.
+	Context io.ReadCloser
+	Options domain.BuildOptions
+}
+
+// BuildImage runs builds in the background so a long build does not hold an
+// HTTP request open; the jobs are tracked in BuildJobs.
+type BuildImage struct {
+	builder domain.ImageBuilder
+	creds   domain.RegistryCredentialRepository
+	jobs    *BuildJobs
+	log     *slog.Logger
+	now     func() time.Time
+}
+
+// NewBuildImage accepts a nil creds repository; base images are then pulled
+// anonymously.
+func NewBuildImage(builder domain.ImageBuilder, creds domain.RegistryCredentialRepository, jobs *BuildJobs, log *slog.Logger) *BuildImage {
+	return &BuildImage{builder: builder, creds: creds, jobs: jobs, log: log, now: time.Now}
+}
+
+// Execute validates the options and starts the build, returning the new job.
+func (uc *BuildImage) Execute(ctx context.Context, input BuildImageInput) (*domain.BuildJob, error) {
+	if input.Context == nil {
+		return nil, invalidInput("missing build context")
+	}
+	if err := validateBuildOptions(&input.Options); err != nil {
+		input.Context.Close()
+		return nil, err
+	}
+	if uc.creds != nil {
+		stored, err := uc.creds.List(ctx)
+		if err != nil {
+			uc.log.WarnContext(ctx, "registry credential lookup failed, building without credentials", "error", err)
+		}
+		for _, c := range stored {
+			input.Options.Credentials = append(input.Options.Credentials, c.RegistryCredential)
+		}
+	}
+
+	buildCtx, cancel := context.WithCancel(context.Background())
+	j := &buildJob{
+		job: domain.BuildJob{
+			ID:        newID(),
+			Status:    domain.BuildRunning,
+			Options:   input.Options,
+			StartedAt: uc.now(),
+		},
+		cancel:  cancel,
+		changed: make(chan struct{}),
+	}
+	out := uc.jobs.add(j)
+
+	uc.log.InfoContext(ctx, "image build started", "job_id", j.job.ID, "tags", input.Options.Tags)
+	go uc.run(buildCtx, j, input)
+	return out, nil
+}
+
+func (uc *BuildImage) run(ctx context.Context, j *buildJob, input BuildImageInput) {
+	defer input.Context.Close()
+	defer j.cancel()
+
+	res, err := uc.builder.Build(ctx, input.Context, input.Options, func(line string) {
+		uc.jobs.appendOutput(j, line)
+	})
+
+	uc.jobs.finish(j, func(job *domain.BuildJob) {
+		job.FinishedAt = uc.now()
+		switch {
+		case ctx.Err() != nil:
+			job.Status = domain.BuildCancelled
+			job.Error = "build cancelled"
+		case err != nil:
+			job.Status = domain.BuildFailed
+			job.Error = err.Error()
+		default:
+			job.Status = domain.BuildSucceeded
+			job.ImageID = res.ImageID
+		}
+		uc.log.Info("image build finished", "job_id", job.ID, "status", job.Status, "image_id", job.ImageID, "error", job.Error)
+	})
+}
+
+func validateBuildOptions(opts *domain.BuildOptions) error {
+	for _, t := range opts.Tags {
+		if !validImageReference(t) {
+			return invalidInput("invalid tag %q", t)
+		}
+	}
+	for k := range opts.BuildArgs {
+		if k == "" || strings.ContainsAny(k, "= \t") {
+			return invalidInput("invalid build arg %q", k)
+		}
+	}
+	if opts.Dockerfile != "" {
+		clean := path.Clean(opts.Dockerfile)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+			return invalidInput("dockerfile must be a path inside the build context")
+		}
+		opts.Dockerfile = clean
+	}
+	return nil
+}
+
+// validImageReference accepts "repo" or "repo:tag".
+func validImageReference(ref string) bool {
+	repo, tag := ref, ""
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		repo, tag = ref[:i], ref[i+1:]
+		if !tagPattern.MatchString(tag) {
+			return false
+		}
+	}
+	return validRepository(repo)
+}
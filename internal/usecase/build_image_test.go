@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockBuilder struct {
+	release chan struct{}
+	opts    domain.BuildOptions
+	err     error
+}
+
+func (m *mockBuilder) Build(ctx context.Context, buildContext io.Reader, opts domain.BuildOptions, output func(string)) (*domain.BuildResult, error) {
+	m.opts = opts
+	output("Step 1/2 : FROM alpine")
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	output("Step 2/2 : RUN true")
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.BuildResult{ImageID: "sha256:built"}, nil
+}
+
+func buildInput(opts domain.BuildOptions) BuildImageInput {
+	return BuildImageInput{Context: io.NopCloser(strings.NewReader("tar")), Options: opts}
+}
+
+func TestBuildImage_FollowUntilDone(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	builder := &mockBuilder{release: make(chan struct{})}
+	jobs := NewBuildJobs()
+	uc := NewBuildImage(builder, nil, jobs, log)
+	ctx := context.Background()
+
+	job, err := uc.Execute(ctx, buildInput(domain.BuildOptions{
+		Tags:       []string{"registry.local:5000/app:v1"},
+		Dockerfile: "./docker/Dockerfile",
+		BuildArgs:  map[string]string{"NPM_TOKEN": "s3cr3t"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Options.BuildArgs["NPM_TOKEN"] != domain.RedactedSecret {
+		t.Errorf("build arg not redacted: %v", job.Options.BuildArgs)
+	}
+	if job.Status != domain.BuildRunning {
+		t.Errorf("expected running job, got %s", job.Status)
+	}
+
+	var lines []string
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(builder.release)
+	}()
+	final, err := NewFollowBuild(jobs, log).Execute(ctx, FollowBuildInput{ID: job.ID, Output: func(l string) { lines = append(lines, l) }})
+	if err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if final.Status != domain.BuildSucceeded || final.ImageID != "sha256:built" {
+		t.Errorf("unexpected final job: %+v", final)
+	}
+	if len(lines) != 2 {
+		t.Errorf("expected 2 output lines, got %v", lines)
+	}
+	if builder.opts.BuildArgs["NPM_TOKEN"] != "s3cr3t" {
+		t.Errorf("builder got build args %v", builder.opts.BuildArgs)
+	}
+	if builder.opts.Dockerfile != "docker/Dockerfile" {
+		t.Errorf("dockerfile not cleaned: %q", builder.opts.Dockerfile)
+	}
+
+	got, err := NewGetBuild(jobs, log).Execute(ctx, job.ID)
+	if err != nil || len(got.Output) != 2 {
+		t.Errorf("get: %v, output %v", err, got)
+	}
+}
+
+func TestBuildImage_Cancel(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	jobs := NewBuildJobs()
+	uc := NewBuildImage(&mockBuilder{release: make(chan struct{})}, nil, jobs, log)
+	ctx := context.Background()
+
+	job, err := uc.Execute(ctx, buildInput(domain.BuildOptions{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := NewCancelBuild(jobs, log).Execute(ctx, job.ID); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	final, err := NewFollowBuild(jobs, log).Execute(ctx, FollowBuildInput{ID: job.ID, Output: func(string) {}})
+	if err != nil || final.Status != domain.BuildCancelled {
+		t.Errorf("expected cancelled job, got %+v (%v)", final, err)
+	}
+	if err := NewCancelBuild(jobs, log).Execute(ctx, job.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("expected ErrConflict cancelling a finished build, got %v", err)
+	}
+}
+
+func TestBuildImage_InvalidOptions(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	uc := NewBuildImage(&mockBuilder{}, nil, NewBuildJobs(), log)
+	for _, opts := range []domain.BuildOptions{
+		{Tags: []string{"App:latest"}},
+		{Tags: []string{"app:bad tag"}},
+		{Dockerfile: "../Dockerfile"},
+		{BuildArgs: map[string]string{"A=B": "c"}},
+	} {
+		if _, err := uc.Execute(context.Background(), buildInput(opts)); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", opts, err)
+		}
+	}
+	if _, err := NewGetBuild(NewBuildJobs(), log).Execute(context.Background(), "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	maxBuildOutputLines = 5000
+	maxFinishedBuilds   = 50
+)
+
+type buildJob struct {
+	job    domain.BuildJob
+	lines  []string
+	total  int
+	cancel context.CancelFunc
+	// changed is closed and replaced whenever the job gets output or
+	// finishes, waking up followers.
+	changed chan struct{}
+}
+
+// BuildJobs holds the builds started by BuildImage, shared with the use
+// cases that list, follow and cancel them. Jobs and their output are kept
+// in memory; only the most recent finished jobs are retained.
+type BuildJobs struct {
+	mu    sync.Mutex
+	jobs  map[string]*buildJob
+	order []string
+}
+
+func NewBuildJobs() *BuildJobs {
+	return &BuildJobs{jobs: make(map[string]*buildJob)}
+}
+
+func (b *BuildJobs) add(j *buildJob) *domain.BuildJob {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.jobs[j.job.ID] = j
+	b.order = append(b.order, j.job.ID)
+	b.prune()
+	return b.snapshot(j, false)
+}
+
+func (b *BuildJobs) appendOutput(j *buildJob, line string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	j.lines = append(j.lines, line)
+	j.total++
+	if len(j.lines) > maxBuildOutputLines {
+		j.lines = j.lines[len(j.lines)-maxBuildOutputLines:]
+	}
+	b.notify(j)
+}
+
+// finish applies update to the job under the lock and wakes up followers.
+func (b *BuildJobs) finish(j *buildJob, update func(*domain.BuildJob)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	update(&j.job)
+	b.notify(j)
+	b.prune()
+}
+
+func (b *BuildJobs) list() []*domain.BuildJob {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]*domain.BuildJob, 0, len(b.order))
+	for i := len(b.order) - 1; i >= 0; i-- {
+		out = append(out, b.snapshot(b.jobs[b.order[i]], false))
+	}
+	return out
+}
+
+func (b *BuildJobs) get(id string, withOutput bool) (*domain.BuildJob, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	j, ok := b.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("build %s: %w", id, domain.ErrNotFound)
+	}
+	return b.snapshot(j, withOutput), nil
+}
+
+func (b *BuildJobs) cancel(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	j, ok := b.jobs[id]
+	if !ok {
+		return fmt.Errorf("build %s: %w", id, domain.ErrNotFound)
+	}
+	if j.job.Status != domain.BuildRunning {
+		return fmt.Errorf("build %s already %s: %w", id, j.job.Status, domain.ErrConflict)
+	}
+	j.cancel()
+	return nil
+}
+
+// follow replays the job's buffered output to fn, then keeps calling it
+// with new lines until the build finishes or ctx is done. It returns the
+// finished job.
+func (b *BuildJobs) follow(ctx context.Context, id string, fn func(line string)) (*domain.BuildJob, error) {
+	next := 0
+	for {
+		b.mu.Lock()
+		j, ok := b.jobs[id]
+		if !ok {
+			b.mu.Unlock()
+			return nil, fmt.Errorf("build %s: %w", id, domain.ErrNotFound)
+		}
+		first := j.total - len(j.lines)
+		if next < first {
+			next = first
+		}
+		pending := append([]string(nil), j.lines[next-first:]...)
+		next = j.total
+		snap := b.snapshot(j, false)
+		changed := j.changed
+		b.mu.Unlock()
+
+		for _, line := range pending {
+			fn(line)
+		}
+		if snap.Status != domain.BuildRunning {
+			return snap, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// notify must be called with b.mu held.
+func (b *BuildJobs) notify(j *buildJob) {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// prune drops the oldest finished jobs beyond maxFinishedBuilds. It must be
+// called with b.mu held.
+func (b *BuildJobs) prune() {
+	finished := 0
+	for _, id := range b.order {
+		if b.jobs[id].job.Status != domain.BuildRunning {
+			finished++
+		}
+	}
+	kept := b.order[:0]
+	for _, id := range b.order {
+		if finished > maxFinishedBuilds && b.jobs[id].job.Status != domain.BuildRunning {
+			delete(b.jobs, id)
+			finished--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	b.order = kept
+}
+
+// snapshot must be called with b.mu held.
+func (b *BuildJobs) snapshot(j *buildJob, withOutput bool) *domain.BuildJob {
+	out := j.job
+	out.Options = j.job.Options.Redacted()
+	out.Options.Tags = append([]string(nil), j.job.Options.Tags...)
+	if withOutput {
+		out.Output = append([]string{}, j.lines...)
+	}
+	return &out
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+)
+
+type CancelBuild struct {
+	jobs *BuildJobs
+	log  *slog.Logger
+}
+
+func NewCancelBuild(jobs *BuildJobs, log *slog.Logger) *CancelBuild {
+	return &CancelBuild{jobs: jobs, log: log}
+}
+
+func (uc *CancelBuild) Execute(ctx context.Context, id string) error {
+	if err := uc.jobs.cancel(id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "image build cancel requested", "job_id", id)
+	return nil
+}
@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// cloneProgressInterval throttles progress reports while files are copied.
+const cloneProgressInterval = 500 * time.Millisecond
+
+type CloneVolumeInput struct {
+	Source string
+	// Target is the new volume. Its driver defaults to the source's; driver
+	// options and labels are not copied.
+	Target domain.VolumeCreateOptions
+	// Force clones even while running containers mount the source
+	// read-write, at the risk of copying files halfway through a write.
+	Force bool
+}
+
+// VolumeCloneProgress reports a clone in flight; TotalBytes is 0 when the
+// size of the source is unknown.
+type VolumeCloneProgress struct {
+	Files       int   `json:"files"`
+	CopiedBytes int64 `json:"copied_bytes"`
+	TotalBytes  int64 `json:"total_bytes,omitempty"`
+}
+
+// CloneVolumeOutput holds the file count and bytes found in both volumes
+// once the copy was verified.
+type CloneVolumeOutput struct {
+	Source string `json:"source"`
+	Volume string `json:"volume"`
+	Driver string `json:"driver"`
+	Files  int    `json:"files"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// CloneVolume creates a volume and copies another one into it: the source
+// is archived through a read-only helper container and extracted through a
+// second one mounting the new volume. The copy is then read back to compare
+// file counts and sizes. On any failure the new volume is removed.
+type CloneVolume struct {
+	volumes    domain.VolumeRepository
+	usage      domain.VolumeUsageProvider
+	containers domain.ContainerRepository
+	manager    domain.VolumeManager
+	files      domain.VolumeFileAccess
+	log        *slog.Logger
+}
+
+func NewCloneVolume(volumes domain.VolumeRepository, usage domain.VolumeUsageProvider, containers domain.ContainerRepository, manager domain.VolumeManager, files domain.VolumeFileAccess, log *slog.Logger) *CloneVolume {
+	return &CloneVolume{volumes: volumes, usage: usage, containers: containers, manager: manager, files: files, log: log}
+}
+
+func (uc *CloneVolume) Execute(ctx context.Context, input CloneVolumeInput, progress func(VolumeCloneProgress)) (*CloneVolumeOutput, error) {
+	opts := input.Target
+	if input.Source == "" {
+		return nil, invalidInput("missing source volume")
+	}
+	if !volumeNamePattern.MatchString(opts.Name) {
+		return nil, invalidInput("invalid volume name %q", opts.Name)
+	}
+	if opts.Name == input.Source {
+		return nil, invalidInput("a volume cannot be cloned onto itself")
+	}
+	list, err := uc.volumes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var source *domain.Volume
+	for _, v := range list {
+		switch v.Name {
+		case input.Source:
+			source = v
+		case opts.Name:
+			return nil, fmt.Errorf("volume %s already exists: %w", opts.Name, domain.ErrConflict)
+		}
+	}
+	if source == nil {
+		return nil, fmt.Errorf("volume %s: %w", input.Source, domain.ErrNotFound)
+	}
+	if !input.Force {
+		if writers, err := uc.writers(ctx, input.Source); err != nil {
+			return nil, err
+		} else if len(writers) > 0 {
+			return nil, &domain.InUseError{Resource: "volume " + input.Source, Containers: writers}
+		}
+	}
+	if opts.Driver == "" {
+		opts.Driver = source.Driver
+	}
+
+	var total int64
+	if sizes, err := uc.usage.VolumeUsage(ctx); err != nil {
+		uc.log.WarnContext(ctx, "volume disk usage failed, cloning without a total", "error", err)
+	} else if u, ok := sizes[input.Source]; ok && u.Size > 0 {
+		total = u.Size
+	}
+
+	target, err := uc.manager.Create(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "cloning volume", "source", input.Source, "volume", target.Name, "driver", target.Driver)
+	out, err := uc.copy(ctx, input.Source, target.Name, total, progress)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "volume clone failed", "source", input.Source, "volume", target.Name, "error", err)
+		if rmErr := uc.manager.Remove(context.WithoutCancel(ctx), target.Name, true); rmErr != nil {
+			uc.log.ErrorContext(ctx, "remove failed clone", "volume", target.Name, "error", rmErr)
+		}
+		return nil, err
+	}
+	out.Driver = target.Driver
+	uc.log.InfoContext(ctx, "volume cloned", "source", input.Source, "volume", target.Name, "files", out.Files, "bytes", out.Bytes)
+	return out, nil
+}
+
+// writers returns the running containers that mount volume read-write.
+func (uc *CloneVolume) writers(ctx context.Context, volume string) ([]string, error) {
+	cs, err := uc.containers.ListActive(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, c := range cs {
+		if c.State != "running" {
+			continue
+		}
+		for _, m := range c.Mounts {
+			if m.Type == "volume" && m.Name == volume && m.RW {
+				ids = append(ids, c.ID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (uc *CloneVolume) copy(ctx context.Context, source, target string, total int64, progress func(VolumeCloneProgress)) (*CloneVolumeOutput, error) {
+	rc, _, err := uc.files.Read(ctx, source, "/")
+	if err != nil {
+		return nil, err
+	}
+	p := VolumeCloneProgress{TotalBytes: total}
+	progress(p)
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		last := time.Now()
+		err := retar(tar.NewReader(rc), pw, "", func(hdr *tar.Header) {
+			if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+				p.Files++
+				p.CopiedBytes += hdr.Size
+			}
+			if time.Since(last) >= cloneProgressInterval {
+				last = time.Now()
+				progress(p)
+			}
+		})
+		rc.Close()
+		pw.CloseWithError(err)
+		done <- err
+	}()
+	err = uc.files.Write(ctx, target, "/", pr)
+	// Unblocks the copy if the extraction stopped reading early.
+	pr.Close()
+	copyErr := <-done
+	switch {
+	case copyErr != nil && !errors.Is(copyErr, io.ErrClosedPipe):
+		return nil, fmt.Errorf("read volume %s: %w", source, copyErr)
+	case err != nil:
+		return nil, err
+	}
+	progress(p)
+
+	files, bytes, err := uc.measure(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("verify clone: %w", err)
+	}
+	if files != p.Files || bytes != p.CopiedBytes {
+		return nil, fmt.Errorf("verify clone: source has %d files (%d bytes) but the clone has %d files (%d bytes)", p.Files, p.CopiedBytes, files, bytes)
+	}
+	return &CloneVolumeOutput{Source: source, Volume: target, Files: files, Bytes: bytes}, nil
+}
+
+// measure counts the regular files of a volume and their bytes.
+func (uc *CloneVolume) measure(ctx context.Context, volume string) (int, int64, error) {
+	rc, _, err := uc.files.Read(ctx, volume, "/")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rc.Close()
+	var (
+		files int
+		bytes int64
+	)
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files, bytes, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+		if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			files++
+			bytes += hdr.Size
+		}
+	}
+}
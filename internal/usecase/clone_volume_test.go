@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestCloneVolume_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes := &mockVolumeRepo{list: []*domain.Volume{
+		{Name: "app-data", Driver: "local"},
+		{Name: "existing", Driver: "local"},
+	}}
+	usage := &mockVolumeUsage{usage: map[string]domain.VolumeUsageData{"app-data": {Size: 8}}}
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "db", State: "running", Mounts: []domain.Mount{{Type: "volume", Name: "app-data", RW: true}}},
+		{ID: "reader", State: "running", Mounts: []domain.Mount{{Type: "volume", Name: "app-data"}}},
+	}}
+	manager := &mockVolumeManager{}
+	uc := NewCloneVolume(volumes, usage, containers, manager, &mockVolumeFiles{}, log)
+	ctx := context.Background()
+	noProgress := func(VolumeCloneProgress) {}
+
+	// Only the read-write mount blocks the clone.
+	_, err := uc.Execute(ctx, CloneVolumeInput{Source: "app-data", Target: domain.VolumeCreateOptions{Name: "app-copy"}}, noProgress)
+	var inUse *domain.InUseError
+	if !errors.As(err, &inUse) || !reflect.DeepEqual(inUse.Containers, []string{"db"}) {
+		t.Fatalf("expected InUseError for db, got %v", err)
+	}
+	if manager.created != nil {
+		t.Errorf("volume created despite the conflict: %+v", manager.created)
+	}
+
+	var last VolumeCloneProgress
+	out, err := uc.Execute(ctx, CloneVolumeInput{Source: "app-data", Target: domain.VolumeCreateOptions{Name: "app-copy"}, Force: true}, func(p VolumeCloneProgress) {
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if manager.created == nil || manager.created.Driver != "local" {
+		t.Errorf("created = %+v", manager.created)
+	}
+	if out.Volume != "app-copy" || out.Files != 2 || out.Bytes != 8 {
+		t.Errorf("out = %+v", out)
+	}
+	if last != (VolumeCloneProgress{Files: 2, CopiedBytes: 8, TotalBytes: 8}) {
+		t.Errorf("last progress = %+v", last)
+	}
+
+	if _, err := uc.Execute(ctx, CloneVolumeInput{Source: "app-data", Target: domain.VolumeCreateOptions{Name: "existing"}, Force: true}, noProgress); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("expected ErrConflict for an existing target, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, CloneVolumeInput{Source: "missing", Target: domain.VolumeCreateOptions{Name: "copy"}}, noProgress); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
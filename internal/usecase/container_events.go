@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const eventsReconnectDelay = 5 * time.Second
+
+// consumeContainerEvents calls handle for every container event until the
+// context is cancelled, resubscribing when the event stream drops.
+func consumeContainerEvents(ctx context.Context, src domain.ContainerEventSource, log *slog.Logger, handle func(domain.ContainerEvent)) error {
+	for {
+		evCh, errCh := src.Events(ctx)
+		for ev := range evCh {
+			handle(ev)
+		}
+		if err := <-errCh; err != nil {
+			log.WarnContext(ctx, "container events stream ended, reconnecting", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(eventsReconnectDelay):
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// CrashLoopPolicy flags a container as crash-looping after Restarts restarts
+// (a start that follows a die) within Window.
+type CrashLoopPolicy struct {
+	Restarts int
+	Window   time.Duration
+}
+
+var DefaultCrashLoopPolicy = CrashLoopPolicy{Restarts: 5, Window: 10 * time.Minute}
+
+func (p CrashLoopPolicy) RestartsWithin(history []domain.LifecycleEvent, now time.Time) int {
+	cutoff := now.Add(-p.Window)
+	n := 0
+	died := false
+	for _, ev := range history {
+		switch ev.Action {
+		case domain.EventDie:
+			died = true
+		case domain.EventStart:
+			if died && !ev.Time.Before(cutoff) {
+				n++
+			}
+			died = false
+		}
+	}
+	return n
+}
+
+func (p CrashLoopPolicy) CrashLooping(history []domain.LifecycleEvent, now time.Time) bool {
+	return p.Restarts > 0 && p.RestartsWithin(history, now) >= p.Restarts
+}
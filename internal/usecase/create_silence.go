@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type CreateSilence struct {
+	repo domain.SilenceRepository
+	log  *slog.Logger
+}
+
+func NewCreateSilence(repo domain.SilenceRepository, log *slog.Logger) *CreateSilence {
+	return &CreateSilence{repo: repo, log: log}
+}
+
+func (uc *CreateSilence) Execute(ctx context.Context, s domain.Silence) (*domain.Silence, error) {
+	now := time.Now().UTC()
+	if s.StartsAt.IsZero() {
+		s.StartsAt = now
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return nil, invalidInput("ends_at must be after starts_at")
+	}
+	if len(s.Matchers) == 0 {
+		return nil, invalidInput("at least one matcher is required")
+	}
+	for _, m := range s.Matchers {
+		if err := validateMatcher(m); err != nil {
+			return nil, err
+		}
+	}
+	s.ID = newID()
+	s.CreatedAt = now
+	if err := uc.repo.Save(ctx, &s); err != nil {
+		uc.log.ErrorContext(ctx, "save silence failed", "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "silence created", "silence_id", s.ID, "ends_at", s.EndsAt)
+	return &s, nil
+}
+
+func validateMatcher(m domain.Matcher) error {
+	switch m.Field {
+	case domain.MatchContainer, domain.MatchRule:
+	case domain.MatchLabel:
+		if m.Label == "" {
+			return invalidInput("label matcher requires a label name")
+		}
+	default:
+		return invalidInput("matcher field must be one of container, label, rule")
+	}
+	if m.Value == "" {
+		return invalidInput("matcher value is required")
+	}
+	if _, err := path.Match(m.Value, ""); err != nil {
+		return invalidInput("invalid matcher pattern %q", m.Value)
+	}
+	return nil
+}
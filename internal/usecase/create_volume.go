@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultVolumeDriver = "local"
+
+// volumeNamePattern is the daemon's own rule for volume names.
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+type CreateVolume struct {
+	volumes domain.VolumeManager
+	log     *slog.Logger
+}
+
+func NewCreateVolume(volumes domain.VolumeManager, log *slog.Logger) *CreateVolume {
+	return &CreateVolume{volumes: volumes, log: log}
+}
+
+func (uc *CreateVolume) Execute(ctx context.Context, opts domain.VolumeCreateOptions) (*domain.Volume, error) {
+	opts.Name = strings.TrimSpace(opts.Name)
+	if opts.Name != "" && !volumeNamePattern.MatchString(opts.Name) {
+		return nil, invalidInput("invalid volume name %q: use letters, digits, '_', '.' and '-', starting with a letter or digit", opts.Name)
+	}
+	if opts.Driver = strings.TrimSpace(opts.Driver); opts.Driver == "" {
+		opts.Driver = defaultVolumeDriver
+	}
+	for k := range opts.DriverOpts {
+		if strings.TrimSpace(k) == "" {
+			return nil, invalidInput("driver option names must not be empty")
+		}
+	}
+	for k := range opts.Labels {
+		if strings.TrimSpace(k) == "" {
+			return nil, invalidInput("label names must not be empty")
+		}
+	}
+	return uc.volumes.Create(ctx, opts)
+}
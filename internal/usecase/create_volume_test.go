@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockVolumeManager struct {
+	created   *domain.VolumeCreateOptions
+	removeErr error
+	pruned    *domain.VolumePruneFilter
+}
+
+func (m *mockVolumeManager) Create(ctx context.Context, opts domain.VolumeCreateOptions) (*domain.Volume, error) {
+	m.created = &opts
+	return &domain.Volume{Name: opts.Name, Driver: opts.Driver, Labels: opts.Labels}, nil
+}
+
+func (m *mockVolumeManager) Remove(ctx context.Context, name string, force bool) error {
+	return m.removeErr
+}
+
+func (m *mockVolumeManager) Prune(ctx context.Context, filter domain.VolumePruneFilter) (*domain.VolumePruneResult, error) {
+	m.pruned = &filter
+	return &domain.VolumePruneResult{Deleted: []string{}}, nil
+}
+
+func TestCreateVolume_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes := &mockVolumeManager{}
+	uc := NewCreateVolume(volumes, log)
+	ctx := context.Background()
+
+	v, err := uc.Execute(ctx, domain.VolumeCreateOptions{Name: " pgdata ", Labels: map[string]string{"env": "dev"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Name != "pgdata" || volumes.created.Driver != defaultVolumeDriver {
+		t.Errorf("created %+v with %+v", v, volumes.created)
+	}
+
+	for _, opts := range []domain.VolumeCreateOptions{
+		{Name: "-bad"},
+		{Name: "has space"},
+		{Name: "ok", DriverOpts: map[string]string{"": "x"}},
+	} {
+		if _, err := uc.Execute(ctx, opts); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", opts, err)
+		}
+	}
+}
+
+func TestRemoveVolume_InUse(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inUse := &domain.InUseError{Resource: "volume pgdata", Containers: []string{"c1"}}
+	uc := NewRemoveVolume(&mockVolumeManager{removeErr: inUse}, log)
+
+	err := uc.Execute(context.Background(), RemoveVolumeInput{Name: "pgdata"})
+	var got *domain.InUseError
+	if !errors.As(err, &got) || !errors.Is(err, domain.ErrConflict) || got.Containers[0] != "c1" {
+		t.Errorf("expected InUseError, got %v", err)
+	}
+	if err := uc.Execute(context.Background(), RemoveVolumeInput{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPruneVolumes_Filter(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes := &mockVolumeManager{}
+	uc := NewPruneVolumes(volumes, log)
+
+	if _, err := uc.Execute(context.Background(), domain.VolumePruneFilter{Labels: []string{"env=dev"}, ExcludeLabels: []string{"keep"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if volumes.pruned == nil || volumes.pruned.ExcludeLabels[0] != "keep" {
+		t.Errorf("pruned with %+v", volumes.pruned)
+	}
+	if _, err := uc.Execute(context.Background(), domain.VolumePruneFilter{Labels: []string{"=x"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
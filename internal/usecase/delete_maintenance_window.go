@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteMaintenanceWindow struct {
+	repo domain.MaintenanceWindowRepository
+	log  *slog.Logger
+}
+
+func NewDeleteMaintenanceWindow(repo domain.MaintenanceWindowRepository, log *slog.Logger) *DeleteMaintenanceWindow {
+	return &DeleteMaintenanceWindow{repo: repo, log: log}
+}
+
+func (uc *DeleteMaintenanceWindow) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "maintenance window deleted", "window_id", id)
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteNotificationChannel struct {
+	repo domain.NotificationChannelRepository
+	log  *slog.Logger
+}
+
+func NewDeleteNotificationChannel(repo domain.NotificationChannelRepository, log *slog.Logger) *DeleteNotificationChannel {
+	return &DeleteNotificationChannel{repo: repo, log: log}
+}
+
+func (uc *DeleteNotificationChannel) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "notification channel deleted", "channel_id", id)
+	return nil
+}
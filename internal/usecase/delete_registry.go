@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteRegistry struct {
+	repo domain.RegistryRepository
+	log  *slog.Logger
+}
+
+func NewDeleteRegistry(repo domain.RegistryRepository, log *slog.Logger) *DeleteRegistry {
+	return &DeleteRegistry{repo: repo, log: log}
+}
+
+func (uc *DeleteRegistry) Execute(ctx context.Context, name string) error {
+	if err := uc.repo.Delete(ctx, name); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "registry deleted", "registry", name)
+	return nil
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteRegistryCredential struct {
+	repo domain.RegistryCredentialRepository
+	log  *slog.Logger
+}
+
+func NewDeleteRegistryCredential(repo domain.RegistryCredentialRepository, log *slog.Logger) *DeleteRegistryCredential {
+	return &DeleteRegistryCredential{repo: repo, log: log}
+}
+
+func (uc *DeleteRegistryCredential) Execute(ctx context.Context, registry string) error {
+	registry = domain.NormalizeRegistryHost(registry)
+	if err := uc.repo.Delete(ctx, registry); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "registry credential deleted", "registry", registry)
+	return nil
+}
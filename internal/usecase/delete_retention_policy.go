@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteRetentionPolicy struct {
+	repo domain.RetentionPolicyRepository
+	log  *slog.Logger
+}
+
+func NewDeleteRetentionPolicy(repo domain.RetentionPolicyRepository, log *slog.Logger) *DeleteRetentionPolicy {
+	return &DeleteRetentionPolicy{repo: repo, log: log}
+}
+
+func (uc *DeleteRetentionPolicy) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "retention policy deleted", "policy_id", id)
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteSilence struct {
+	repo domain.SilenceRepository
+	log  *slog.Logger
+}
+
+func NewDeleteSilence(repo domain.SilenceRepository, log *slog.Logger) *DeleteSilence {
+	return &DeleteSilence{repo: repo, log: log}
+}
+
+func (uc *DeleteSilence) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "silence deleted", "silence_id", id)
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type DeleteVolumeBackup struct {
+	backups domain.VolumeBackupRepository
+	log     *slog.Logger
+}
+
+func NewDeleteVolumeBackup(backups domain.VolumeBackupRepository, log *slog.Logger) *DeleteVolumeBackup {
+	return &DeleteVolumeBackup{backups: backups, log: log}
+}
+
+func (uc *DeleteVolumeBackup) Execute(ctx context.Context, id string) error {
+	if err := uc.backups.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "volume backup deleted", "backup_id", id)
+	return nil
+}
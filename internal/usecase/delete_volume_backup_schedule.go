@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// DeleteVolumeBackupSchedule deletes a schedule; its backups are kept.
+type DeleteVolumeBackupSchedule struct {
+	repo domain.VolumeBackupScheduleRepository
+	log  *slog.Logger
+}
+
+func NewDeleteVolumeBackupSchedule(repo domain.VolumeBackupScheduleRepository, log *slog.Logger) *DeleteVolumeBackupSchedule {
+	return &DeleteVolumeBackupSchedule{repo: repo, log: log}
+}
+
+func (uc *DeleteVolumeBackupSchedule) Execute(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "volume backup schedule deleted", "schedule_id", id)
+	return nil
+}
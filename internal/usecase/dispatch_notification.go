@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// notificationQueueSize bounds the notifications waiting for one channel;
+// further ones are dropped until the channel catches up.
+const notificationQueueSize = 64
+
+type queuedNotification struct {
+	ctx context.Context
+	ch  *domain.NotificationChannel
+	n   *domain.Notification
+}
+
+// DispatchNotification fans a notification out to every enabled channel.
+// Delivery is asynchronous: each channel has its own bounded queue, drained
+// by a worker that lives while the queue has work, so a slow or unreachable
+// channel (the notifier retries with backoff) delays only itself.
+type DispatchNotification struct {
+	repo     domain.NotificationChannelRepository
+	notifier domain.Notifier
+	log      *slog.Logger
+
+	mu sync.Mutex
+	// queues holds a channel's queue while its worker runs; the worker
+	// removes it once the queue is empty.
+	queues map[string]chan queuedNotification
+}
+
+func NewDispatchNotification(repo domain.NotificationChannelRepository, notifier domain.Notifier, log *slog.Logger) *DispatchNotification {
+	return &DispatchNotification{
+		repo:     repo,
+		notifier: notifier,
+		log:      log,
+		queues:   make(map[string]chan queuedNotification),
+	}
+}
+
+// Execute queues n and returns without waiting for delivery. Deliveries
+// stop when ctx is cancelled.
+func (uc *DispatchNotification) Execute(ctx context.Context, n *domain.Notification) error {
+	channels, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "dispatch notification: list channels failed", "error", err)
+		return err
+	}
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+
+	queued := 0
+	for _, ch := range channels {
+		if !ch.Enabled {
+			continue
+		}
+		if uc.enqueue(queuedNotification{ctx: ctx, ch: ch, n: n}) {
+			queued++
+		}
+	}
+	uc.log.DebugContext(ctx, "notification queued", "title", n.Title, "channels", queued)
+	return nil
+}
+
+func (uc *DispatchNotification) enqueue(job queuedNotification) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	q, ok := uc.queues[job.ch.ID]
+	if !ok {
+		q = make(chan queuedNotification, notificationQueueSize)
+		uc.queues[job.ch.ID] = q
+		go uc.drain(job.ch.ID, q)
+	}
+	select {
+	case q <- job:
+		return true
+	default:
+		uc.log.WarnContext(job.ctx, "notification queue full, dropping notification", "channel_id", job.ch.ID, "title", job.n.Title)
+		return false
+	}
+}
+
+// drain delivers the queued notifications of one channel in order and exits
+// once the queue is empty; enqueue starts a new worker for the next one.
+func (uc *DispatchNotification) drain(channelID string, q chan queuedNotification) {
+	for {
+		uc.mu.Lock()
+		var job queuedNotification
+		select {
+		case job = <-q:
+		default:
+			delete(uc.queues, channelID)
+			uc.mu.Unlock()
+			return
+		}
+		uc.mu.Unlock()
+
+		if err := uc.notifier.Send(job.ctx, job.ch, job.n); err != nil {
+			uc.log.WarnContext(job.ctx, "notification delivery failed", "channel_id", job.ch.ID, "title", job.n.Title, "error", err)
+		}
+	}
+}
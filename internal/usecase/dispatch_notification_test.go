@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockNotifier struct {
+	mu   sync.Mutex
+	sent []string
+	fail map[string]error
+	// block, when set, holds every delivery to the channels it lists until
+	// it is closed.
+	block map[string]chan struct{}
+}
+
+func (m *mockNotifier) Send(ctx context.Context, ch *domain.NotificationChannel, n *domain.Notification) error {
+	if b := m.block[ch.ID]; b != nil {
+		<-b
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.fail[ch.ID]; err != nil {
+		return err
+	}
+	m.sent = append(m.sent, ch.ID)
+	return nil
+}
+
+func (m *mockNotifier) sentTo() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.sent...)
+}
+
+func TestDispatchNotification_EnabledChannelsOnly(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockChannelRepo(
+		&domain.NotificationChannel{ID: "on", Enabled: true},
+		&domain.NotificationChannel{ID: "off", Enabled: false},
+		&domain.NotificationChannel{ID: "broken", Enabled: true},
+	)
+	notifier := &mockNotifier{fail: map[string]error{"broken": errors.New("boom")}}
+	uc := NewDispatchNotification(repo, notifier, log)
+
+	if err := uc.Execute(context.Background(), &domain.Notification{Title: "t"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uc.wait()
+	if sent := notifier.sentTo(); len(sent) != 1 || sent[0] != "on" {
+		t.Errorf("delivered: %v", sent)
+	}
+}
+
+func TestDispatchNotification_SlowChannelDoesNotBlock(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockChannelRepo(
+		&domain.NotificationChannel{ID: "slow", Enabled: true},
+		&domain.NotificationChannel{ID: "fast", Enabled: true},
+	)
+	release := make(chan struct{})
+	notifier := &mockNotifier{block: map[string]chan struct{}{"slow": release}}
+	uc := NewDispatchNotification(repo, notifier, log)
+
+	done := make(chan error)
+	go func() { done <- uc.Execute(context.Background(), &domain.Notification{Title: "t"}) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Execute waited for a blocked channel")
+	}
+
+	deadline := time.After(5 * time.Second)
+	for len(notifier.sentTo()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("fast channel not notified while slow was blocked")
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	// Overfill the slow channel's queue: the overflow is dropped instead of
+	// blocking the caller.
+	for i := 0; i < notificationQueueSize+5; i++ {
+		if err := uc.Execute(context.Background(), &domain.Notification{Title: "t"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	close(release)
+	uc.wait()
+	slow := 0
+	for _, id := range notifier.sentTo() {
+		if id == "slow" {
+			slow++
+		}
+	}
+	// One in flight when the rest were queued, plus a full queue.
+	if slow < notificationQueueSize || slow > notificationQueueSize+1 {
+		t.Errorf("slow channel got %d notifications, want the queue bound", slow)
+	}
+}
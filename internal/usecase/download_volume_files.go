@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	VolumeDownloadRaw = "raw"
+	VolumeDownloadTar = "tar"
+	VolumeDownloadZip = "zip"
+)
+
+type DownloadVolumeFilesInput struct {
+	Volume string
+	Path   string
+	// Format is raw (a single file as is), tar or zip. It defaults to raw
+	// for files and tar for directories.
+	Format string
+}
+
+// VolumeDownload is a download ready to stream; closing Body releases the
+// helper container even when it was not read to the end.
+type VolumeDownload struct {
+	Filename string
+	Format   string
+	Body     io.ReadCloser
+}
+
+type DownloadVolumeFiles struct {
+	files domain.VolumeFileAccess
+	log   *slog.Logger
+}
+
+func NewDownloadVolumeFiles(files domain.VolumeFileAccess, log *slog.Logger) *DownloadVolumeFiles {
+	return &DownloadVolumeFiles{files: files, log: log}
+}
+
+func (uc *DownloadVolumeFiles) Execute(ctx context.Context, input DownloadVolumeFilesInput) (*VolumeDownload, error) {
+	if input.Volume == "" {
+		return nil, invalidInput("missing volume name")
+	}
+	p := cleanVolumePath(input.Path)
+	rc, entry, err := uc.files.Read(ctx, input.Volume, p)
+	if err != nil {
+		return nil, err
+	}
+	body, err := uc.download(ctx, input, p, rc, entry)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return body, nil
+}
+
+// download takes over rc once it returns without an error.
+func (uc *DownloadVolumeFiles) download(ctx context.Context, input DownloadVolumeFilesInput, p string, rc io.ReadCloser, entry *domain.VolumeFileEntry) (*VolumeDownload, error) {
+	format := input.Format
+	if format == "" {
+		format = VolumeDownloadTar
+		if entry.Type == domain.FileTypeFile {
+			format = VolumeDownloadRaw
+		}
+	}
+	switch format {
+	case VolumeDownloadRaw:
+		if entry.Type != domain.FileTypeFile {
+			return nil, invalidInput("%s is not a regular file; download it as tar or zip", p)
+		}
+	case VolumeDownloadTar, VolumeDownloadZip:
+	default:
+		return nil, invalidInput("unknown download format %q, expected raw, tar or zip", format)
+	}
+	base := entry.Name
+	if p == "/" {
+		base = input.Volume
+	}
+
+	tr := tar.NewReader(rc)
+	if format == VolumeDownloadRaw {
+		if _, err := tr.Next(); err != nil {
+			return nil, fmt.Errorf("read %s from volume %s: %w", p, input.Volume, err)
+		}
+		return &VolumeDownload{Filename: base, Format: format, Body: &tarFileBody{Reader: tr, Closer: rc}}, nil
+	}
+
+	uc.log.InfoContext(ctx, "downloading volume files", "volume", input.Volume, "path", p, "format", format)
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if format == VolumeDownloadZip {
+			err = tarToZip(tr, pw, base)
+		} else {
+			err = retar(tr, pw, base, nil)
+		}
+		rc.Close()
+		pw.CloseWithError(err)
+	}()
+	return &VolumeDownload{Filename: base + "." + format, Format: format, Body: pr}, nil
+}
+
+type tarFileBody struct {
+	io.Reader
+	io.Closer
+}
+
+// renameEntry moves an archive entry below base, the download's top-level
+// name. With an empty base the entry is made relative to the archived path,
+// which itself becomes "".
+func renameEntry(name, base string) string {
+	if rel := archiveRelName(name); rel != "" {
+		return path.Join(base, rel)
+	}
+	return base
+}
+
+// retar copies the archive, renaming its top-level entry to base, or
+// dropping it when base is empty. copied, if set, is called after each
+// entry.
+func retar(tr *tar.Reader, w io.Writer, base string, copied func(*tar.Header)) error {
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return tw.Close()
+		}
+		if err != nil {
+			return err
+		}
+		dir := hdr.Typeflag == tar.TypeDir
+		hdr.Name = renameEntry(hdr.Name, base)
+		if hdr.Name == "" {
+			continue
+		}
+		if dir {
+			hdr.Name += "/"
+		}
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = renameEntry(hdr.Linkname, base)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+		if copied != nil {
+			copied(hdr)
+		}
+	}
+}
+
+// tarToZip converts the archive to zip below base. Symlinks are stored the
+// way zip tools expect, as entries holding their target; zip has no hard
+// links, so those are skipped along with devices and other special files.
+func tarToZip(tr *tar.Reader, w io.Writer, base string) error {
+	zw := zip.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return zw.Close()
+		}
+		if err != nil {
+			return err
+		}
+		fh := &zip.FileHeader{Name: renameEntry(hdr.Name, base), Modified: hdr.ModTime, Method: zip.Deflate}
+		fh.SetMode(hdr.FileInfo().Mode())
+		var src io.Reader = tr
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			fh.Name += "/"
+			fh.Method = zip.Store
+		case tar.TypeSymlink:
+			src = strings.NewReader(hdr.Linkname)
+		case tar.TypeReg, tar.TypeRegA:
+		default:
+			continue
+		}
+		fw, err := zw.CreateHeader(fh)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(fw, src); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type EnforceImagePolicyInput struct {
+	// ContainerID selects the container whose image is checked. Image is
+	// the reference to check when there is no container yet.
+	ContainerID string
+	Image       string
+	Action      string
+}
+
+// EnforceImagePolicy checks an image against the image policy before a
+// container starts. In audit mode violations are only logged and recorded;
+// in enforce mode they fail with a *domain.ImagePolicyError.
+//
+// A policy that cannot be read does not block containers unless the last
+// policy read was in enforce mode; a missing policy is off.
+type EnforceImagePolicy struct {
+	policies   domain.ImagePolicyRepository
+	decisions  domain.ImagePolicyDecisionRepository
+	containers domain.ContainerInspector
+	images     domain.ImageInspector
+	log        *slog.Logger
+	now        func() time.Time
+
+	mu       sync.Mutex
+	lastMode string
+}
+
+func NewEnforceImagePolicy(policies domain.ImagePolicyRepository, decisions domain.ImagePolicyDecisionRepository, containers domain.ContainerInspector, images domain.ImageInspector, log *slog.Logger) *EnforceImagePolicy {
+	return &EnforceImagePolicy{policies: policies, decisions: decisions, containers: containers, images: images, log: log, now: time.Now}
+}
+
+func (uc *EnforceImagePolicy) Execute(ctx context.Context, input EnforceImagePolicyInput) error {
+	policy, err := uc.policy(ctx)
+	if err != nil {
+		return err
+	}
+	if policy.Mode != domain.ImagePolicyAudit && policy.Mode != domain.ImagePolicyEnforce {
+		return nil
+	}
+
+	d := &domain.ImagePolicyDecision{
+		Time:   uc.now().UTC(),
+		Image:  input.Image,
+		Action: input.Action,
+		Mode:   policy.Mode,
+	}
+	imageRef := input.Image
+	if input.ContainerID != "" {
+		c, err := uc.containers.Inspect(ctx, input.ContainerID)
+		if err != nil {
+			return err
+		}
+		d.ContainerID, d.Container, d.Image = c.ID, c.Name, c.Image
+		// The container's own image, even if its reference was retagged since.
+		imageRef = c.ImageID
+	}
+	if d.Image == "" {
+		return invalidInput("missing image")
+	}
+	d.Violations, err = evaluateImagePolicy(ctx, uc.images, policy, d.Image, imageRef, d.Time)
+	if err != nil {
+		return err
+	}
+	d.Allowed = len(d.Violations) == 0 || policy.Mode == domain.ImagePolicyAudit
+	if err := uc.decisions.Append(ctx, d); err != nil {
+		uc.log.ErrorContext(ctx, "record image policy decision failed", "error", err)
+	}
+
+	switch {
+	case !d.Allowed:
+		uc.log.WarnContext(ctx, "image policy blocked action", "container_id", d.ContainerID, "image", d.Image, "action", d.Action, "violations", len(d.Violations))
+		return &domain.ImagePolicyError{Image: d.Image, Violations: d.Violations}
+	case len(d.Violations) > 0:
+		uc.log.WarnContext(ctx, "image policy violated (audit only)", "container_id", d.ContainerID, "image", d.Image, "action", d.Action, "violations", len(d.Violations))
+	}
+	return nil
+}
+
+// policy reads the current policy and remembers its mode, which decides
+// whether a later read failure fails closed.
+func (uc *EnforceImagePolicy) policy(ctx context.Context) (*domain.ImagePolicy, error) {
+	policy, err := uc.policies.Get(ctx)
+	if errors.Is(err, domain.ErrNotFound) {
+		policy, err = &domain.ImagePolicy{Mode: domain.ImagePolicyOff}, nil
+	}
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if err != nil {
+		if uc.lastMode == domain.ImagePolicyEnforce {
+			uc.log.ErrorContext(ctx, "load image policy failed, blocking while the last policy enforced", "error", err)
+			return nil, err
+		}
+		uc.log.ErrorContext(ctx, "load image policy failed, allowing action", "error", err)
+		return &domain.ImagePolicy{Mode: domain.ImagePolicyOff}, nil
+	}
+	uc.lastMode = policy.Mode
+	return policy, nil
+}
+
+// evaluateImagePolicy checks ref, resolved locally through imageRef. An
+// image missing locally is evaluated without its metadata.
+func evaluateImagePolicy(ctx context.Context, images domain.ImageInspector, policy *domain.ImagePolicy, ref, imageRef string, now time.Time) ([]domain.PolicyViolation, error) {
+	img, err := images.Inspect(ctx, imageRef)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	return policy.Evaluate(ref, img, now), nil
+}
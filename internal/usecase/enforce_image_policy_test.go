@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type memImagePolicies struct {
+	policy    domain.ImagePolicy
+	decisions []*domain.ImagePolicyDecision
+}
+
+func (m *memImagePolicies) Get(ctx context.Context) (*domain.ImagePolicy, error) {
+	p := m.policy
+	return &p, nil
+}
+
+func (m *memImagePolicies) Save(ctx context.Context, p *domain.ImagePolicy) error {
+	m.policy = *p
+	return nil
+}
+
+func (m *memImagePolicies) Append(ctx context.Context, d *domain.ImagePolicyDecision) error {
+	m.decisions = append(m.decisions, d)
+	return nil
+}
+
+func (m *memImagePolicies) List(ctx context.Context, limit int) ([]*domain.ImagePolicyDecision, error) {
+	return m.decisions, nil
+}
+
+func TestExecuteContainerAction_ImagePolicy(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	store := &memImagePolicies{policy: domain.ImagePolicy{Mode: domain.ImagePolicyEnforce, DeniedTags: []string{"latest"}}}
+	containers := &mockInspector{details: map[string]*domain.ContainerDetails{
+		"web": {ID: "web", Name: "web", Image: "nginx:latest", ImageID: "sha256:web"},
+		"api": {ID: "api", Name: "api", Image: "registry.local/api:1.2", ImageID: "sha256:api"},
+	}}
+	images := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"sha256:web": {ID: "sha256:web", CreatedAt: time.Now()},
+		"sha256:api": {ID: "sha256:api", CreatedAt: time.Now()},
+	}}
+	ctrl := &mockController{}
+	uc := NewExecuteContainerAction(ctrl, NewEnforceImagePolicy(store, store, containers, images, log), log)
+	ctx := context.Background()
+
+	err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "web", Action: domain.ActionStart})
+	var policyErr *domain.ImagePolicyError
+	if !errors.As(err, &policyErr) || !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("expected ImagePolicyError, got %v", err)
+	}
+	if len(policyErr.Violations) != 1 || policyErr.Violations[0].Rule != domain.PolicyRuleDeniedTags || policyErr.Image != "nginx:latest" {
+		t.Errorf("unexpected violations: %+v", policyErr)
+	}
+	if ctrl.lastID != "" {
+		t.Errorf("blocked container was started")
+	}
+
+	// Stopping is never checked.
+	if err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "web", Action: domain.ActionStop}); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "api", Action: domain.ActionRestart}); err != nil {
+		t.Fatalf("restart of compliant container: %v", err)
+	}
+
+	store.policy.Mode = domain.ImagePolicyAudit
+	if err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "web", Action: domain.ActionStart}); err != nil {
+		t.Fatalf("audit mode should not block: %v", err)
+	}
+	if ctrl.lastID != "web" || ctrl.lastAction != domain.ActionStart {
+		t.Errorf("controller called with %q, %q", ctrl.lastID, ctrl.lastAction)
+	}
+
+	if len(store.decisions) != 3 {
+		t.Fatalf("expected 3 decisions, got %d", len(store.decisions))
+	}
+	blocked, audited := store.decisions[0], store.decisions[2]
+	if blocked.Allowed || blocked.Mode != domain.ImagePolicyEnforce || blocked.Container != "web" {
+		t.Errorf("unexpected blocked decision: %+v", blocked)
+	}
+	if !audited.Allowed || audited.Mode != domain.ImagePolicyAudit || len(audited.Violations) != 1 {
+		t.Errorf("unexpected audit decision: %+v", audited)
+	}
+
+	store.policy.Mode = domain.ImagePolicyOff
+	if err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "web", Action: domain.ActionStart}); err != nil || len(store.decisions) != 3 {
+		t.Errorf("policy off: err %v, %d decisions", err, len(store.decisions))
+	}
+}
+
+type failingImagePolicies struct {
+	*memImagePolicies
+	err error
+}
+
+func (f *failingImagePolicies) Get(ctx context.Context) (*domain.ImagePolicy, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.memImagePolicies.Get(ctx)
+}
+
+func TestEnforceImagePolicy_UnreadablePolicy(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	mem := &memImagePolicies{policy: domain.ImagePolicy{Mode: domain.ImagePolicyOff}}
+	store := &failingImagePolicies{memImagePolicies: mem, err: errors.New("corrupt policy file")}
+	containers := &mockInspector{details: map[string]*domain.ContainerDetails{
+		"web": {ID: "web", Name: "web", Image: "nginx:latest", ImageID: "sha256:web"},
+	}}
+	uc := NewEnforceImagePolicy(store, mem, containers, &mockImageInspector{}, log)
+	ctx := context.Background()
+	input := EnforceImagePolicyInput{ContainerID: "web", Action: domain.ActionStart}
+
+	if err := uc.Execute(ctx, input); err != nil {
+		t.Fatalf("unreadable policy with no enforce mode seen should allow, got %v", err)
+	}
+
+	store.err = domain.ErrNotFound
+	if err := uc.Execute(ctx, input); err != nil {
+		t.Fatalf("missing policy should be off, got %v", err)
+	}
+
+	store.err = nil
+	mem.policy = domain.ImagePolicy{Mode: domain.ImagePolicyEnforce}
+	if err := uc.Execute(ctx, input); err != nil {
+		t.Fatalf("compliant container: %v", err)
+	}
+	store.err = errors.New("corrupt policy file")
+	if err := uc.Execute(ctx, input); err == nil {
+		t.Error("unreadable policy after enforce mode should block")
+	}
+}
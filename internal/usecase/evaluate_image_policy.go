@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ImagePolicyEvaluation struct {
+	Image      string                   `json:"image"`
+	Mode       string                   `json:"mode"`
+	Violations []domain.PolicyViolation `json:"violations"`
+	// Allowed reports whether the image would pass the policy in enforce
+	// mode, whatever the current mode.
+	Allowed bool `json:"allowed"`
+}
+
+// EvaluateImagePolicy is the dry run of the image policy for one image
+// reference; it records nothing.
+type EvaluateImagePolicy struct {
+	policies domain.ImagePolicyRepository
+	images   domain.ImageInspector
+	log      *slog.Logger
+}
+
+func NewEvaluateImagePolicy(policies domain.ImagePolicyRepository, images domain.ImageInspector, log *slog.Logger) *EvaluateImagePolicy {
+	return &EvaluateImagePolicy{policies: policies, images: images, log: log}
+}
+
+func (uc *EvaluateImagePolicy) Execute(ctx context.Context, ref string) (*ImagePolicyEvaluation, error) {
+	if ref == "" {
+		return nil, invalidInput("missing image")
+	}
+	policy, err := uc.policies.Get(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "load image policy failed", "error", err)
+		return nil, err
+	}
+	violations, err := evaluateImagePolicy(ctx, uc.images, policy, ref, ref, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &ImagePolicyEvaluation{Image: ref, Mode: policy.Mode, Violations: violations, Allowed: len(violations) == 0}, nil
+}
@@ -23,11 +23,13 @@ type ExecuteContainerActionInput struct {
 
 type ExecuteContainerAction struct {
 	ctrl domain.ContainerController
-	log  *slog.Logger
+	// policy is checked before start and restart; nil disables the check.
+	policy *EnforceImagePolicy
+	log    *slog.Logger
 }
 
-func NewExecuteContainerAction(ctrl domain.ContainerController, log *slog.Logger) *ExecuteContainerAction {
-	return &ExecuteContainerAction{ctrl: ctrl, log: log}
+func NewExecuteContainerAction(ctrl domain.ContainerController, policy *EnforceImagePolicy, log *slog.Logger) *ExecuteContainerAction {
+	return &ExecuteContainerAction{ctrl: ctrl, policy: policy, log: log}
 }
 
 func (uc *ExecuteContainerAction) Execute(ctx context.Context, input ExecuteContainerActionInput) error {
@@ -37,6 +39,12 @@ func (uc *ExecuteContainerAction) Execute(ctx context.Context, input ExecuteCont
 	if !allowedActions[input.Action] {
 		return errors.New("invalid action: must be one of start, stop, restart, pause, unpause")
 	}
+	if uc.policy != nil && (input.Action == domain.ActionStart || input.Action == domain.ActionRestart) {
+		err := uc.policy.Execute(ctx, EnforceImagePolicyInput{ContainerID: input.ContainerID, Action: input.Action})
+		if err != nil {
+			return err
+		}
+	}
 	err := uc.ctrl.ExecuteAction(ctx, input.ContainerID, input.Action)
 	if err != nil {
 		uc.log.ErrorContext(ctx, "container action failed", "container_id", input.ContainerID, "action", input.Action, "error", err)
@@ -12,18 +12,21 @@ type mockController struct {
 	lastID     string
 	lastAction string
 	err        error
+	// actions records every call as "id:action".
+	actions []string
 }
 
 func (m *mockController) ExecuteAction(ctx context.Context, containerID, action string) error {
 	m.lastID = containerID
 	m.lastAction = action
+	m.actions = append(m.actions, containerID+":"+action)
 	return m.err
 }
 
 func TestExecuteContainerAction_Validation(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	ctrl := &mockController{}
-	uc := NewExecuteContainerAction(ctrl, log)
+	uc := NewExecuteContainerAction(ctrl, nil, log)
 	ctx := context.Background()
 
 	t.Run("missing container id", func(t *testing.T) {
@@ -69,7 +72,7 @@ func TestExecuteContainerAction_Validation(t *testing.T) {
 func TestExecuteContainerAction_ControllerError(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	ctrl := &mockController{err: errors.New("docker error")}
-	uc := NewExecuteContainerAction(ctrl, log)
+	uc := NewExecuteContainerAction(ctrl, nil, log)
 	ctx := context.Background()
 
 	err := uc.Execute(ctx, ExecuteContainerActionInput{ContainerID: "cid", Action: "start"})
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const (
+	imageFileTreeCacheSize = 8
+	topWastedFiles         = 20
+)
+
+type ExploreImageFilesInput struct {
+	Ref string
+	// Layer indexes the filesystem layers, bottom first; a negative value
+	// selects the top layer, i.e. the final image.
+	Layer int
+	Path  string
+}
+
+type ImageFileLayer struct {
+	domain.LayerFileStats
+	DiffID    string `json:"diff_id"`
+	CreatedBy string `json:"created_by"`
+}
+
+type ExploreImageFilesOutput struct {
+	ImageID     string                 `json:"image_id"`
+	Layer       int                    `json:"layer"`
+	Path        string                 `json:"path"`
+	Entries     []domain.FileTreeEntry `json:"entries"`
+	Layers      []ImageFileLayer       `json:"layers"`
+	TotalBytes  int64                  `json:"total_bytes"`
+	WastedBytes int64                  `json:"wasted_bytes"`
+	// Efficiency is the share of layer bytes still visible in the final
+	// image.
+	Efficiency float64             `json:"efficiency"`
+	Wasted     []domain.WastedFile `json:"wasted"`
+}
+
+type imageFileAnalysis struct {
+	tree   *domain.ImageFileTree
+	layers []ImageFileLayer
+}
+
+// ExploreImageFiles reads every layer of an image to build its file tree.
+// That means exporting the whole image, so analyses are cached by image ID
+// (image content never changes) and concurrent requests for the same image
+// share one export.
+type ExploreImageFiles struct {
+	inspector domain.ImageInspector
+	reader    domain.ImageLayerReader
+	log       *slog.Logger
+	cache     *imageCache[*imageFileAnalysis]
+}
+
+func NewExploreImageFiles(inspector domain.ImageInspector, reader domain.ImageLayerReader, log *slog.Logger) *ExploreImageFiles {
+	return &ExploreImageFiles{
+		inspector: inspector,
+		reader:    reader,
+		log:       log,
+		cache:     newImageCache[*imageFileAnalysis](imageFileTreeCacheSize),
+	}
+}
+
+func (uc *ExploreImageFiles) Execute(ctx context.Context, input ExploreImageFilesInput) (*ExploreImageFilesOutput, error) {
+	if input.Ref == "" {
+		return nil, invalidInput("missing image id")
+	}
+	details, err := uc.inspector.Inspect(ctx, input.Ref)
+	if err != nil {
+		return nil, err
+	}
+	a, err := uc.cache.get(ctx, details.ID, func() (*imageFileAnalysis, error) {
+		return uc.analyze(ctx, details)
+	})
+	if err != nil {
+		return nil, err
+	}
+	layer := input.Layer
+	if layer < 0 {
+		layer = len(a.layers) - 1
+	}
+	if layer >= len(a.layers) {
+		return nil, invalidInput("layer must be between 0 and %d", len(a.layers)-1)
+	}
+	dir := input.Path
+	if dir == "" {
+		dir = "/"
+	}
+	entries, err := a.tree.List(layer, dir)
+	if err != nil {
+		return nil, err
+	}
+	out := &ExploreImageFilesOutput{
+		ImageID:     details.ID,
+		Layer:       layer,
+		Path:        dir,
+		Entries:     entries,
+		Layers:      a.layers,
+		TotalBytes:  a.tree.TotalBytes,
+		WastedBytes: a.tree.WastedBytes,
+		Efficiency:  1,
+		Wasted:      a.tree.TopWasted(topWastedFiles),
+	}
+	if a.tree.TotalBytes > 0 {
+		out.Efficiency = float64(a.tree.TotalBytes-a.tree.WastedBytes) / float64(a.tree.TotalBytes)
+	}
+	return out, nil
+}
+
+func (uc *ExploreImageFiles) analyze(ctx context.Context, details *domain.ImageDetails) (*imageFileAnalysis, error) {
+	contents, err := uc.reader.ReadLayers(ctx, details.ID)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "image layer read failed", "image_id", details.ID, "error", err)
+		return nil, err
+	}
+	a := &imageFileAnalysis{tree: domain.BuildImageFileTree(contents)}
+	// Pair the filesystem layers with the history entries that created them.
+	var history []domain.ImageLayer
+	for _, l := range details.Layers {
+		if !l.Empty {
+			history = append(history, l)
+		}
+	}
+	for i, st := range a.tree.Layers {
+		l := ImageFileLayer{LayerFileStats: st}
+		if i < len(history) {
+			l.DiffID = history[i].DiffID
+			l.CreatedBy = history[i].CreatedBy
+		}
+		a.layers = append(a.layers, l)
+	}
+	uc.log.InfoContext(ctx, "image files analyzed", "image_id", details.ID, "layers", len(a.layers), "wasted_bytes", a.tree.WastedBytes)
+	return a, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockLayerReader struct {
+	layers map[string][]domain.LayerContents
+	reads  int
+}
+
+func (m *mockLayerReader) ReadLayers(ctx context.Context, imageID string) ([]domain.LayerContents, error) {
+	m.reads++
+	l, ok := m.layers[imageID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return l, nil
+}
+
+func TestExploreImageFiles_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"app:latest": {
+			ID: "sha256:app",
+			Layers: []domain.ImageLayer{
+				{DiffID: "sha256:a", CreatedBy: "ADD rootfs.tar /"},
+				{CreatedBy: "ENV A=b", Empty: true},
+				{DiffID: "sha256:b", CreatedBy: "RUN rm /tmp/big"},
+			},
+		},
+	}}
+	reader := &mockLayerReader{layers: map[string][]domain.LayerContents{
+		"sha256:app": {
+			{Files: []domain.LayerFile{{Path: "tmp/big", Type: domain.FileTypeFile, Size: 300}, {Path: "app", Type: domain.FileTypeFile, Size: 100}}},
+			{Files: []domain.LayerFile{{Path: "tmp/big", Whiteout: true}}},
+		},
+	}}
+	uc := NewExploreImageFiles(inspector, reader, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, ExploreImageFilesInput{Ref: "app:latest", Layer: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Layer != 1 || out.WastedBytes != 300 || out.Efficiency != 0.25 {
+		t.Errorf("layer=%d wasted=%d efficiency=%v", out.Layer, out.WastedBytes, out.Efficiency)
+	}
+	if len(out.Layers) != 2 || out.Layers[1].CreatedBy != "RUN rm /tmp/big" || out.Layers[1].Deleted != 1 {
+		t.Errorf("unexpected layers: %+v", out.Layers)
+	}
+
+	out, err = uc.Execute(ctx, ExploreImageFilesInput{Ref: "app:latest", Layer: 0, Path: "/tmp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Entries) != 1 || out.Entries[0].Path != "/tmp/big" || out.Entries[0].Change != domain.ChangeAdded {
+		t.Errorf("unexpected entries: %+v", out.Entries)
+	}
+	if reader.reads != 1 {
+		t.Errorf("expected analysis to be cached, image read %d times", reader.reads)
+	}
+
+	if _, err := uc.Execute(ctx, ExploreImageFilesInput{Ref: "app:latest", Layer: 2}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for bad layer, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, ExploreImageFilesInput{Ref: "app:latest", Layer: -1, Path: "/app"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a file path, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ExportImages struct {
+	archiver domain.ImageArchiver
+	log      *slog.Logger
+}
+
+func NewExportImages(archiver domain.ImageArchiver, log *slog.Logger) *ExportImages {
+	return &ExportImages{archiver: archiver, log: log}
+}
+
+// Execute returns the `docker save` tarball of refs; the caller must close
+// it.
+func (uc *ExportImages) Execute(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	seen := make(map[string]bool, len(refs))
+	var list []string
+	for _, r := range refs {
+		r = strings.TrimSpace(r)
+		if r == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+		list = append(list, r)
+	}
+	if len(list) == 0 {
+		return nil, invalidInput("missing image reference")
+	}
+	uc.log.InfoContext(ctx, "exporting images", "refs", list)
+	return uc.archiver.Export(ctx, list)
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import "time"
+
+// wait blocks until every queued notification has been handled: a
+// channel's queue is dropped only after its last delivery returns.
+func (uc *DispatchNotification) wait() {
+	for {
+		uc.mu.Lock()
+		idle := len(uc.queues) == 0
+		uc.mu.Unlock()
+		if idle {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// FireAlert records an alert and notifies channels unless an active silence
+// or maintenance window covers it.
+type FireAlert struct {
+	alerts   domain.AlertRepository
+	silences domain.SilenceRepository
+	windows  domain.MaintenanceWindowRepository
+	dispatch *DispatchNotification
+	now      func() time.Time
+	log      *slog.Logger
+}
+
+func NewFireAlert(
+	alerts domain.AlertRepository,
+	silences domain.SilenceRepository,
+	windows domain.MaintenanceWindowRepository,
+	dispatch *DispatchNotification,
+	log *slog.Logger,
+) *FireAlert {
+	return &FireAlert{
+		alerts:   alerts,
+		silences: silences,
+		windows:  windows,
+		dispatch: dispatch,
+		now:      time.Now,
+		log:      log,
+	}
+}
+
+func (uc *FireAlert) Execute(ctx context.Context, a *domain.Alert) error {
+	now := uc.now()
+	if a.ID == "" {
+		a.ID = newID()
+	}
+	if a.FiredAt.IsZero() {
+		a.FiredAt = now.UTC()
+	}
+	suppressedBy, err := uc.suppressedBy(ctx, a, now)
+	if err != nil {
+		uc.log.WarnContext(ctx, "checking silences failed, notifying anyway", "error", err)
+	}
+	a.SuppressedBy = suppressedBy
+
+	if err := uc.alerts.Append(ctx, a); err != nil {
+		uc.log.ErrorContext(ctx, "recording alert failed", "rule", a.Rule, "error", err)
+	}
+	if a.SuppressedBy != "" {
+		uc.log.DebugContext(ctx, "alert suppressed", "rule", a.Rule, "container", a.ContainerName, "by", a.SuppressedBy)
+		return nil
+	}
+	// Delivery is queued so a slow channel never holds up the caller.
+	return uc.dispatch.Execute(ctx, a.Notification())
+}
+
+func (uc *FireAlert) suppressedBy(ctx context.Context, a *domain.Alert, now time.Time) (string, error) {
+	silences, err := uc.silences.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, s := range silences {
+		if s.ActiveAt(now) && s.Matches(a) {
+			return "silence:" + s.ID, nil
+		}
+	}
+	windows, err := uc.windows.List(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, w := range windows {
+		if w.Covers(a) && w.ActiveAt(now) {
+			return "maintenance:" + w.ID, nil
+		}
+	}
+	return "", nil
+}
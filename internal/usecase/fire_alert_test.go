@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockAlertRepo struct {
+	alerts []*domain.Alert
+}
+
+func (m *mockAlertRepo) Append(ctx context.Context, a *domain.Alert) error {
+	m.alerts = append(m.alerts, a)
+	return nil
+}
+
+func (m *mockAlertRepo) List(ctx context.Context, limit int) ([]*domain.Alert, error) {
+	return m.alerts, nil
+}
+
+type mockSilenceRepo struct {
+	list []*domain.Silence
+}
+
+func (m *mockSilenceRepo) List(ctx context.Context) ([]*domain.Silence, error) { return m.list, nil }
+func (m *mockSilenceRepo) Save(ctx context.Context, s *domain.Silence) error   { return nil }
+func (m *mockSilenceRepo) Delete(ctx context.Context, id string) error         { return nil }
+
+type mockWindowRepo struct {
+	list []*domain.MaintenanceWindow
+}
+
+func (m *mockWindowRepo) List(ctx context.Context) ([]*domain.MaintenanceWindow, error) {
+	return m.list, nil
+}
+func (m *mockWindowRepo) Get(ctx context.Context, id string) (*domain.MaintenanceWindow, error) {
+	return nil, domain.ErrNotFound
+}
+func (m *mockWindowRepo) Save(ctx context.Context, w *domain.MaintenanceWindow) error { return nil }
+func (m *mockWindowRepo) Delete(ctx context.Context, id string) error                 { return nil }
+
+func TestFireAlert_Suppression(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	now := time.Date(2026, 3, 4, 2, 15, 0, 0, time.Local)
+
+	silences := &mockSilenceRepo{list: []*domain.Silence{{
+		ID:       "deploy",
+		Matchers: []domain.Matcher{{Field: domain.MatchContainer, Value: "api-*"}, {Field: domain.MatchRule, Value: domain.RuleContainerDied}},
+		StartsAt: now.Add(-time.Minute),
+		EndsAt:   now.Add(time.Hour),
+	}}}
+	windows := &mockWindowRepo{list: []*domain.MaintenanceWindow{{
+		ID:              "nightly",
+		Schedule:        "0 2 * * *",
+		DurationMinutes: 30,
+		Labels:          map[string]string{"tier": "batch"},
+		Enabled:         true,
+	}}}
+
+	cases := []struct {
+		name  string
+		alert domain.Alert
+		want  string
+	}{
+		{"silenced by name and rule", domain.Alert{Rule: domain.RuleContainerDied, ContainerName: "api-1"}, "silence:deploy"},
+		{"silence needs every matcher", domain.Alert{Rule: domain.RuleContainerOOM, ContainerName: "api-1"}, ""},
+		{"maintenance window by label", domain.Alert{Rule: domain.RuleContainerOOM, ContainerName: "etl", Labels: map[string]string{"tier": "batch"}}, "maintenance:nightly"},
+		{"outside scope", domain.Alert{Rule: domain.RuleContainerDied, ContainerName: "web", Labels: map[string]string{"tier": "front"}}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			alerts := &mockAlertRepo{}
+			notifier := &mockNotifier{}
+			channels := newMockChannelRepo(&domain.NotificationChannel{ID: "ch", Enabled: true})
+			dispatch := NewDispatchNotification(channels, notifier, log)
+			uc := NewFireAlert(alerts, silences, windows, dispatch, log)
+			uc.now = func() time.Time { return now }
+
+			a := c.alert
+			if err := uc.Execute(context.Background(), &a); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(alerts.alerts) != 1 {
+				t.Fatalf("alert should always be recorded, got %d", len(alerts.alerts))
+			}
+			if a.SuppressedBy != c.want {
+				t.Errorf("suppressed_by: got %q, want %q", a.SuppressedBy, c.want)
+			}
+			dispatch.wait()
+			notified := len(notifier.sent) == 1
+			if notified != (c.want == "") {
+				t.Errorf("notified=%v with suppressed_by=%q", notified, a.SuppressedBy)
+			}
+		})
+	}
+}
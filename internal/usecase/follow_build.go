@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type FollowBuildInput struct {
+	ID string
+	// Output receives the buffered output first, then every new line.
+	Output func(line string)
+}
+
+// FollowBuild streams a build's output until it finishes, and returns the
+// finished job.
+type FollowBuild struct {
+	jobs *BuildJobs
+	log  *slog.Logger
+}
+
+func NewFollowBuild(jobs *BuildJobs, log *slog.Logger) *FollowBuild {
+	return &FollowBuild{jobs: jobs, log: log}
+}
+
+func (uc *FollowBuild) Execute(ctx context.Context, input FollowBuildInput) (*domain.BuildJob, error) {
+	return uc.jobs.follow(ctx, input.ID, input.Output)
+}
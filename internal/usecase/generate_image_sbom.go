@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const imageSBOMCacheSize = 32
+
+type GenerateImageSBOMInput struct {
+	Ref string
+	// Format is one of the domain.SBOMFormat* values; empty means CycloneDX.
+	Format string
+}
+
+type GenerateImageSBOMOutput struct {
+	SBOM     *domain.SBOM
+	Format   string
+	Document []byte
+}
+
+// GenerateImageSBOM inventories the packages installed in a local image by
+// reading its layers, without any network access. The scan exports the
+// whole image, so inventories are cached by image ID, which is the digest
+// of the image config.
+type GenerateImageSBOM struct {
+	inspector domain.ImageInspector
+	scanner   domain.ImageFileScanner
+	cataloger domain.PackageCataloger
+	encoder   domain.SBOMEncoder
+	log       *slog.Logger
+	cache     *imageCache[*domain.SBOM]
+	now       func() time.Time
+}
+
+func NewGenerateImageSBOM(inspector domain.ImageInspector, scanner domain.ImageFileScanner, cataloger domain.PackageCataloger, encoder domain.SBOMEncoder, log *slog.Logger) *GenerateImageSBOM {
+	return &GenerateImageSBOM{
+		inspector: inspector,
+		scanner:   scanner,
+		cataloger: cataloger,
+		encoder:   encoder,
+		log:       log,
+		cache:     newImageCache[*domain.SBOM](imageSBOMCacheSize),
+		now:       time.Now,
+	}
+}
+
+func (uc *GenerateImageSBOM) Execute(ctx context.Context, input GenerateImageSBOMInput) (*GenerateImageSBOMOutput, error) {
+	if input.Ref == "" {
+		return nil, invalidInput("missing image id")
+	}
+	format := input.Format
+	if format == "" {
+		format = domain.SBOMFormatCycloneDX
+	}
+	if format != domain.SBOMFormatCycloneDX && format != domain.SBOMFormatSPDX {
+		return nil, invalidInput("format must be %q or %q", domain.SBOMFormatCycloneDX, domain.SBOMFormatSPDX)
+	}
+	sbom, err := uc.inventory(ctx, input.Ref)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := uc.encoder.Encode(sbom, format)
+	if err != nil {
+		return nil, err
+	}
+	return &GenerateImageSBOMOutput{SBOM: sbom, Format: format, Document: doc}, nil
+}
+
+// inventory returns the SBOM of the image ref names, from the cache when
+// possible. Other use cases go through it to share the cache.
+func (uc *GenerateImageSBOM) inventory(ctx context.Context, ref string) (*domain.SBOM, error) {
+	details, err := uc.inspector.Inspect(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return uc.cache.get(ctx, details.ID, func() (*domain.SBOM, error) {
+		return uc.generate(ctx, details)
+	})
+}
+
+func (uc *GenerateImageSBOM) generate(ctx context.Context, details *domain.ImageDetails) (*domain.SBOM, error) {
+	results, err := uc.scanner.ScanFiles(ctx, details.ID, uc.cataloger.Wants, uc.cataloger.Read)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "image scan failed", "image_id", details.ID, "error", err)
+		return nil, err
+	}
+	distro, pkgs := uc.cataloger.Catalog(results)
+	sbom := &domain.SBOM{
+		ImageID:      details.ID,
+		RepoTags:     details.RepoTags,
+		RepoDigests:  details.RepoDigests,
+		Architecture: details.Architecture,
+		OS:           details.OS,
+		Distro:       distro,
+		Packages:     pkgs,
+		GeneratedAt:  uc.now().UTC(),
+	}
+	uc.log.InfoContext(ctx, "image sbom generated", "image_id", details.ID, "packages", len(pkgs))
+	return sbom, nil
+}
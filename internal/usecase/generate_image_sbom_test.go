@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockFileScanner struct {
+	mu    sync.Mutex
+	files map[string]string
+	scans int
+}
+
+func (m *mockFileScanner) ScanFiles(ctx context.Context, imageID string, want func(domain.LayerFile) bool, read func(domain.LayerFile, io.Reader) (any, error)) (map[string]any, error) {
+	m.mu.Lock()
+	m.scans++
+	m.mu.Unlock()
+	results := make(map[string]any)
+	for p, content := range m.files {
+		f := domain.LayerFile{Path: p, Type: domain.FileTypeFile, Size: int64(len(content))}
+		if !want(f) {
+			continue
+		}
+		if v, err := read(f, strings.NewReader(content)); err == nil && v != nil {
+			results[p] = v
+		}
+	}
+	return results, nil
+}
+
+// lineCataloger reads "name version" lines from files under "pkgs/".
+type lineCataloger struct{}
+
+func (lineCataloger) Wants(f domain.LayerFile) bool { return strings.HasPrefix(f.Path, "pkgs/") }
+
+func (lineCataloger) Read(f domain.LayerFile, r io.Reader) (any, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	name, version, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+	return domain.Package{Name: name, Version: version, Type: domain.PackageTypeDeb, Location: "/" + f.Path}, nil
+}
+
+func (lineCataloger) Catalog(results map[string]any) (*domain.Distro, []domain.Package) {
+	var pkgs []domain.Package
+	for _, v := range results {
+		pkgs = append(pkgs, v.(domain.Package))
+	}
+	return &domain.Distro{ID: "debian"}, pkgs
+}
+
+type formatEncoder struct{}
+
+func (formatEncoder) Encode(sbom *domain.SBOM, format string) ([]byte, error) {
+	return []byte(format + ":" + sbom.ImageID), nil
+}
+
+func TestGenerateImageSBOM_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"app:latest": {ID: "sha256:app", RepoTags: []string{"app:latest"}, Architecture: "amd64", OS: "linux"},
+		"sha256:app": {ID: "sha256:app", RepoTags: []string{"app:latest"}, Architecture: "amd64", OS: "linux"},
+	}}
+	scanner := &mockFileScanner{files: map[string]string{
+		"pkgs/bash": "bash 5.2",
+		"etc/motd":  "hello",
+	}}
+	uc := NewGenerateImageSBOM(inspector, scanner, lineCataloger{}, formatEncoder{}, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, GenerateImageSBOMInput{Ref: "app:latest"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Format != domain.SBOMFormatCycloneDX || string(out.Document) != "cyclonedx:sha256:app" {
+		t.Errorf("format=%s document=%s", out.Format, out.Document)
+	}
+	if len(out.SBOM.Packages) != 1 || out.SBOM.Packages[0].Name != "bash" || out.SBOM.Distro.ID != "debian" || out.SBOM.Architecture != "amd64" {
+		t.Errorf("unexpected sbom: %+v", out.SBOM)
+	}
+
+	// Another reference to the same image reuses the inventory.
+	out, err = uc.Execute(ctx, GenerateImageSBOMInput{Ref: "sha256:app", Format: domain.SBOMFormatSPDX})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out.Document) != "spdx:sha256:app" {
+		t.Errorf("document = %s", out.Document)
+	}
+	if scanner.scans != 1 {
+		t.Errorf("expected the inventory to be cached, image scanned %d times", scanner.scans)
+	}
+
+	if _, err := uc.Execute(ctx, GenerateImageSBOMInput{Ref: "app:latest", Format: "xml"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, GenerateImageSBOMInput{Ref: "missing"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// GetBuild returns a build including its buffered output.
+type GetBuild struct {
+	jobs *BuildJobs
+	log  *slog.Logger
+}
+
+func NewGetBuild(jobs *BuildJobs, log *slog.Logger) *GetBuild {
+	return &GetBuild{jobs: jobs, log: log}
+}
+
+func (uc *GetBuild) Execute(ctx context.Context, id string) (*domain.BuildJob, error) {
+	return uc.jobs.get(id, true)
+}
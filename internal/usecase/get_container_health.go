@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const healthNone = "none"
+
+type GetContainerHealthOutput struct {
+	ID            string               `json:"id"`
+	Name          string               `json:"name"`
+	Status        string               `json:"status"`
+	FailingStreak int                  `json:"failing_streak"`
+	Probes        []domain.HealthProbe `json:"probes"`
+}
+
+type GetContainerHealth struct {
+	inspector domain.ContainerInspector
+	log       *slog.Logger
+}
+
+func NewGetContainerHealth(inspector domain.ContainerInspector, log *slog.Logger) *GetContainerHealth {
+	return &GetContainerHealth{inspector: inspector, log: log}
+}
+
+// Execute returns the healthcheck state with at most limit probe results,
+// newest last. Status is "none" when the container has no healthcheck.
+func (uc *GetContainerHealth) Execute(ctx context.Context, containerID string, limit int) (*GetContainerHealthOutput, error) {
+	if containerID == "" {
+		return nil, invalidInput("missing container id")
+	}
+	details, err := uc.inspector.Inspect(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	out := &GetContainerHealthOutput{
+		ID:     details.ID,
+		Name:   details.Name,
+		Status: healthNone,
+		Probes: make([]domain.HealthProbe, 0),
+	}
+	h := details.State.Health
+	if h == nil {
+		return out, nil
+	}
+	out.Status = h.Status
+	out.FailingStreak = h.FailingStreak
+	probes := h.Probes
+	if limit > 0 && len(probes) > limit {
+		probes = probes[len(probes)-limit:]
+	}
+	out.Probes = append(out.Probes, probes...)
+	return out, nil
+}
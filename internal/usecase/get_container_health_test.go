@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockInspector struct {
+	details map[string]*domain.ContainerDetails
+}
+
+func (m *mockInspector) Inspect(ctx context.Context, containerID string) (*domain.ContainerDetails, error) {
+	d, ok := m.details[containerID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return d, nil
+}
+
+func TestGetContainerHealth_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockInspector{details: map[string]*domain.ContainerDetails{
+		"plain": {ID: "plain", Name: "plain"},
+		"web": {ID: "web", Name: "web", State: domain.ContainerState{Health: &domain.ContainerHealth{
+			Status:        domain.HealthUnhealthy,
+			FailingStreak: 3,
+			Probes: []domain.HealthProbe{
+				{ExitCode: 0, Output: "ok"},
+				{ExitCode: 1, Output: "timeout"},
+				{ExitCode: 1, Output: "connection refused"},
+			},
+		}}},
+	}}
+	uc := NewGetContainerHealth(inspector, log)
+	ctx := context.Background()
+
+	t.Run("no healthcheck", func(t *testing.T) {
+		out, err := uc.Execute(ctx, "plain", 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Status != "none" || len(out.Probes) != 0 {
+			t.Errorf("got %+v", out)
+		}
+	})
+
+	t.Run("limits to the newest probes", func(t *testing.T) {
+		out, err := uc.Execute(ctx, "web", 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Status != domain.HealthUnhealthy || out.FailingStreak != 3 {
+			t.Errorf("got %+v", out)
+		}
+		if len(out.Probes) != 2 || out.Probes[1].Output != "connection refused" {
+			t.Errorf("probes: %+v", out.Probes)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := uc.Execute(ctx, "missing", 0); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
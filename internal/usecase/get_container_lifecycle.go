@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type GetContainerLifecycleOutput struct {
+	ID    string                `json:"id"`
+	Name  string                `json:"name"`
+	Image string                `json:"image"`
+	State domain.ContainerState `json:"state"`
+	// CrashLooping and RestartsInWindow only count the restarts seen since
+	// dockscope started: the history is kept in memory, and the daemon's
+	// RestartCount (in State) has no timestamps to place restarts in the
+	// window. A container already crash-looping is flagged after Restarts
+	// more restarts.
+	CrashLooping     bool                    `json:"crash_looping"`
+	RestartsInWindow int                     `json:"restarts_in_window"`
+	WindowSeconds    int                     `json:"window_seconds"`
+	History          []domain.LifecycleEvent `json:"history"`
+}
+
+type GetContainerLifecycle struct {
+	inspector domain.ContainerInspector
+	lifecycle domain.LifecycleRepository
+	policy    CrashLoopPolicy
+	log       *slog.Logger
+}
+
+func NewGetContainerLifecycle(inspector domain.ContainerInspector, lifecycle domain.LifecycleRepository, policy CrashLoopPolicy, log *slog.Logger) *GetContainerLifecycle {
+	return &GetContainerLifecycle{inspector: inspector, lifecycle: lifecycle, policy: policy, log: log}
+}
+
+func (uc *GetContainerLifecycle) Execute(ctx context.Context, containerID string) (*GetContainerLifecycleOutput, error) {
+	if containerID == "" {
+		return nil, invalidInput("missing container id")
+	}
+	details, err := uc.inspector.Inspect(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := uc.lifecycle.History(ctx, details.ID)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "lifecycle history failed", "container_id", details.ID, "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	return &GetContainerLifecycleOutput{
+		ID:               details.ID,
+		Name:             details.Name,
+		Image:            details.Image,
+		State:            details.State,
+		CrashLooping:     uc.policy.CrashLooping(history, now),
+		RestartsInWindow: uc.policy.RestartsWithin(history, now),
+		WindowSeconds:    int(uc.policy.Window.Seconds()),
+		History:          history,
+	}, nil
+}
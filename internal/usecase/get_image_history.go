@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type GetImageHistoryOutput struct {
+	*domain.ImageDetails
+	// UniqueSize is the space only this image uses, i.e. what removing it
+	// would free; SharedSize is held by layers other images also use.
+	UniqueSize int64 `json:"unique_size"`
+	SharedSize int64 `json:"shared_size"`
+}
+
+type GetImageHistory struct {
+	inspector domain.ImageInspector
+	log       *slog.Logger
+}
+
+func NewGetImageHistory(inspector domain.ImageInspector, log *slog.Logger) *GetImageHistory {
+	return &GetImageHistory{inspector: inspector, log: log}
+}
+
+func (uc *GetImageHistory) Execute(ctx context.Context, imageID string) (*GetImageHistoryOutput, error) {
+	if imageID == "" {
+		return nil, invalidInput("missing image id")
+	}
+	details, err := uc.inspector.Inspect(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	out := &GetImageHistoryOutput{ImageDetails: details}
+	for _, l := range details.Layers {
+		if l.Shared {
+			out.SharedSize += l.Size
+		} else {
+			out.UniqueSize += l.Size
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockImageInspector struct {
+	details map[string]*domain.ImageDetails
+}
+
+func (m *mockImageInspector) Inspect(ctx context.Context, ref string) (*domain.ImageDetails, error) {
+	d, ok := m.details[ref]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return d, nil
+}
+
+func TestGetImageHistory_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"app": {
+			ID: "sha256:app",
+			Layers: []domain.ImageLayer{
+				{DiffID: "sha256:base", Size: 80, Shared: true},
+				{CreatedBy: "ENV A=b", Empty: true},
+				{DiffID: "sha256:deps", Size: 900},
+				{DiffID: "sha256:src", Size: 20},
+			},
+		},
+	}}
+	uc := NewGetImageHistory(inspector, log)
+
+	out, err := uc.Execute(context.Background(), "app")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.SharedSize != 80 || out.UniqueSize != 920 {
+		t.Errorf("shared/unique = %d/%d, want 80/920", out.SharedSize, out.UniqueSize)
+	}
+	if len(out.Layers) != 4 {
+		t.Errorf("expected 4 layers, got %d", len(out.Layers))
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := uc.Execute(context.Background(), ""); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
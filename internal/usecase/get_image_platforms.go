@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ImagePlatforms struct {
+	// Ref is the reference looked up in the registry.
+	Ref string `json:"ref"`
+	// Local is the platform of the local image, if there is one.
+	Local  *domain.Platform `json:"local,omitempty"`
+	Daemon *domain.Platform `json:"daemon,omitempty"`
+	// Distribution holds the manifest list entries; DistributionError says
+	// why it is missing, e.g. for an image that was never pushed.
+	Distribution      *domain.ImageDistribution `json:"distribution,omitempty"`
+	DistributionError string                    `json:"distribution_error,omitempty"`
+	// SupportsDaemon reports whether the registry has a variant of the image
+	// that runs natively on the daemon.
+	SupportsDaemon bool `json:"supports_daemon"`
+}
+
+// GetImagePlatforms reports the platform of a local image and the platforms
+// its reference provides in the registry.
+type GetImagePlatforms struct {
+	inspector    domain.ImageInspector
+	distribution domain.ImageDistributionInspector
+	daemon       domain.DaemonPlatformProvider
+	creds        domain.RegistryCredentialProvider
+	log          *slog.Logger
+}
+
+func NewGetImagePlatforms(inspector domain.ImageInspector, distribution domain.ImageDistributionInspector, daemon domain.DaemonPlatformProvider, creds domain.RegistryCredentialProvider, log *slog.Logger) *GetImagePlatforms {
+	return &GetImagePlatforms{inspector: inspector, distribution: distribution, daemon: daemon, creds: creds, log: log}
+}
+
+func (uc *GetImagePlatforms) Execute(ctx context.Context, ref string) (*ImagePlatforms, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, invalidInput("missing image reference")
+	}
+	out := &ImagePlatforms{Ref: ref}
+	img, err := uc.inspector.Inspect(ctx, ref)
+	switch {
+	case err == nil:
+		out.Local = &domain.Platform{OS: img.OS, Architecture: img.Architecture, Variant: img.Variant}
+		if out.Ref = registryRef(ref, img); out.Ref == "" {
+			out.DistributionError = "image has no repository reference"
+		}
+	case errors.Is(err, domain.ErrNotFound) && !looksLikeImageID(ref):
+		// Not pulled yet: ask the registry only.
+	default:
+		return nil, err
+	}
+	if out.Daemon, err = uc.daemon.DaemonPlatform(ctx); err != nil {
+		uc.log.WarnContext(ctx, "daemon platform lookup failed", "error", err)
+	}
+
+	if out.Ref != "" {
+		cred, err := credentialFor(ctx, uc.creds, out.Ref)
+		if err != nil {
+			uc.log.WarnContext(ctx, "registry credential lookup failed, inspecting anonymously", "ref", out.Ref, "error", err)
+		}
+		out.Distribution, err = uc.distribution.DistributionInspect(ctx, out.Ref, cred)
+		if err != nil {
+			if out.Local == nil {
+				return nil, err
+			}
+			out.DistributionError = err.Error()
+		}
+	}
+	if out.Daemon != nil && out.Distribution != nil {
+		for _, p := range out.Distribution.Platforms {
+			if p.Runs(*out.Daemon) {
+				out.SupportsDaemon = true
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// registryRef is the reference to look up for the local image img found by
+// ref: ref itself when it names a repository, otherwise the image's first
+// repo digest or tag.
+func registryRef(ref string, img *domain.ImageDetails) string {
+	if !looksLikeImageID(ref) && !strings.HasPrefix(strings.TrimPrefix(img.ID, "sha256:"), ref) {
+		return ref
+	}
+	if len(img.RepoDigests) > 0 {
+		return img.RepoDigests[0]
+	}
+	for _, t := range img.RepoTags {
+		if t != "<none>:<none>" {
+			return t
+		}
+	}
+	return ""
+}
+
+func looksLikeImageID(ref string) bool {
+	return strings.HasPrefix(ref, "sha256:")
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockDistribution struct {
+	byRef  map[string]*domain.ImageDistribution
+	gotRef string
+}
+
+func (m *mockDistribution) DistributionInspect(ctx context.Context, ref string, cred *domain.RegistryCredential) (*domain.ImageDistribution, error) {
+	m.gotRef = ref
+	d, ok := m.byRef[ref]
+	if !ok {
+		return nil, domain.ErrUnavailable
+	}
+	return d, nil
+}
+
+func TestGetImagePlatforms_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"sha256:0123abcd": {
+			ID: "sha256:0123abcd", OS: "linux", Architecture: "arm64", Variant: "v8",
+			RepoTags: []string{"app:1.0"}, RepoDigests: []string{"app@sha256:index"},
+		},
+		"built:local": {ID: "sha256:beef", OS: "linux", Architecture: "amd64", RepoTags: []string{"built:local"}},
+	}}
+	dist := &mockDistribution{byRef: map[string]*domain.ImageDistribution{
+		"app@sha256:index": {MultiArch: true, Platforms: []domain.Platform{
+			{OS: "linux", Architecture: "amd64"},
+			{OS: "linux", Architecture: "arm64", Variant: "v8"},
+		}},
+		"redis:7": {Platforms: []domain.Platform{{OS: "linux", Architecture: "arm64"}}},
+	}}
+	daemon := &mockPlatforms{daemon: &domain.Platform{OS: "linux", Architecture: "amd64"}}
+	uc := NewGetImagePlatforms(inspector, dist, daemon, nil, log)
+	ctx := context.Background()
+
+	// An image ID is looked up in the registry by its repo digest.
+	out, err := uc.Execute(ctx, "sha256:0123abcd")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dist.gotRef != "app@sha256:index" || out.Local.Architecture != "arm64" || !out.Distribution.MultiArch || !out.SupportsDaemon {
+		t.Errorf("unexpected result: %+v", out)
+	}
+
+	// A local-only image still reports its own platform.
+	out, err = uc.Execute(ctx, "built:local")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Local == nil || out.Distribution != nil || out.DistributionError == "" {
+		t.Errorf("unexpected result: %+v", out)
+	}
+
+	// Not pulled: only the registry answers.
+	out, err = uc.Execute(ctx, "redis:7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Local != nil || out.SupportsDaemon {
+		t.Errorf("unexpected result: %+v", out)
+	}
+	if _, err := uc.Execute(ctx, "missing:1"); !errors.Is(err, domain.ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
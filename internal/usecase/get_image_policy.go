@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type GetImagePolicy struct {
+	repo domain.ImagePolicyRepository
+	log  *slog.Logger
+}
+
+func NewGetImagePolicy(repo domain.ImagePolicyRepository, log *slog.Logger) *GetImagePolicy {
+	return &GetImagePolicy{repo: repo, log: log}
+}
+
+func (uc *GetImagePolicy) Execute(ctx context.Context) (*domain.ImagePolicy, error) {
+	p, err := uc.repo.Get(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "load image policy failed", "error", err)
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type GetRegistryManifestInput struct {
+	Registry   string
+	Repository string
+	// Reference is a tag or a digest.
+	Reference string
+}
+
+// GetRegistryManifest resolves a tag or digest of a repository in a
+// configured registry.
+type GetRegistryManifest struct {
+	registries domain.RegistryRepository
+	creds      domain.RegistryCredentialRepository
+	client     domain.RegistryClient
+	log        *slog.Logger
+}
+
+func NewGetRegistryManifest(registries domain.RegistryRepository, creds domain.RegistryCredentialRepository, client domain.RegistryClient, log *slog.Logger) *GetRegistryManifest {
+	return &GetRegistryManifest{registries: registries, creds: creds, client: client, log: log}
+}
+
+func (uc *GetRegistryManifest) Execute(ctx context.Context, input GetRegistryManifestInput) (*domain.RegistryManifest, error) {
+	if !repoPattern.MatchString(input.Repository) {
+		return nil, invalidInput("invalid repository %q", input.Repository)
+	}
+	if !validRegistryReference(input.Reference) {
+		return nil, invalidInput("invalid tag or digest %q", input.Reference)
+	}
+	reg, err := lookupRegistry(ctx, uc.registries, uc.creds, input.Registry, uc.log)
+	if err != nil {
+		return nil, err
+	}
+	return uc.client.Manifest(ctx, reg, input.Repository, input.Reference)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/dockscope/dockscope/internal/domain"
 )
@@ -24,6 +25,22 @@ type ContainerMetricsEntry struct {
 	MemoryPercent   float64 `json:"memory_percent,omitempty"`
 }
 
+type CrashLoopEntry struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Restarts     int    `json:"restarts"`
+	LastExitCode int    `json:"last_exit_code"`
+	OOMKilled    bool   `json:"oom_killed"`
+}
+
+type HealthCounts struct {
+	Healthy   int `json:"healthy"`
+	Unhealthy int `json:"unhealthy"`
+	Starting  int `json:"starting"`
+	// None counts running containers without a healthcheck.
+	None int `json:"none"`
+}
+
 type GetSystemSummaryOutput struct {
 	ContainersTotal    int                     `json:"containers_total"`
 	ContainersRunning  int                     `json:"containers_running"`
@@ -35,6 +52,8 @@ type GetSystemSummaryOutput struct {
 	VolumesCount       int                     `json:"volumes_count"`
 	TopContainersByMemory []ContainerMemoryEntry `json:"top_containers_by_memory"`
 	ContainerMetrics   []ContainerMetricsEntry `json:"container_metrics"`
+	CrashLooping       []CrashLoopEntry        `json:"crash_looping"`
+	Health             HealthCounts            `json:"health"`
 }
 
 const topContainersByMemoryN = 10
@@ -45,6 +64,8 @@ type GetSystemSummary struct {
 	volumes    domain.VolumeRepository
 	stats      domain.ContainerStatsStreamer
 	sysInfo    domain.SystemInfoProvider
+	lifecycle  domain.LifecycleRepository
+	policy     CrashLoopPolicy
 	log        *slog.Logger
 }
 
@@ -54,6 +75,8 @@ func NewGetSystemSummary(
 	volumes domain.VolumeRepository,
 	stats domain.ContainerStatsStreamer,
 	sysInfo domain.SystemInfoProvider,
+	lifecycle domain.LifecycleRepository,
+	policy CrashLoopPolicy,
 	log *slog.Logger,
 ) *GetSystemSummary {
 	return &GetSystemSummary{
@@ -62,6 +85,8 @@ func NewGetSystemSummary(
 		volumes:    volumes,
 		stats:      stats,
 		sysInfo:    sysInfo,
+		lifecycle:  lifecycle,
+		policy:     policy,
 		log:        log,
 	}
 }
@@ -92,6 +117,7 @@ func (uc *GetSystemSummary) Execute(ctx context.Context) (*GetSystemSummaryOutpu
 		MemoryLimitBytes:  memTotal,
 		TopContainersByMemory: make([]ContainerMemoryEntry, 0, topContainersByMemoryN),
 		ContainerMetrics:  make([]ContainerMetricsEntry, 0),
+		CrashLooping:      uc.crashLooping(ctx, containers),
 	}
 
 	var running []*domain.Container
@@ -100,6 +126,16 @@ func (uc *GetSystemSummary) Execute(ctx context.Context) (*GetSystemSummaryOutpu
 		case "running":
 			out.ContainersRunning++
 			running = append(running, c)
+			switch c.Health {
+			case domain.HealthHealthy:
+				out.Health.Healthy++
+			case domain.HealthUnhealthy:
+				out.Health.Unhealthy++
+			case domain.HealthStarting:
+				out.Health.Starting++
+			default:
+				out.Health.None++
+			}
 		default:
 			out.ContainersStopped++
 		}
@@ -190,6 +226,36 @@ func (uc *GetSystemSummary) Execute(ctx context.Context) (*GetSystemSummaryOutpu
 	return out, nil
 }
 
+func (uc *GetSystemSummary) crashLooping(ctx context.Context, containers []*domain.Container) []CrashLoopEntry {
+	out := make([]CrashLoopEntry, 0)
+	all, err := uc.lifecycle.All(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "could not read lifecycle history", "error", err)
+		return out
+	}
+	now := time.Now()
+	for _, c := range containers {
+		history := all[c.ID]
+		if !uc.policy.CrashLooping(history, now) {
+			continue
+		}
+		entry := CrashLoopEntry{
+			ID:       c.ID,
+			Name:     containerDisplayName(c),
+			Restarts: uc.policy.RestartsWithin(history, now),
+		}
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].Action == domain.EventDie {
+				entry.LastExitCode = history[i].ExitCode
+				entry.OOMKilled = history[i].OOMKilled
+				break
+			}
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
 func containerDisplayName(c *domain.Container) string {
 	if len(c.Names) > 0 && c.Names[0] != "" {
 		name := c.Names[0]
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dockscope/dockscope/internal/domain"
 )
@@ -62,6 +63,31 @@ func (m *mockSysInfo) GetMemTotal(ctx context.Context) (uint64, error) {
 	return m.mem, m.err
 }
 
+type mockLifecycleRepo struct {
+	events map[string][]domain.LifecycleEvent
+}
+
+func (m *mockLifecycleRepo) Record(ctx context.Context, containerID string, ev domain.LifecycleEvent) error {
+	if m.events == nil {
+		m.events = make(map[string][]domain.LifecycleEvent)
+	}
+	m.events[containerID] = append(m.events[containerID], ev)
+	return nil
+}
+
+func (m *mockLifecycleRepo) History(ctx context.Context, containerID string) ([]domain.LifecycleEvent, error) {
+	return m.events[containerID], nil
+}
+
+func (m *mockLifecycleRepo) All(ctx context.Context) (map[string][]domain.LifecycleEvent, error) {
+	return m.events, nil
+}
+
+func (m *mockLifecycleRepo) Forget(ctx context.Context, containerID string) error {
+	delete(m.events, containerID)
+	return nil
+}
+
 func TestGetSystemSummary_Empty(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	uc := NewGetSystemSummary(
@@ -70,6 +96,8 @@ func TestGetSystemSummary_Empty(t *testing.T) {
 		&mockVolumeRepo{list: []*domain.Volume{}},
 		&mockStatsStreamer{},
 		&mockSysInfo{mem: 1024 * 1024 * 1024},
+		&mockLifecycleRepo{},
+		DefaultCrashLoopPolicy,
 		log,
 	)
 	ctx := context.Background()
@@ -92,7 +120,7 @@ func TestGetSystemSummary_Empty(t *testing.T) {
 func TestGetSystemSummary_Counts(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	containers := []*domain.Container{
-		{ID: "1", State: "running", Names: []string{"/a"}},
+		{ID: "1", State: "running", Names: []string{"/a"}, Health: domain.HealthUnhealthy},
 		{ID: "2", State: "exited", Names: []string{"/b"}},
 	}
 	images := []*domain.Image{{ID: "img1"}}
@@ -104,6 +132,8 @@ func TestGetSystemSummary_Counts(t *testing.T) {
 		&mockVolumeRepo{list: volumes},
 		&mockStatsStreamer{snapshot: &domain.ContainerMetrics{CPUPercentage: 1.5, MemoryUsage: 100}},
 		&mockSysInfo{mem: 2048},
+		&mockLifecycleRepo{},
+		DefaultCrashLoopPolicy,
 		log,
 	)
 	ctx := context.Background()
@@ -130,6 +160,9 @@ func TestGetSystemSummary_Counts(t *testing.T) {
 	if len(out.TopContainersByMemory) != 1 {
 		t.Errorf("expected 1 top by memory, got %d", len(out.TopContainersByMemory))
 	}
+	if out.Health != (HealthCounts{Unhealthy: 1}) {
+		t.Errorf("health counts: %+v", out.Health)
+	}
 }
 
 func TestGetSystemSummary_ContainerRepoError(t *testing.T) {
@@ -140,6 +173,8 @@ func TestGetSystemSummary_ContainerRepoError(t *testing.T) {
 		&mockVolumeRepo{list: nil},
 		&mockStatsStreamer{},
 		&mockSysInfo{},
+		&mockLifecycleRepo{},
+		DefaultCrashLoopPolicy,
 		log,
 	)
 	ctx := context.Background()
@@ -149,3 +184,41 @@ func TestGetSystemSummary_ContainerRepoError(t *testing.T) {
 		t.Errorf("expected DeadlineExceeded, got %v", err)
 	}
 }
+
+func TestGetSystemSummary_CrashLooping(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	now := time.Now()
+	lifecycle := &mockLifecycleRepo{}
+	for i := 0; i < 3; i++ {
+		at := now.Add(time.Duration(i-3) * time.Minute)
+		_ = lifecycle.Record(context.Background(), "1", domain.LifecycleEvent{Action: domain.EventDie, ExitCode: 137, OOMKilled: true, Time: at})
+		_ = lifecycle.Record(context.Background(), "1", domain.LifecycleEvent{Action: domain.EventStart, Time: at.Add(time.Second)})
+	}
+	_ = lifecycle.Record(context.Background(), "2", domain.LifecycleEvent{Action: domain.EventDie, ExitCode: 1, Time: now})
+
+	uc := NewGetSystemSummary(
+		&mockContainerRepo{list: []*domain.Container{
+			{ID: "1", State: "restarting", Names: []string{"/flaky"}},
+			{ID: "2", State: "exited", Names: []string{"/stopped"}},
+		}},
+		&mockImageRepo{},
+		&mockVolumeRepo{},
+		&mockStatsStreamer{},
+		&mockSysInfo{},
+		lifecycle,
+		CrashLoopPolicy{Restarts: 3, Window: 10 * time.Minute},
+		log,
+	)
+
+	out, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.CrashLooping) != 1 {
+		t.Fatalf("expected 1 crash-looping container, got %+v", out.CrashLooping)
+	}
+	got := out.CrashLooping[0]
+	if got.Name != "flaky" || got.Restarts != 3 || got.LastExitCode != 137 || !got.OOMKilled {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type GetVulnerabilityDBStatus struct {
+	db  domain.VulnerabilityDatabase
+	log *slog.Logger
+}
+
+func NewGetVulnerabilityDBStatus(db domain.VulnerabilityDatabase, log *slog.Logger) *GetVulnerabilityDBStatus {
+	return &GetVulnerabilityDBStatus{db: db, log: log}
+}
+
+func (uc *GetVulnerabilityDBStatus) Execute(ctx context.Context) *domain.VulnerabilityDBStatus {
+	return uc.db.Status()
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+)
+
+// imageCache keeps the results of expensive per-image analyses by image ID
+// (image content never changes), dropping the oldest beyond size, and lets
+// concurrent requests for the same image share one computation.
+type imageCache[T any] struct {
+	size int
+
+	mu       sync.Mutex
+	items    map[string]T
+	order    []string
+	inflight map[string]*imageCacheCall[T]
+}
+
+type imageCacheCall[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newImageCache[T any](size int) *imageCache[T] {
+	return &imageCache[T]{
+		size:     size,
+		items:    make(map[string]T),
+		inflight: make(map[string]*imageCacheCall[T]),
+	}
+}
+
+// get returns the cached value for id or computes it; failures are not
+// cached.
+func (c *imageCache[T]) get(ctx context.Context, id string, compute func() (T, error)) (T, error) {
+	c.mu.Lock()
+	if v, ok := c.items[id]; ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+	call, running := c.inflight[id]
+	if !running {
+		call = &imageCacheCall[T]{done: make(chan struct{})}
+		c.inflight[id] = call
+	}
+	c.mu.Unlock()
+
+	if running {
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+
+	call.value, call.err = compute()
+	c.mu.Lock()
+	delete(c.inflight, id)
+	if call.err == nil {
+		if len(c.order) >= c.size {
+			delete(c.items, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.items[id] = call.value
+		c.order = append(c.order, id)
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.value, call.err
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func isDangling(img *domain.Image) bool {
+	for _, t := range img.RepoTags {
+		if t != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// annotateImageUsage fills the usage fields of images: the containers created
+// from each image, whether it is dangling, and its shared and unique sizes
+// when layers is available.
+func annotateImageUsage(images []*domain.Image, containers []*domain.Container, layers map[string][]domain.LayerRef) {
+	byID := make(map[string]*domain.Image, len(images))
+	for _, img := range images {
+		img.Containers = []string{}
+		img.Dangling = isDangling(img)
+		byID[img.ID] = img
+	}
+	for _, c := range containers {
+		if img, ok := byID[c.ImageID]; ok {
+			img.Containers = append(img.Containers, c.ID)
+		}
+	}
+
+	if layers != nil {
+		users := make(map[string]int)
+		for _, ls := range layers {
+			for _, l := range ls {
+				users[l.ChainID]++
+			}
+		}
+		for _, img := range images {
+			ls, ok := layers[img.ID]
+			if !ok {
+				continue
+			}
+			img.SharedSize = 0
+			for _, l := range ls {
+				if users[l.ChainID] > 1 {
+					img.SharedSize += l.Size
+				}
+			}
+		}
+	}
+	for _, img := range images {
+		img.UniqueSize = img.Size
+		if img.SharedSize > 0 {
+			img.UniqueSize = max(img.Size-img.SharedSize, 0)
+		}
+	}
+}
+
+// reclaimableBytes is the space freed by removing all of candidates: the
+// layers none of the remaining images use, each counted once. Without layer
+// data it falls back to the candidates' unique sizes, which undercounts
+// layers shared only among the candidates.
+func reclaimableBytes(candidates, all []*domain.Image, layers map[string][]domain.LayerRef) int64 {
+	var total int64
+	if layers == nil {
+		for _, img := range candidates {
+			total += img.UniqueSize
+		}
+		return total
+	}
+	removed := make(map[string]bool, len(candidates))
+	for _, img := range candidates {
+		removed[img.ID] = true
+	}
+	kept := make(map[string]bool)
+	for _, img := range all {
+		if removed[img.ID] {
+			continue
+		}
+		for _, l := range layers[img.ID] {
+			kept[l.ChainID] = true
+		}
+	}
+	counted := make(map[string]bool)
+	for _, img := range candidates {
+		for _, l := range layers[img.ID] {
+			if !kept[l.ChainID] && !counted[l.ChainID] {
+				counted[l.ChainID] = true
+				total += l.Size
+			}
+		}
+	}
+	return total
+}
+
+// listImagesWithUsage lists images annotated with their usage. Layer data is
+// best effort: on failure sizes are left as the daemon reported them and
+// the returned layers map is nil.
+func listImagesWithUsage(ctx context.Context, images domain.ImageRepository, containers domain.ContainerRepository, index domain.ImageLayerIndex, log *slog.Logger) ([]*domain.Image, map[string][]domain.LayerRef, error) {
+	list, err := images.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	cs, err := containers.ListActive(ctx, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	layers, err := index.ImageLayers(ctx)
+	if err != nil {
+		log.WarnContext(ctx, "image layer lookup failed, shared sizes unavailable", "error", err)
+		layers = nil
+	}
+	annotateImageUsage(list, cs, layers)
+	return list, layers, nil
+}
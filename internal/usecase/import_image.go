@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// importChanges are the instructions the daemon accepts as --change.
+var importChanges = map[string]bool{
+	"CMD": true, "ENTRYPOINT": true, "ENV": true, "EXPOSE": true, "LABEL": true,
+	"ONBUILD": true, "STOPSIGNAL": true, "USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+type ImportImage struct {
+	archiver domain.ImageArchiver
+	log      *slog.Logger
+}
+
+func NewImportImage(archiver domain.ImageArchiver, log *slog.Logger) *ImportImage {
+	return &ImportImage{archiver: archiver, log: log}
+}
+
+// Execute creates a single-layer image from a rootfs tarball and returns its
+// ID.
+func (uc *ImportImage) Execute(ctx context.Context, rootfs io.Reader, opts domain.ImportOptions) (string, error) {
+	if rootfs == nil {
+		return "", invalidInput("missing rootfs tarball")
+	}
+	if opts.Ref != "" && !validImageReference(opts.Ref) {
+		return "", invalidInput("invalid image reference %q", opts.Ref)
+	}
+	for _, c := range opts.Changes {
+		word, _, _ := strings.Cut(strings.TrimSpace(c), " ")
+		if !importChanges[strings.ToUpper(word)] {
+			return "", invalidInput("unsupported change %q", c)
+		}
+	}
+	return uc.archiver.Import(ctx, rootfs, opts)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockArchiver struct {
+	imported *domain.ImportOptions
+	exported []string
+}
+
+func (m *mockArchiver) Export(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	m.exported = refs
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (m *mockArchiver) Load(ctx context.Context, archive io.Reader, progress func(domain.LayerProgress)) (*domain.LoadResult, error) {
+	return &domain.LoadResult{}, nil
+}
+
+func (m *mockArchiver) Import(ctx context.Context, rootfs io.Reader, opts domain.ImportOptions) (string, error) {
+	m.imported = &opts
+	return "sha256:imported", nil
+}
+
+func TestImportImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	archiver := &mockArchiver{}
+	uc := NewImportImage(archiver, log)
+	ctx := context.Background()
+
+	id, err := uc.Execute(ctx, strings.NewReader("tar"), domain.ImportOptions{
+		Ref:     "rootfs/base:1.0",
+		Changes: []string{`CMD ["/bin/sh"]`, "env PATH=/usr/bin"},
+	})
+	if err != nil || id != "sha256:imported" {
+		t.Fatalf("got %q, %v", id, err)
+	}
+	if archiver.imported == nil || len(archiver.imported.Changes) != 2 {
+		t.Errorf("changes not passed through: %+v", archiver.imported)
+	}
+
+	for _, opts := range []domain.ImportOptions{
+		{Ref: "Bad/Ref"},
+		{Changes: []string{"RUN rm -rf /"}},
+	} {
+		if _, err := uc.Execute(ctx, strings.NewReader("tar"), opts); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", opts, err)
+		}
+	}
+}
+
+func TestExportImages_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	archiver := &mockArchiver{}
+	uc := NewExportImages(archiver, log)
+
+	rc, err := uc.Execute(context.Background(), []string{"app:1", " app:1", "", "db:2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rc.Close()
+	if len(archiver.exported) != 2 || archiver.exported[0] != "app:1" || archiver.exported[1] != "db:2" {
+		t.Errorf("unexpected refs: %v", archiver.exported)
+	}
+	if _, err := uc.Execute(context.Background(), []string{" "}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// dockerConfigFile is the part of ~/.docker/config.json holding credentials.
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		Username      string `json:"username"`
+		Password      string `json:"password"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+type ImportRegistryCredentialsInput struct {
+	// Config is the content of a docker config.json; when empty, the file at
+	// the path given to NewImportRegistryCredentials is read.
+	Config []byte
+	// Overwrite replaces credentials already in the store.
+	Overwrite bool
+}
+
+// ImportRegistryCredentials copies the credentials of a docker config.json
+// into the store. Credentials kept by a credential helper (credsStore,
+// credHelpers) are not in the file and are reported as skipped.
+type ImportRegistryCredentials struct {
+	repo       domain.RegistryCredentialRepository
+	configPath string
+	log        *slog.Logger
+}
+
+func NewImportRegistryCredentials(repo domain.RegistryCredentialRepository, configPath string, log *slog.Logger) *ImportRegistryCredentials {
+	return &ImportRegistryCredentials{repo: repo, configPath: configPath, log: log}
+}
+
+func (uc *ImportRegistryCredentials) Execute(ctx context.Context, input ImportRegistryCredentialsInput) (*domain.CredentialImportResult, error) {
+	data := input.Config
+	if len(data) == 0 {
+		var err error
+		if data, err = os.ReadFile(uc.configPath); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("docker config %s: %w", uc.configPath, domain.ErrNotFound)
+			}
+			return nil, err
+		}
+	}
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, invalidInput("invalid docker config: %v", err)
+	}
+
+	res := &domain.CredentialImportResult{Imported: []string{}, Skipped: []domain.CredentialSkipped{}}
+	skip := func(registry, format string, args ...any) {
+		res.Skipped = append(res.Skipped, domain.CredentialSkipped{Registry: registry, Reason: fmt.Sprintf(format, args...)})
+	}
+	addrs := make([]string, 0, len(cfg.Auths))
+	for addr := range cfg.Auths {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		entry := cfg.Auths[addr]
+		host := domain.NormalizeRegistryHost(addr)
+		cred := domain.RegistryCredential{Registry: host, Username: entry.Username, Password: entry.Password, IdentityToken: entry.IdentityToken}
+		if entry.Auth != "" {
+			raw, err := base64.StdEncoding.DecodeString(entry.Auth)
+			user, pass, ok := strings.Cut(string(raw), ":")
+			if err != nil || !ok {
+				skip(host, "malformed auth field")
+				continue
+			}
+			cred.Username, cred.Password = user, pass
+		}
+		if validateRegistryCredential(&cred) != nil {
+			if helper := credentialHelper(&cfg, addr, host); helper != "" {
+				skip(host, "stored in credential helper %q", helper)
+			} else {
+				skip(host, "no credentials in the config file")
+			}
+			continue
+		}
+		if seen[host] {
+			skip(host, "duplicate entry %s", addr)
+			continue
+		}
+		seen[host] = true
+		if !input.Overwrite {
+			if _, err := uc.repo.Get(ctx, host); err == nil {
+				skip(host, "already stored")
+				continue
+			} else if !errors.Is(err, domain.ErrNotFound) {
+				return nil, err
+			}
+		}
+		if err := uc.repo.Save(ctx, &domain.StoredRegistryCredential{RegistryCredential: cred, UpdatedAt: time.Now().UTC()}); err != nil {
+			return nil, err
+		}
+		res.Imported = append(res.Imported, host)
+	}
+	for addr, helper := range cfg.CredHelpers {
+		if host := domain.NormalizeRegistryHost(addr); !seen[host] && !hasSkip(res, host) {
+			skip(host, "stored in credential helper %q", helper)
+		}
+	}
+	sort.Slice(res.Skipped, func(i, j int) bool { return res.Skipped[i].Registry < res.Skipped[j].Registry })
+	uc.log.InfoContext(ctx, "registry credentials imported", "imported", len(res.Imported), "skipped", len(res.Skipped))
+	return res, nil
+}
+
+func credentialHelper(cfg *dockerConfigFile, addr, host string) string {
+	for a, helper := range cfg.CredHelpers {
+		if a == addr || domain.NormalizeRegistryHost(a) == host {
+			return helper
+		}
+	}
+	return cfg.CredsStore
+}
+
+func hasSkip(res *domain.CredentialImportResult, host string) bool {
+	for _, s := range res.Skipped {
+		if s.Registry == host {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockCredentialRepo struct {
+	items map[string]*domain.StoredRegistryCredential
+}
+
+func (m *mockCredentialRepo) List(ctx context.Context) ([]*domain.StoredRegistryCredential, error) {
+	out := make([]*domain.StoredRegistryCredential, 0, len(m.items))
+	for _, c := range m.items {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (m *mockCredentialRepo) Get(ctx context.Context, registry string) (*domain.StoredRegistryCredential, error) {
+	c, ok := m.items[registry]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (m *mockCredentialRepo) Save(ctx context.Context, c *domain.StoredRegistryCredential) error {
+	m.items[c.Registry] = c
+	return nil
+}
+
+func (m *mockCredentialRepo) Delete(ctx context.Context, registry string) error {
+	if _, ok := m.items[registry]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(m.items, registry)
+	return nil
+}
+
+const testDockerConfig = `{
+	"auths": {
+		"https://index.docker.io/v1/": {"auth": "aHViOmh1YnB3"},
+		"registry.local:5000": {"auth": "Y2k6Y2lwdw=="},
+		"ghcr.io": {},
+		"gcr.io": {},
+		"broken.example.com": {"auth": "bm9jb2xvbg=="}
+	},
+	"credsStore": "desktop",
+	"credHelpers": {"gcr.io": "gcloud", "123.dkr.ecr.eu-west-1.amazonaws.com": "ecr-login"}
+}`
+
+func TestImportRegistryCredentials_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := &mockCredentialRepo{items: map[string]*domain.StoredRegistryCredential{
+		"registry.local:5000": {RegistryCredential: domain.RegistryCredential{Registry: "registry.local:5000", Username: "old", Password: "old"}},
+	}}
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(testDockerConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewImportRegistryCredentials(repo, path, log)
+
+	res, err := uc.Execute(context.Background(), ImportRegistryCredentialsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(res.Imported, []string{"docker.io"}) {
+		t.Errorf("imported = %v", res.Imported)
+	}
+	reasons := make(map[string]string)
+	for _, s := range res.Skipped {
+		reasons[s.Registry] = s.Reason
+	}
+	want := map[string]string{
+		"registry.local:5000":                 "already stored",
+		"ghcr.io":                             `stored in credential helper "desktop"`,
+		"gcr.io":                              `stored in credential helper "gcloud"`,
+		"123.dkr.ecr.eu-west-1.amazonaws.com": `stored in credential helper "ecr-login"`,
+		"broken.example.com":                  "malformed auth field",
+	}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("skipped = %v", reasons)
+	}
+	if hub := repo.items["docker.io"]; hub == nil || hub.Username != "hub" || hub.Password != "hubpw" {
+		t.Errorf("docker.io credential = %+v", hub)
+	}
+
+	// An uploaded config with overwrite replaces the stored entry.
+	res, err = uc.Execute(context.Background(), ImportRegistryCredentialsInput{Config: []byte(testDockerConfig), Overwrite: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["registry.local:5000"]; got.Username != "ci" || got.Password != "cipw" {
+		t.Errorf("registry.local credential = %+v", got)
+	}
+
+	if _, err := uc.Execute(context.Background(), ImportRegistryCredentialsInput{Config: []byte("{")}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+	missing := NewImportRegistryCredentials(repo, filepath.Join(t.TempDir(), "nope.json"), log)
+	if _, err := missing.Execute(context.Background(), ImportRegistryCredentialsInput{}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSaveRegistryCredential_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := &mockCredentialRepo{items: map[string]*domain.StoredRegistryCredential{}}
+	uc := NewSaveRegistryCredential(repo, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, domain.RegistryCredential{Registry: "https://Registry.Local:5000/", Username: "ci", Password: "pw"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Registry != "registry.local:5000" || out.Password != domain.RedactedSecret {
+		t.Errorf("unexpected output: %+v", out)
+	}
+	if _, err := uc.Execute(ctx, domain.RegistryCredential{Registry: "registry.local:5000", Username: "ci2", Password: domain.RedactedSecret}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["registry.local:5000"]; got.Username != "ci2" || got.Password != "pw" {
+		t.Errorf("stored = %+v", got)
+	}
+	if _, err := uc.Execute(ctx, domain.RegistryCredential{Registry: "ghcr.io", Username: "x", Password: domain.RedactedSecret}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for a redacted password without a stored one, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultAlertsLimit = 100
+
+type ListAlerts struct {
+	repo domain.AlertRepository
+	log  *slog.Logger
+}
+
+func NewListAlerts(repo domain.AlertRepository, log *slog.Logger) *ListAlerts {
+	return &ListAlerts{repo: repo, log: log}
+}
+
+func (uc *ListAlerts) Execute(ctx context.Context, limit int) ([]*domain.Alert, error) {
+	if limit <= 0 {
+		limit = defaultAlertsLimit
+	}
+	list, err := uc.repo.List(ctx, limit)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list alerts failed", "error", err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultAutoHealActionsLimit = 100
+
+type ListAutoHealActions struct {
+	repo domain.AutoHealAuditRepository
+	log  *slog.Logger
+}
+
+func NewListAutoHealActions(repo domain.AutoHealAuditRepository, log *slog.Logger) *ListAutoHealActions {
+	return &ListAutoHealActions{repo: repo, log: log}
+}
+
+func (uc *ListAutoHealActions) Execute(ctx context.Context, limit int) ([]*domain.AutoHealAction, error) {
+	if limit <= 0 {
+		limit = defaultAutoHealActionsLimit
+	}
+	list, err := uc.repo.List(ctx, limit)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list auto-heal actions failed", "error", err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// ListBuilds returns the tracked builds, newest first, without their output.
+type ListBuilds struct {
+	jobs *BuildJobs
+	log  *slog.Logger
+}
+
+func NewListBuilds(jobs *BuildJobs, log *slog.Logger) *ListBuilds {
+	return &ListBuilds{jobs: jobs, log: log}
+}
+
+func (uc *ListBuilds) Execute(ctx context.Context) ([]*domain.BuildJob, error) {
+	return uc.jobs.list(), nil
+}
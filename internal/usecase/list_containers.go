@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/dockscope/dockscope/internal/domain"
@@ -12,12 +13,14 @@ type ListContainersInput struct {
 }
 
 type ListContainers struct {
-	repo domain.ContainerRepository
-	log  *slog.Logger
+	repo      domain.ContainerRepository
+	platforms domain.ImagePlatformIndex
+	daemon    domain.DaemonPlatformProvider
+	log       *slog.Logger
 }
 
-func NewListContainers(repo domain.ContainerRepository, log *slog.Logger) *ListContainers {
-	return &ListContainers{repo: repo, log: log}
+func NewListContainers(repo domain.ContainerRepository, platforms domain.ImagePlatformIndex, daemon domain.DaemonPlatformProvider, log *slog.Logger) *ListContainers {
+	return &ListContainers{repo: repo, platforms: platforms, daemon: daemon, log: log}
 }
 
 func (uc *ListContainers) Execute(ctx context.Context, input ListContainersInput) ([]*domain.Container, error) {
@@ -26,6 +29,32 @@ func (uc *ListContainers) Execute(ctx context.Context, input ListContainersInput
 		uc.log.ErrorContext(ctx, "list containers use case failed", "error", err)
 		return nil, err
 	}
+	uc.annotatePlatforms(ctx, list)
 	uc.log.DebugContext(ctx, "list containers ok", "count", len(list))
 	return list, nil
 }
+
+// annotatePlatforms sets each container's image platform and warns about
+// the ones the daemon can only run emulated. It is best effort: the list is
+// returned without platforms when they cannot be looked up.
+func (uc *ListContainers) annotatePlatforms(ctx context.Context, list []*domain.Container) {
+	platforms, err := uc.platforms.ImagePlatforms(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "image platform lookup failed", "error", err)
+		return
+	}
+	daemon, err := uc.daemon.DaemonPlatform(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "daemon platform lookup failed", "error", err)
+	}
+	for _, c := range list {
+		p, ok := platforms[c.ImageID]
+		if !ok {
+			continue
+		}
+		c.Platform = &p
+		if daemon != nil && !p.Runs(*daemon) {
+			c.PlatformWarning = fmt.Sprintf("image platform %s differs from the daemon's %s; the container runs emulated", p, daemon)
+		}
+	}
+}
@@ -19,6 +19,23 @@ func (m *mockContainerRepository) ListActive(ctx context.Context, all bool) ([]*
 	return m.list, m.err
 }
 
+type mockPlatforms struct {
+	images map[string]domain.Platform
+	daemon *domain.Platform
+	err    error
+}
+
+func (m *mockPlatforms) ImagePlatforms(ctx context.Context) (map[string]domain.Platform, error) {
+	return m.images, m.err
+}
+
+func (m *mockPlatforms) DaemonPlatform(ctx context.Context) (*domain.Platform, error) {
+	if m.daemon == nil {
+		return nil, errors.New("daemon unreachable")
+	}
+	return m.daemon, nil
+}
+
 func TestListContainers_Execute(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
 	ctx := context.Background()
@@ -29,7 +46,7 @@ func TestListContainers_Execute(t *testing.T) {
 			{ID: "b", State: "exited"},
 		}
 		repo := &mockContainerRepository{list: want}
-		uc := NewListContainers(repo, log)
+		uc := NewListContainers(repo, &mockPlatforms{}, &mockPlatforms{}, log)
 
 		got, err := uc.Execute(ctx, ListContainersInput{All: true})
 		if err != nil {
@@ -46,11 +63,41 @@ func TestListContainers_Execute(t *testing.T) {
 	t.Run("propagates repo error", func(t *testing.T) {
 		repoErr := errors.New("repo failed")
 		repo := &mockContainerRepository{err: repoErr}
-		uc := NewListContainers(repo, log)
+		uc := NewListContainers(repo, &mockPlatforms{}, &mockPlatforms{}, log)
 
 		_, err := uc.Execute(ctx, ListContainersInput{All: false})
 		if err != repoErr {
 			t.Errorf("got err %v", err)
 		}
 	})
+
+	t.Run("warns about emulated platforms", func(t *testing.T) {
+		repo := &mockContainerRepository{list: []*domain.Container{
+			{ID: "native", ImageID: "sha256:amd"},
+			{ID: "emulated", ImageID: "sha256:arm"},
+			{ID: "gone", ImageID: "sha256:removed"},
+		}}
+		platforms := &mockPlatforms{
+			images: map[string]domain.Platform{
+				"sha256:amd": {OS: "linux", Architecture: "amd64"},
+				"sha256:arm": {OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+			daemon: &domain.Platform{OS: "linux", Architecture: "amd64"},
+		}
+		uc := NewListContainers(repo, platforms, platforms, log)
+
+		got, err := uc.Execute(ctx, ListContainersInput{All: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got[0].Platform == nil || got[0].PlatformWarning != "" {
+			t.Errorf("native container: %+v, warning %q", got[0].Platform, got[0].PlatformWarning)
+		}
+		if got[1].Platform.String() != "linux/arm64/v8" || got[1].PlatformWarning == "" {
+			t.Errorf("emulated container: %+v, warning %q", got[1].Platform, got[1].PlatformWarning)
+		}
+		if got[2].Platform != nil {
+			t.Errorf("unknown image should have no platform, got %+v", got[2].Platform)
+		}
+	})
 }
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultImagePolicyDecisionsLimit = 100
+
+type ListImagePolicyDecisions struct {
+	repo domain.ImagePolicyDecisionRepository
+	log  *slog.Logger
+}
+
+func NewListImagePolicyDecisions(repo domain.ImagePolicyDecisionRepository, log *slog.Logger) *ListImagePolicyDecisions {
+	return &ListImagePolicyDecisions{repo: repo, log: log}
+}
+
+func (uc *ListImagePolicyDecisions) Execute(ctx context.Context, limit int) ([]*domain.ImagePolicyDecision, error) {
+	if limit <= 0 {
+		limit = defaultImagePolicyDecisionsLimit
+	}
+	list, err := uc.repo.List(ctx, limit)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list image policy decisions failed", "error", err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -8,20 +8,32 @@ import (
 )
 
 type ListImages struct {
-	repo domain.ImageRepository
-	log  *slog.Logger
+	repo       domain.ImageRepository
+	containers domain.ContainerRepository
+	layers     domain.ImageLayerIndex
+	platforms  domain.ImagePlatformIndex
+	log        *slog.Logger
 }
 
-func NewListImages(repo domain.ImageRepository, log *slog.Logger) *ListImages {
-	return &ListImages{repo: repo, log: log}
+func NewListImages(repo domain.ImageRepository, containers domain.ContainerRepository, layers domain.ImageLayerIndex, platforms domain.ImagePlatformIndex, log *slog.Logger) *ListImages {
+	return &ListImages{repo: repo, containers: containers, layers: layers, platforms: platforms, log: log}
 }
 
 func (uc *ListImages) Execute(ctx context.Context) ([]*domain.Image, error) {
-	list, err := uc.repo.List(ctx)
+	list, _, err := listImagesWithUsage(ctx, uc.repo, uc.containers, uc.layers, uc.log)
 	if err != nil {
 		uc.log.ErrorContext(ctx, "list images use case failed", "error", err)
 		return nil, err
 	}
+	if platforms, err := uc.platforms.ImagePlatforms(ctx); err != nil {
+		uc.log.WarnContext(ctx, "image platform lookup failed", "error", err)
+	} else {
+		for _, img := range list {
+			if p, ok := platforms[img.ID]; ok {
+				img.Platform = &p
+			}
+		}
+	}
 	uc.log.DebugContext(ctx, "list images ok", "count", len(list))
 	return list, nil
 }
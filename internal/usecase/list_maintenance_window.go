@@ -0,0 +1,45 @@
+// The file name is singular on purpose: a "_windows.go" suffix would make it
+// a Windows-only file.
+
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type MaintenanceWindowEntry struct {
+	*domain.MaintenanceWindow
+	Active  bool      `json:"active"`
+	NextRun time.Time `json:"next_run,omitempty"`
+}
+
+type ListMaintenanceWindows struct {
+	repo domain.MaintenanceWindowRepository
+	log  *slog.Logger
+}
+
+func NewListMaintenanceWindows(repo domain.MaintenanceWindowRepository, log *slog.Logger) *ListMaintenanceWindows {
+	return &ListMaintenanceWindows{repo: repo, log: log}
+}
+
+func (uc *ListMaintenanceWindows) Execute(ctx context.Context) ([]MaintenanceWindowEntry, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list maintenance windows failed", "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	out := make([]MaintenanceWindowEntry, 0, len(list))
+	for _, w := range list {
+		e := MaintenanceWindowEntry{MaintenanceWindow: w, Active: w.ActiveAt(now)}
+		if sched, err := domain.ParseCron(w.Schedule); err == nil {
+			e.NextRun = sched.Next(now)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
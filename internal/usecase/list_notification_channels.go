@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListNotificationChannels struct {
+	repo domain.NotificationChannelRepository
+	log  *slog.Logger
+}
+
+func NewListNotificationChannels(repo domain.NotificationChannelRepository, log *slog.Logger) *ListNotificationChannels {
+	return &ListNotificationChannels{repo: repo, log: log}
+}
+
+func (uc *ListNotificationChannels) Execute(ctx context.Context) ([]*domain.NotificationChannel, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list notification channels failed", "error", err)
+		return nil, err
+	}
+	out := make([]*domain.NotificationChannel, 0, len(list))
+	for _, ch := range list {
+		out = append(out, ch.Redacted())
+	}
+	return out, nil
+}
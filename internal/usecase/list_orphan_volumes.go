@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListOrphanVolumes struct {
+	repo       domain.VolumeRepository
+	usage      domain.VolumeUsageProvider
+	containers domain.ContainerRepository
+	log        *slog.Logger
+}
+
+func NewListOrphanVolumes(repo domain.VolumeRepository, usage domain.VolumeUsageProvider, containers domain.ContainerRepository, log *slog.Logger) *ListOrphanVolumes {
+	return &ListOrphanVolumes{repo: repo, usage: usage, containers: containers, log: log}
+}
+
+// Execute lists the orphan volumes, largest first.
+func (uc *ListOrphanVolumes) Execute(ctx context.Context) (*domain.VolumeOrphanReport, error) {
+	list, err := listVolumesWithUsage(ctx, uc.repo, uc.usage, uc.containers, true, uc.log)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list orphan volumes failed", "error", err)
+		return nil, err
+	}
+	report := &domain.VolumeOrphanReport{Volumes: []*domain.Volume{}}
+	for _, v := range list {
+		if !isOrphanVolume(v) {
+			continue
+		}
+		report.Volumes = append(report.Volumes, v)
+		if v.UsageData != nil && v.UsageData.Size > 0 {
+			report.ReclaimableBytes += v.UsageData.Size
+		}
+	}
+	sort.SliceStable(report.Volumes, func(i, j int) bool {
+		return volumeSize(report.Volumes[i]) > volumeSize(report.Volumes[j])
+	})
+	return report, nil
+}
+
+func volumeSize(v *domain.Volume) int64 {
+	if v.UsageData == nil {
+		return -1
+	}
+	return v.UsageData.Size
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockVolumeUsage struct {
+	usage map[string]domain.VolumeUsageData
+	err   error
+	calls int
+}
+
+func (m *mockVolumeUsage) VolumeUsage(ctx context.Context) (map[string]domain.VolumeUsageData, error) {
+	m.calls++
+	return m.usage, m.err
+}
+
+func volumeFixture() (*mockVolumeRepo, *mockContainerRepo) {
+	volumes := &mockVolumeRepo{list: []*domain.Volume{
+		{Name: "pgdata", Driver: "local"},
+		{Name: "cache", Driver: "local"},
+		{Name: "old-logs", Driver: "local"},
+		{Name: "nfs-share", Driver: "nfs"},
+	}}
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "db", Mounts: []domain.Mount{{Type: "volume", Name: "pgdata", Target: "/var/lib/postgresql/data"}}},
+		// A stopped container still holds its volumes.
+		{ID: "stopped", State: "exited", Mounts: []domain.Mount{
+			{Type: "volume", Name: "pgdata"},
+			{Type: "bind", Source: "/srv/cache", Target: "/cache"},
+		}},
+	}}
+	return volumes, containers
+}
+
+func TestListOrphanVolumes_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes, containers := volumeFixture()
+	usage := &mockVolumeUsage{usage: map[string]domain.VolumeUsageData{
+		"pgdata":    {Size: 5000, RefCount: 2},
+		"cache":     {Size: 100, RefCount: 0},
+		"old-logs":  {Size: 700, RefCount: 0},
+		"nfs-share": {Size: -1, RefCount: 0},
+	}}
+
+	report, err := NewListOrphanVolumes(volumes, usage, containers, log).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, v := range report.Volumes {
+		names = append(names, v.Name)
+	}
+	if len(names) != 3 || names[0] != "old-logs" || names[1] != "cache" || names[2] != "nfs-share" {
+		t.Errorf("orphans = %v", names)
+	}
+	if report.ReclaimableBytes != 800 {
+		t.Errorf("reclaimable = %d, want 800", report.ReclaimableBytes)
+	}
+}
+
+func TestListVolumes_WithoutDiskUsage(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes, containers := volumeFixture()
+
+	list, err := NewListVolumes(volumes, &mockVolumeUsage{err: errors.New("df timed out")}, containers, log).Execute(context.Background(), ListVolumesInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := list[0].Containers; len(got) != 2 || got[0] != "db" || got[1] != "stopped" {
+		t.Errorf("pgdata containers = %v", got)
+	}
+	if list[0].UsageData != nil || len(list[1].Containers) != 0 {
+		t.Errorf("unexpected volume: %+v", list[1])
+	}
+}
+
+func TestListVolumes_SkipSize(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	volumes, containers := volumeFixture()
+	usage := &mockVolumeUsage{usage: map[string]domain.VolumeUsageData{"pgdata": {Size: 100, RefCount: 2}}}
+	uc := NewListVolumes(volumes, usage, containers, log)
+
+	list, err := uc.Execute(context.Background(), ListVolumesInput{SkipSize: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.calls != 0 || list[0].UsageData != nil {
+		t.Errorf("disk usage computed with SkipSize: %d calls, %+v", usage.calls, list[0].UsageData)
+	}
+
+	list, err = uc.Execute(context.Background(), ListVolumesInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if usage.calls != 1 || list[0].UsageData == nil || list[0].UsageData.Size != 100 {
+		t.Errorf("size not reported: %d calls, %+v", usage.calls, list[0].UsageData)
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListRegistries struct {
+	repo domain.RegistryRepository
+	log  *slog.Logger
+}
+
+func NewListRegistries(repo domain.RegistryRepository, log *slog.Logger) *ListRegistries {
+	return &ListRegistries{repo: repo, log: log}
+}
+
+func (uc *ListRegistries) Execute(ctx context.Context) ([]*domain.Registry, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list registries failed", "error", err)
+		return nil, err
+	}
+	out := make([]*domain.Registry, 0, len(list))
+	for _, r := range list {
+		out = append(out, r.Redacted())
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListRegistryCredentials struct {
+	repo domain.RegistryCredentialRepository
+	log  *slog.Logger
+}
+
+func NewListRegistryCredentials(repo domain.RegistryCredentialRepository, log *slog.Logger) *ListRegistryCredentials {
+	return &ListRegistryCredentials{repo: repo, log: log}
+}
+
+func (uc *ListRegistryCredentials) Execute(ctx context.Context) ([]*domain.StoredRegistryCredential, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list registry credentials failed", "error", err)
+		return nil, err
+	}
+	out := make([]*domain.StoredRegistryCredential, 0, len(list))
+	for _, c := range list {
+		out = append(out, c.Redacted())
+	}
+	return out, nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListRegistryRepositoriesInput struct {
+	Registry string
+	// Limit is the page size, capped at maxRegistryPageSize; 0 lets the
+	// registry choose. Last is the Next of the previous page.
+	Limit int
+	Last  string
+}
+
+// ListRegistryRepositories pages through the catalog of a configured
+// registry.
+type ListRegistryRepositories struct {
+	registries domain.RegistryRepository
+	creds      domain.RegistryCredentialRepository
+	client     domain.RegistryClient
+	log        *slog.Logger
+}
+
+func NewListRegistryRepositories(registries domain.RegistryRepository, creds domain.RegistryCredentialRepository, client domain.RegistryClient, log *slog.Logger) *ListRegistryRepositories {
+	return &ListRegistryRepositories{registries: registries, creds: creds, client: client, log: log}
+}
+
+func (uc *ListRegistryRepositories) Execute(ctx context.Context, input ListRegistryRepositoriesInput) (*domain.RegistryRepositories, error) {
+	reg, err := lookupRegistry(ctx, uc.registries, uc.creds, input.Registry, uc.log)
+	if err != nil {
+		return nil, err
+	}
+	return uc.client.Repositories(ctx, reg, pageSize(input.Limit), input.Last)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListRegistryTagsInput struct {
+	Registry   string
+	Repository string
+	// Limit and Last page the tags as in ListRegistryRepositoriesInput.
+	Limit int
+	Last  string
+}
+
+// ListRegistryTags pages through the tags of a repository in a configured
+// registry.
+type ListRegistryTags struct {
+	registries domain.RegistryRepository
+	creds      domain.RegistryCredentialRepository
+	client     domain.RegistryClient
+	log        *slog.Logger
+}
+
+func NewListRegistryTags(registries domain.RegistryRepository, creds domain.RegistryCredentialRepository, client domain.RegistryClient, log *slog.Logger) *ListRegistryTags {
+	return &ListRegistryTags{registries: registries, creds: creds, client: client, log: log}
+}
+
+func (uc *ListRegistryTags) Execute(ctx context.Context, input ListRegistryTagsInput) (*domain.RegistryTags, error) {
+	if !repoPattern.MatchString(input.Repository) {
+		return nil, invalidInput("invalid repository %q", input.Repository)
+	}
+	reg, err := lookupRegistry(ctx, uc.registries, uc.creds, input.Registry, uc.log)
+	if err != nil {
+		return nil, err
+	}
+	return uc.client.Tags(ctx, reg, input.Repository, pageSize(input.Limit), input.Last)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type RetentionPolicyEntry struct {
+	*domain.RetentionPolicy
+	NextRun time.Time `json:"next_run,omitempty"`
+}
+
+type ListRetentionPolicies struct {
+	repo domain.RetentionPolicyRepository
+	log  *slog.Logger
+}
+
+func NewListRetentionPolicies(repo domain.RetentionPolicyRepository, log *slog.Logger) *ListRetentionPolicies {
+	return &ListRetentionPolicies{repo: repo, log: log}
+}
+
+func (uc *ListRetentionPolicies) Execute(ctx context.Context) ([]RetentionPolicyEntry, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list retention policies failed", "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	out := make([]RetentionPolicyEntry, 0, len(list))
+	for _, p := range list {
+		e := RetentionPolicyEntry{RetentionPolicy: p}
+		if p.Enabled && p.Schedule != "" {
+			if sched, err := domain.ParseCron(p.Schedule); err == nil {
+				e.NextRun = sched.Next(now)
+			}
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const defaultRetentionRunsLimit = 50
+
+type ListRetentionRuns struct {
+	repo domain.RetentionRunRepository
+	log  *slog.Logger
+}
+
+func NewListRetentionRuns(repo domain.RetentionRunRepository, log *slog.Logger) *ListRetentionRuns {
+	return &ListRetentionRuns{repo: repo, log: log}
+}
+
+func (uc *ListRetentionRuns) Execute(ctx context.Context, limit int) ([]*domain.RetentionRun, error) {
+	if limit <= 0 {
+		limit = defaultRetentionRunsLimit
+	}
+	list, err := uc.repo.List(ctx, limit)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list retention runs failed", "error", err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SilenceEntry struct {
+	*domain.Silence
+	Active bool `json:"active"`
+}
+
+type ListSilences struct {
+	repo domain.SilenceRepository
+	log  *slog.Logger
+}
+
+func NewListSilences(repo domain.SilenceRepository, log *slog.Logger) *ListSilences {
+	return &ListSilences{repo: repo, log: log}
+}
+
+func (uc *ListSilences) Execute(ctx context.Context) ([]SilenceEntry, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list silences failed", "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	out := make([]SilenceEntry, 0, len(list))
+	for _, s := range list {
+		out = append(out, SilenceEntry{Silence: s, Active: s.ActiveAt(now)})
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type UnusedImagesReport struct {
+	// Dangling are untagged images no container uses; Unused are tagged
+	// ones. Images in use are in neither list.
+	Dangling                 []*domain.Image `json:"dangling"`
+	Unused                   []*domain.Image `json:"unused"`
+	DanglingReclaimableBytes int64           `json:"dangling_reclaimable_bytes"`
+	ReclaimableBytes         int64           `json:"reclaimable_bytes"`
+}
+
+type ListUnusedImages struct {
+	repo       domain.ImageRepository
+	containers domain.ContainerRepository
+	layers     domain.ImageLayerIndex
+	log        *slog.Logger
+}
+
+func NewListUnusedImages(repo domain.ImageRepository, containers domain.ContainerRepository, layers domain.ImageLayerIndex, log *slog.Logger) *ListUnusedImages {
+	return &ListUnusedImages{repo: repo, containers: containers, layers: layers, log: log}
+}
+
+func (uc *ListUnusedImages) Execute(ctx context.Context) (*UnusedImagesReport, error) {
+	list, layers, err := listImagesWithUsage(ctx, uc.repo, uc.containers, uc.layers, uc.log)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list unused images failed", "error", err)
+		return nil, err
+	}
+	out := &UnusedImagesReport{Dangling: []*domain.Image{}, Unused: []*domain.Image{}}
+	for _, img := range list {
+		if len(img.Containers) > 0 {
+			continue
+		}
+		if img.Dangling {
+			out.Dangling = append(out.Dangling, img)
+		} else {
+			out.Unused = append(out.Unused, img)
+		}
+	}
+	out.DanglingReclaimableBytes = reclaimableBytes(out.Dangling, list, layers)
+	out.ReclaimableBytes = reclaimableBytes(append(append([]*domain.Image{}, out.Dangling...), out.Unused...), list, layers)
+	return out, nil
+}
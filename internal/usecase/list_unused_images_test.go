@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockLayerIndex struct {
+	layers map[string][]domain.LayerRef
+	err    error
+}
+
+func (m *mockLayerIndex) ImageLayers(ctx context.Context) (map[string][]domain.LayerRef, error) {
+	return m.layers, m.err
+}
+
+func unusedImagesFixture() (*mockImageRepo, *mockContainerRepo, *mockLayerIndex) {
+	images := &mockImageRepo{list: []*domain.Image{
+		{ID: "web", RepoTags: []string{"web:1"}, Size: 150, SharedSize: -1},
+		{ID: "old", RepoTags: []string{"web:0"}, Size: 130, SharedSize: -1},
+		{ID: "none", RepoTags: []string{"<none>:<none>"}, Size: 120, SharedSize: -1},
+	}}
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "c1", ImageID: "web"},
+		{ID: "c2", ImageID: "web"},
+	}}
+	// web and old share the base; old and none share a layer only
+	// between themselves.
+	index := &mockLayerIndex{layers: map[string][]domain.LayerRef{
+		"web":  {{ChainID: "base", Size: 100}, {ChainID: "web1", Size: 50}},
+		"old":  {{ChainID: "base", Size: 100}, {ChainID: "deps", Size: 20}, {ChainID: "web0", Size: 10}},
+		"none": {{ChainID: "alpine", Size: 100}, {ChainID: "deps", Size: 20}},
+	}}
+	return images, containers, index
+}
+
+func TestListImages_Usage(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	images, containers, index := unusedImagesFixture()
+
+	list, err := NewListImages(images, containers, index, &mockPlatforms{err: errors.New("inspect failed")}, log).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	web, old, none := list[0], list[1], list[2]
+	if len(web.Containers) != 2 || len(old.Containers) != 0 {
+		t.Errorf("containers: web=%v old=%v", web.Containers, old.Containers)
+	}
+	if !none.Dangling || web.Dangling {
+		t.Errorf("dangling: web=%v none=%v", web.Dangling, none.Dangling)
+	}
+	if old.SharedSize != 120 || old.UniqueSize != 10 || none.UniqueSize != 100 {
+		t.Errorf("sizes: old shared=%d unique=%d, none unique=%d", old.SharedSize, old.UniqueSize, none.UniqueSize)
+	}
+}
+
+func TestListUnusedImages_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	images, containers, index := unusedImagesFixture()
+
+	out, err := NewListUnusedImages(images, containers, index, log).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Dangling) != 1 || len(out.Unused) != 1 || out.Unused[0].ID != "old" {
+		t.Errorf("unexpected report: %+v", out)
+	}
+	// The base layer stays because web is in use; deps is only freed when
+	// both old and none go.
+	if out.DanglingReclaimableBytes != 100 || out.ReclaimableBytes != 130 {
+		t.Errorf("reclaimable: dangling=%d total=%d", out.DanglingReclaimableBytes, out.ReclaimableBytes)
+	}
+
+	// Without layer data the unique sizes are summed, missing deps.
+	images, containers, _ = unusedImagesFixture()
+	out, err = NewListUnusedImages(images, containers, &mockLayerIndex{err: errors.New("boom")}, log).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ReclaimableBytes != 250 {
+		t.Errorf("fallback reclaimable = %d, want 250 (daemon sizes)", out.ReclaimableBytes)
+	}
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type VolumeBackupScheduleEntry struct {
+	*domain.VolumeBackupSchedule
+	NextRun time.Time `json:"next_run,omitempty"`
+}
+
+type ListVolumeBackupSchedules struct {
+	repo domain.VolumeBackupScheduleRepository
+	log  *slog.Logger
+}
+
+func NewListVolumeBackupSchedules(repo domain.VolumeBackupScheduleRepository, log *slog.Logger) *ListVolumeBackupSchedules {
+	return &ListVolumeBackupSchedules{repo: repo, log: log}
+}
+
+func (uc *ListVolumeBackupSchedules) Execute(ctx context.Context) ([]VolumeBackupScheduleEntry, error) {
+	list, err := uc.repo.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list volume backup schedules failed", "error", err)
+		return nil, err
+	}
+	now := time.Now()
+	out := make([]VolumeBackupScheduleEntry, 0, len(list))
+	for _, s := range list {
+		e := VolumeBackupScheduleEntry{VolumeBackupSchedule: s}
+		if s.Enabled {
+			if sched, err := domain.ParseCron(s.Schedule); err == nil {
+				e.NextRun = sched.Next(now)
+			}
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
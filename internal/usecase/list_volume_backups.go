@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ListVolumeBackups struct {
+	backups domain.VolumeBackupRepository
+	log     *slog.Logger
+}
+
+func NewListVolumeBackups(backups domain.VolumeBackupRepository, log *slog.Logger) *ListVolumeBackups {
+	return &ListVolumeBackups{backups: backups, log: log}
+}
+
+// Execute lists the backups of volume, or of every volume when it is empty,
+// newest first.
+func (uc *ListVolumeBackups) Execute(ctx context.Context, volume string) ([]*domain.VolumeBackup, error) {
+	list, err := uc.backups.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list volume backups failed", "error", err)
+		return nil, err
+	}
+	if volume == "" {
+		return list, nil
+	}
+	out := []*domain.VolumeBackup{}
+	for _, b := range list {
+		if b.Volume == volume {
+			out = append(out, b)
+		}
+	}
+	return out, nil
+}
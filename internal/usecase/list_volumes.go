@@ -7,17 +7,25 @@ import (
 	"github.com/dockscope/dockscope/internal/domain"
 )
 
+type ListVolumesInput struct {
+	// SkipSize leaves UsageData empty. Sizes make the daemon walk every
+	// volume, which is slow on large ones.
+	SkipSize bool
+}
+
 type ListVolumes struct {
-	repo domain.VolumeRepository
-	log  *slog.Logger
+	repo       domain.VolumeRepository
+	usage      domain.VolumeUsageProvider
+	containers domain.ContainerRepository
+	log        *slog.Logger
 }
 
-func NewListVolumes(repo domain.VolumeRepository, log *slog.Logger) *ListVolumes {
-	return &ListVolumes{repo: repo, log: log}
+func NewListVolumes(repo domain.VolumeRepository, usage domain.VolumeUsageProvider, containers domain.ContainerRepository, log *slog.Logger) *ListVolumes {
+	return &ListVolumes{repo: repo, usage: usage, containers: containers, log: log}
 }
 
-func (uc *ListVolumes) Execute(ctx context.Context) ([]*domain.Volume, error) {
-	list, err := uc.repo.List(ctx)
+func (uc *ListVolumes) Execute(ctx context.Context, input ListVolumesInput) ([]*domain.Volume, error) {
+	list, err := listVolumesWithUsage(ctx, uc.repo, uc.usage, uc.containers, !input.SkipSize, uc.log)
 	if err != nil {
 		uc.log.ErrorContext(ctx, "list volumes use case failed", "error", err)
 		return nil, err
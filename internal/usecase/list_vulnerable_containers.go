@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type ContainerVulnerabilities struct {
+	ID       string                `json:"id"`
+	Name     string                `json:"name"`
+	Image    string                `json:"image"`
+	ImageID  string                `json:"image_id"`
+	Counts   domain.SeverityCounts `json:"counts"`
+	Findings int                   `json:"findings"`
+	// Error is set when the image could not be scanned.
+	Error string `json:"error,omitempty"`
+}
+
+// ListVulnerableContainers reports the vulnerability counts of every
+// running container's image, worst first. Each image is scanned once, and
+// inventories come from the SBOM cache.
+type ListVulnerableContainers struct {
+	sboms      *GenerateImageSBOM
+	db         domain.VulnerabilityDatabase
+	containers domain.ContainerRepository
+	log        *slog.Logger
+}
+
+func NewListVulnerableContainers(sboms *GenerateImageSBOM, db domain.VulnerabilityDatabase, containers domain.ContainerRepository, log *slog.Logger) *ListVulnerableContainers {
+	return &ListVulnerableContainers{sboms: sboms, db: db, containers: containers, log: log}
+}
+
+func (uc *ListVulnerableContainers) Execute(ctx context.Context) ([]ContainerVulnerabilities, error) {
+	running, err := uc.containers.ListActive(ctx, false)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "container list failed", "error", err)
+		return nil, err
+	}
+	type scan struct {
+		report *ImageVulnerabilityReport
+		err    error
+	}
+	scans := make(map[string]scan)
+	out := make([]ContainerVulnerabilities, 0, len(running))
+	for _, c := range running {
+		s, ok := scans[c.ImageID]
+		if !ok {
+			sbom, err := uc.sboms.inventory(ctx, c.ImageID)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				uc.log.WarnContext(ctx, "image vulnerability scan failed", "image_id", c.ImageID, "error", err)
+				s = scan{err: err}
+			} else {
+				s = scan{report: vulnerabilityReport(sbom, uc.db)}
+			}
+			scans[c.ImageID] = s
+		}
+		cv := ContainerVulnerabilities{ID: c.ID, Name: containerDisplayName(c), Image: c.Image, ImageID: c.ImageID}
+		if s.err != nil {
+			cv.Error = s.err.Error()
+		} else {
+			cv.Counts = s.report.Counts
+			cv.Findings = len(s.report.Findings)
+		}
+		out = append(out, cv)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].Counts, out[j].Counts
+		if a.Critical != b.Critical {
+			return a.Critical > b.Critical
+		}
+		if a.High != b.High {
+			return a.High > b.High
+		}
+		if a.Total() != b.Total() {
+			return a.Total() > b.Total()
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type LoadImages struct {
+	archiver domain.ImageArchiver
+	log      *slog.Logger
+}
+
+func NewLoadImages(archiver domain.ImageArchiver, log *slog.Logger) *LoadImages {
+	return &LoadImages{archiver: archiver, log: log}
+}
+
+func (uc *LoadImages) Execute(ctx context.Context, archive io.Reader, progress func(domain.LayerProgress)) (*domain.LoadResult, error) {
+	if archive == nil {
+		return nil, invalidInput("missing image archive")
+	}
+	return uc.archiver.Load(ctx, archive, progress)
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type OpenVolumeBackup struct {
+	backups domain.VolumeBackupRepository
+	log     *slog.Logger
+}
+
+func NewOpenVolumeBackup(backups domain.VolumeBackupRepository, log *slog.Logger) *OpenVolumeBackup {
+	return &OpenVolumeBackup{backups: backups, log: log}
+}
+
+// Execute returns the backup with its archive; the caller must close it.
+func (uc *OpenVolumeBackup) Execute(ctx context.Context, id string) (*domain.VolumeBackup, io.ReadCloser, error) {
+	b, err := uc.backups.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	rc, err := uc.backups.Open(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, rc, nil
+}
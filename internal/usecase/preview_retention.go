@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type PreviewRetention struct {
+	deps retentionDeps
+}
+
+func NewPreviewRetention(policies domain.RetentionPolicyRepository, images domain.ImageRepository, containers domain.ContainerRepository, layers domain.ImageLayerIndex, log *slog.Logger) *PreviewRetention {
+	return &PreviewRetention{deps: retentionDeps{policies: policies, images: images, containers: containers, layers: layers, log: log}}
+}
+
+// Execute lists exactly what a run of the policy (or of all enabled policies
+// when policyID is empty) would delete right now, without deleting anything.
+func (uc *PreviewRetention) Execute(ctx context.Context, policyID string) (*domain.RetentionPlan, error) {
+	p, err := uc.deps.plan(ctx, policyID, time.Now())
+	if err != nil {
+		uc.deps.log.ErrorContext(ctx, "retention preview failed", "policy_id", policyID, "error", err)
+		return nil, err
+	}
+	return p.plan, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// PruneVolumes removes the volumes no container uses, optionally narrowed
+// down by labels.
+type PruneVolumes struct {
+	volumes domain.VolumeManager
+	log     *slog.Logger
+}
+
+func NewPruneVolumes(volumes domain.VolumeManager, log *slog.Logger) *PruneVolumes {
+	return &PruneVolumes{volumes: volumes, log: log}
+}
+
+func (uc *PruneVolumes) Execute(ctx context.Context, filter domain.VolumePruneFilter) (*domain.VolumePruneResult, error) {
+	for _, l := range append(append([]string{}, filter.Labels...), filter.ExcludeLabels...) {
+		if key, _, _ := strings.Cut(l, "="); strings.TrimSpace(key) == "" {
+			return nil, invalidInput("invalid label filter %q, expected key or key=value", l)
+		}
+	}
+	res, err := uc.volumes.Prune(ctx, filter)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "prune volumes failed", "error", err)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type PullImage struct {
+	images domain.ImageManager
+	creds  domain.RegistryCredentialProvider
+	log    *slog.Logger
+}
+
+// NewPullImage accepts a nil creds provider when no credential store is
+// configured; pulls are then anonymous.
+func NewPullImage(images domain.ImageManager, creds domain.RegistryCredentialProvider, log *slog.Logger) *PullImage {
+	return &PullImage{images: images, creds: creds, log: log}
+}
+
+func (uc *PullImage) Execute(ctx context.Context, ref string, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, invalidInput("missing image reference")
+	}
+	cred, err := credentialFor(ctx, uc.creds, ref)
+	if err != nil {
+		uc.log.WarnContext(ctx, "registry credential lookup failed, pulling anonymously", "ref", ref, "error", err)
+	}
+	return uc.images.Pull(ctx, ref, cred, progress)
+}
+
+func credentialFor(ctx context.Context, creds domain.RegistryCredentialProvider, ref string) (*domain.RegistryCredential, error) {
+	if creds == nil {
+		return nil, nil
+	}
+	return creds.CredentialFor(ctx, ref)
+}
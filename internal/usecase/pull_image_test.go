@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockImageManager struct {
+	lastRef  string
+	lastCred *domain.RegistryCredential
+	progress []domain.LayerProgress
+	untagged []string
+	err      error
+}
+
+func (m *mockImageManager) Pull(ctx context.Context, ref string, cred *domain.RegistryCredential, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+	m.lastRef, m.lastCred = ref, cred
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, p := range m.progress {
+		progress(p)
+	}
+	return &domain.PullResult{Ref: ref, Digest: "sha256:abc", Status: "Downloaded newer image"}, nil
+}
+
+func (m *mockImageManager) Remove(ctx context.Context, ref string, force, noPrune bool) (*domain.ImageDeleteResult, error) {
+	m.lastRef = ref
+	return &domain.ImageDeleteResult{}, m.err
+}
+
+func (m *mockImageManager) Tag(ctx context.Context, source, target string) error {
+	m.lastRef = target
+	return m.err
+}
+
+func (m *mockImageManager) Untag(ctx context.Context, imageRef, tag string) (*domain.ImageDeleteResult, error) {
+	m.lastRef = tag
+	if m.err == nil {
+		m.untagged = append(m.untagged, tag)
+	}
+	return &domain.ImageDeleteResult{}, m.err
+}
+
+type mockCredentialProvider struct {
+	cred *domain.RegistryCredential
+	err  error
+}
+
+func (m *mockCredentialProvider) CredentialFor(ctx context.Context, imageRef string) (*domain.RegistryCredential, error) {
+	return m.cred, m.err
+}
+
+func TestPullImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+
+	t.Run("missing ref", func(t *testing.T) {
+		uc := NewPullImage(&mockImageManager{}, nil, log)
+		if _, err := uc.Execute(ctx, "  ", func(domain.LayerProgress) {}); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("uses stored credential and forwards progress", func(t *testing.T) {
+		images := &mockImageManager{progress: []domain.LayerProgress{
+			{LayerID: "l1", Phase: domain.PhaseDownloading, Current: 10, Total: 100},
+			{LayerID: "l1", Phase: domain.PhaseComplete},
+		}}
+		cred := &domain.RegistryCredential{Registry: "registry.local", Username: "ci"}
+		uc := NewPullImage(images, &mockCredentialProvider{cred: cred}, log)
+
+		var got []domain.LayerProgress
+		res, err := uc.Execute(ctx, "registry.local/app:1.0", func(p domain.LayerProgress) { got = append(got, p) })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if images.lastCred != cred || images.lastRef != "registry.local/app:1.0" {
+			t.Errorf("pull called with %q %+v", images.lastRef, images.lastCred)
+		}
+		if len(got) != 2 || res.Digest != "sha256:abc" {
+			t.Errorf("progress=%v result=%+v", got, res)
+		}
+	})
+
+	t.Run("credential lookup failure falls back to anonymous", func(t *testing.T) {
+		images := &mockImageManager{}
+		uc := NewPullImage(images, &mockCredentialProvider{err: errors.New("locked")}, log)
+		if _, err := uc.Execute(ctx, "alpine", func(domain.LayerProgress) {}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if images.lastCred != nil {
+			t.Errorf("expected anonymous pull, got %+v", images.lastCred)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type PullRegistryImageInput struct {
+	Registry   string
+	Repository string
+	// Reference is a tag or a digest; it defaults to "latest".
+	Reference string
+}
+
+// PullRegistryImage pulls a tag found while browsing a registry, using the
+// registry's own credentials or, failing that, the stored ones for its host.
+type PullRegistryImage struct {
+	registries domain.RegistryRepository
+	creds      domain.RegistryCredentialRepository
+	images     domain.ImageManager
+	log        *slog.Logger
+}
+
+func NewPullRegistryImage(registries domain.RegistryRepository, creds domain.RegistryCredentialRepository, images domain.ImageManager, log *slog.Logger) *PullRegistryImage {
+	return &PullRegistryImage{registries: registries, creds: creds, images: images, log: log}
+}
+
+func (uc *PullRegistryImage) Execute(ctx context.Context, input PullRegistryImageInput, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+	if input.Reference == "" {
+		input.Reference = "latest"
+	}
+	if !repoPattern.MatchString(input.Repository) {
+		return nil, invalidInput("invalid repository %q", input.Repository)
+	}
+	if !validRegistryReference(input.Reference) {
+		return nil, invalidInput("invalid tag or digest %q", input.Reference)
+	}
+	reg, err := lookupRegistry(ctx, uc.registries, uc.creds, input.Registry, uc.log)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := reg.ImageRef(input.Repository, input.Reference)
+	var cred *domain.RegistryCredential
+	if reg.Username != "" {
+		cred = &domain.RegistryCredential{Registry: reg.Host(), Username: reg.Username, Password: reg.Password}
+	}
+	uc.log.InfoContext(ctx, "pulling image from registry", "registry", reg.Name, "ref", ref)
+	return uc.images.Pull(ctx, ref, cred, progress)
+}
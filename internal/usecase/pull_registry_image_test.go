@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockRegistryRepo struct {
+	items map[string]*domain.Registry
+}
+
+func (m *mockRegistryRepo) List(ctx context.Context) ([]*domain.Registry, error) {
+	out := make([]*domain.Registry, 0, len(m.items))
+	for _, r := range m.items {
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (m *mockRegistryRepo) Get(ctx context.Context, name string) (*domain.Registry, error) {
+	r, ok := m.items[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return r, nil
+}
+
+func (m *mockRegistryRepo) Save(ctx context.Context, r *domain.Registry) error {
+	m.items[r.Name] = r
+	return nil
+}
+
+func (m *mockRegistryRepo) Delete(ctx context.Context, name string) error {
+	delete(m.items, name)
+	return nil
+}
+
+type recordingPuller struct {
+	mockImageManager
+	cred *domain.RegistryCredential
+}
+
+func (m *recordingPuller) Pull(ctx context.Context, ref string, cred *domain.RegistryCredential, progress func(domain.LayerProgress)) (*domain.PullResult, error) {
+	m.lastRef = ref
+	m.cred = cred
+	return &domain.PullResult{Ref: ref}, nil
+}
+
+func TestPullRegistryImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := &mockRegistryRepo{items: map[string]*domain.Registry{
+		"ci": {Name: "ci", URL: "https://registry.local:5000", Username: "bot", Password: "pw"},
+	}}
+	images := &recordingPuller{}
+	uc := NewPullRegistryImage(repo, nil, images, log)
+
+	if _, err := uc.Execute(context.Background(), PullRegistryImageInput{Registry: "ci", Repository: "team/app"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if images.lastRef != "registry.local:5000/team/app:latest" {
+		t.Errorf("ref = %q", images.lastRef)
+	}
+	if images.cred == nil || images.cred.Username != "bot" || images.cred.Registry != "registry.local:5000" {
+		t.Errorf("cred = %+v", images.cred)
+	}
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	if _, err := uc.Execute(context.Background(), PullRegistryImageInput{Registry: "ci", Repository: "team/app", Reference: digest}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if images.lastRef != "registry.local:5000/team/app@"+digest {
+		t.Errorf("ref = %q", images.lastRef)
+	}
+
+	for _, in := range []PullRegistryImageInput{
+		{Registry: "ci", Repository: "../etc"},
+		{Registry: "ci", Repository: "app", Reference: "bad/tag"},
+	} {
+		if _, err := uc.Execute(context.Background(), in, nil); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", in, err)
+		}
+	}
+	if _, err := uc.Execute(context.Background(), PullRegistryImageInput{Registry: "nope", Repository: "app"}, nil); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSaveRegistry_KeepsRedactedPassword(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := &mockRegistryRepo{items: map[string]*domain.Registry{}}
+	uc := NewSaveRegistry(repo, log)
+	ctx := context.Background()
+
+	out, err := uc.Execute(ctx, SaveRegistryInput{Registry: domain.Registry{Name: "ci", URL: "https://registry.local/", Username: "bot", Password: "pw"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Password != domain.RedactedSecret || out.URL != "https://registry.local" {
+		t.Errorf("unexpected output: %+v", out)
+	}
+	if _, err := uc.Execute(ctx, SaveRegistryInput{Registry: domain.Registry{Name: "ci", URL: "https://other"}}); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	if _, err := uc.Execute(ctx, SaveRegistryInput{Name: "ci", Registry: domain.Registry{URL: "https://registry.local", Username: "bot", Password: domain.RedactedSecret, Insecure: true}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["ci"]; got.Password != "pw" || !got.Insecure {
+		t.Errorf("stored registry = %+v", got)
+	}
+
+	if _, err := uc.Execute(ctx, SaveRegistryInput{Registry: domain.Registry{Name: "x", URL: "registry.local"}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type PushImage struct {
+	pusher domain.ImagePusher
+	creds  domain.RegistryCredentialProvider
+	log    *slog.Logger
+}
+
+// NewPushImage accepts a nil creds provider; pushes are then anonymous.
+func NewPushImage(pusher domain.ImagePusher, creds domain.RegistryCredentialProvider, log *slog.Logger) *PushImage {
+	return &PushImage{pusher: pusher, creds: creds, log: log}
+}
+
+// Execute pushes ref, "repo" or "repo:tag" (the tag defaults to "latest"),
+// with the stored credential for its registry.
+func (uc *PushImage) Execute(ctx context.Context, ref string, progress func(domain.LayerProgress)) (*domain.PushResult, error) {
+	ref = strings.TrimSpace(ref)
+	if !validImageReference(ref) {
+		return nil, invalidInput("invalid image reference %q, expected repo[:tag]", ref)
+	}
+	if _, tag := domain.SplitImageRef(ref); !strings.HasSuffix(ref, ":"+tag) {
+		ref += ":" + tag
+	}
+	cred, err := credentialFor(ctx, uc.creds, ref)
+	if err != nil {
+		uc.log.WarnContext(ctx, "registry credential lookup failed, pushing anonymously", "ref", ref, "error", err)
+	}
+	return uc.pusher.Push(ctx, ref, cred, progress)
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockPusher struct {
+	ref  string
+	cred *domain.RegistryCredential
+	err  error
+}
+
+func (m *mockPusher) Push(ctx context.Context, ref string, cred *domain.RegistryCredential, progress func(domain.LayerProgress)) (*domain.PushResult, error) {
+	m.ref, m.cred = ref, cred
+	if m.err != nil {
+		return nil, m.err
+	}
+	progress(domain.LayerProgress{LayerID: "abc", Phase: domain.PhaseUploading, Current: 1, Total: 2})
+	return &domain.PushResult{Ref: ref, Digest: "sha256:pushed"}, nil
+}
+
+func TestPushImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	pusher := &mockPusher{}
+	creds := &mockCredentialProvider{cred: &domain.RegistryCredential{Registry: "registry.local:5000", Username: "ci"}}
+	uc := NewPushImage(pusher, creds, log)
+
+	var updates int
+	res, err := uc.Execute(context.Background(), "registry.local:5000/team/app", func(domain.LayerProgress) { updates++ })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pusher.ref != "registry.local:5000/team/app:latest" || res.Digest != "sha256:pushed" || updates != 1 {
+		t.Errorf("ref = %q, result = %+v, updates = %d", pusher.ref, res, updates)
+	}
+	if pusher.cred == nil || pusher.cred.Username != "ci" {
+		t.Errorf("expected the stored credential, got %+v", pusher.cred)
+	}
+
+	if _, err := uc.Execute(context.Background(), "app:v1", func(domain.LayerProgress) {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pusher.ref != "app:v1" {
+		t.Errorf("ref = %q", pusher.ref)
+	}
+
+	if _, err := uc.Execute(context.Background(), "Team/App:v1", nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+
+	pusher.err = &domain.PushError{Code: domain.PushErrorAuthDenied, Message: "denied"}
+	_, err = uc.Execute(context.Background(), "app:v1", nil)
+	var pushErr *domain.PushError
+	if !errors.As(err, &pushErr) || !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("expected an auth_denied PushError, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxRegistryPageSize = 1000
+
+var digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+
+// lookupRegistry returns the registry called name. One without credentials
+// of its own gets the credential stored for its host, if any.
+func lookupRegistry(ctx context.Context, registries domain.RegistryRepository, creds domain.RegistryCredentialRepository, name string, log *slog.Logger) (*domain.Registry, error) {
+	reg, err := registries.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("registry %s: %w", name, err)
+	}
+	if reg.Username != "" || creds == nil {
+		return reg, nil
+	}
+	c, err := creds.Get(ctx, reg.Host())
+	switch {
+	case err == nil && c.Username != "":
+		out := *reg
+		out.Username, out.Password = c.Username, c.Password
+		return &out, nil
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		log.WarnContext(ctx, "registry credential lookup failed, browsing anonymously", "registry", name, "error", err)
+	}
+	return reg, nil
+}
+
+func validRegistryReference(ref string) bool {
+	return tagPattern.MatchString(ref) || digestPattern.MatchString(ref)
+}
+
+func pageSize(limit int) int {
+	if limit < 0 {
+		return 0
+	}
+	return min(limit, maxRegistryPageSize)
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// ReloadVulnerabilityDB rereads the advisory directory, so updated OSV
+// exports are picked up without a restart.
+type ReloadVulnerabilityDB struct {
+	db  domain.VulnerabilityDatabase
+	log *slog.Logger
+}
+
+func NewReloadVulnerabilityDB(db domain.VulnerabilityDatabase, log *slog.Logger) *ReloadVulnerabilityDB {
+	return &ReloadVulnerabilityDB{db: db, log: log}
+}
+
+func (uc *ReloadVulnerabilityDB) Execute(ctx context.Context) (*domain.VulnerabilityDBStatus, error) {
+	st, err := uc.db.Reload(ctx)
+	if err != nil {
+		uc.log.WarnContext(ctx, "vulnerability database reload failed", "error", err)
+		return nil, err
+	}
+	return st, nil
+}
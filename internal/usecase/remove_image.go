@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type RemoveImageInput struct {
+	Ref     string
+	Force   bool
+	NoPrune bool
+}
+
+type RemoveImage struct {
+	images domain.ImageManager
+	log    *slog.Logger
+}
+
+func NewRemoveImage(images domain.ImageManager, log *slog.Logger) *RemoveImage {
+	return &RemoveImage{images: images, log: log}
+}
+
+func (uc *RemoveImage) Execute(ctx context.Context, input RemoveImageInput) (*domain.ImageDeleteResult, error) {
+	if input.Ref == "" {
+		return nil, invalidInput("missing image id")
+	}
+	return uc.images.Remove(ctx, input.Ref, input.Force, input.NoPrune)
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestRemoveImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+
+	if _, err := NewRemoveImage(&mockImageManager{}, log).Execute(ctx, RemoveImageInput{}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+
+	images := &mockImageManager{}
+	if _, err := NewRemoveImage(images, log).Execute(ctx, RemoveImageInput{Ref: "sha256:1"}); err != nil || images.lastRef != "sha256:1" {
+		t.Errorf("unexpected result: %v (manager saw %q)", err, images.lastRef)
+	}
+
+	inUse := &domain.InUseError{Resource: "image sha256:1", Containers: []string{"c1", "c2"}}
+	_, err := NewRemoveImage(&mockImageManager{err: inUse}, log).Execute(ctx, RemoveImageInput{Ref: "sha256:1"})
+	var got *domain.InUseError
+	if !errors.As(err, &got) || !errors.Is(err, domain.ErrConflict) || len(got.Containers) != 2 {
+		t.Errorf("expected InUseError listing the containers, got %v", err)
+	}
+
+	_, err = NewRemoveImage(&mockImageManager{err: domain.ErrNotFound}, log).Execute(ctx, RemoveImageInput{Ref: "missing"})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type RemoveVolumeInput struct {
+	Name  string
+	Force bool
+}
+
+type RemoveVolume struct {
+	volumes domain.VolumeManager
+	log     *slog.Logger
+}
+
+func NewRemoveVolume(volumes domain.VolumeManager, log *slog.Logger) *RemoveVolume {
+	return &RemoveVolume{volumes: volumes, log: log}
+}
+
+func (uc *RemoveVolume) Execute(ctx context.Context, input RemoveVolumeInput) error {
+	if input.Name == "" {
+		return invalidInput("missing volume name")
+	}
+	return uc.volumes.Remove(ctx, input.Name, input.Force)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type RestoreVolumeInput struct {
+	// Volume is the volume restored into, created if missing. It defaults
+	// to the backup's own volume.
+	Volume   string
+	BackupID string
+	// Archive is an uploaded tar archive, optionally compressed, used when
+	// BackupID is empty.
+	Archive        io.Reader
+	StopContainers bool
+}
+
+type RestoreVolumeOutput struct {
+	Volume  string `json:"volume"`
+	Created bool   `json:"created"`
+}
+
+// RestoreVolume extracts a backup into a volume. Files of an existing volume
+// are overwritten by the backup's but files missing from it are kept.
+type RestoreVolume struct {
+	files      domain.VolumeFileAccess
+	backups    domain.VolumeBackupRepository
+	volumes    domain.VolumeManager
+	containers domain.ContainerRepository
+	controller domain.ContainerController
+	log        *slog.Logger
+}
+
+func NewRestoreVolume(files domain.VolumeFileAccess, backups domain.VolumeBackupRepository, volumes domain.VolumeManager, containers domain.ContainerRepository, controller domain.ContainerController, log *slog.Logger) *RestoreVolume {
+	return &RestoreVolume{files: files, backups: backups, volumes: volumes, containers: containers, controller: controller, log: log}
+}
+
+func (uc *RestoreVolume) Execute(ctx context.Context, input RestoreVolumeInput) (*RestoreVolumeOutput, error) {
+	target, archive := input.Volume, input.Archive
+	if input.BackupID != "" {
+		b, err := uc.backups.Get(ctx, input.BackupID)
+		if err != nil {
+			return nil, err
+		}
+		if target == "" {
+			target = b.Volume
+		}
+		rc, err := uc.backups.Open(ctx, b.ID)
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		archive = rc
+	}
+	if archive == nil {
+		return nil, invalidInput("missing backup")
+	}
+	if !volumeNamePattern.MatchString(target) {
+		return nil, invalidInput("invalid volume name %q", target)
+	}
+
+	out := &RestoreVolumeOutput{Volume: target}
+	_, err := uc.files.Stat(ctx, target, "/")
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		if _, err := uc.volumes.Create(ctx, domain.VolumeCreateOptions{Name: target, Driver: defaultVolumeDriver}); err != nil {
+			return nil, err
+		}
+		out.Created = true
+	case err != nil:
+		return nil, err
+	}
+
+	var stopped []string
+	if input.StopContainers && !out.Created {
+		if stopped, err = stopVolumeContainers(ctx, uc.containers, uc.controller, target, uc.log); err != nil {
+			return nil, err
+		}
+	}
+	err = uc.files.Write(ctx, target, "/", archive)
+	startVolumeContainers(ctx, uc.controller, target, stopped, uc.log)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "volume restore failed", "volume", target, "backup_id", input.BackupID, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "volume restored", "volume", target, "backup_id", input.BackupID, "created", out.Created)
+	return out, nil
+}
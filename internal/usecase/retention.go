@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// retentionDeps groups what planning needs, shared by the preview, the run
+// and the scheduler.
+type retentionDeps struct {
+	policies   domain.RetentionPolicyRepository
+	images     domain.ImageRepository
+	containers domain.ContainerRepository
+	layers     domain.ImageLayerIndex
+	log        *slog.Logger
+}
+
+type retentionPlanning struct {
+	plan   *domain.RetentionPlan
+	images []*domain.Image
+	layers map[string][]domain.LayerRef
+}
+
+// plan evaluates the policy with policyID, or every enabled policy when it
+// is empty. A tag is only deleted when no enabled policy covering its
+// repository retains it, so overlapping policies never delete what another
+// one keeps, and tags of images any container uses are never deleted.
+func (d *retentionDeps) plan(ctx context.Context, policyID string, now time.Time) (*retentionPlanning, error) {
+	all, err := d.policies.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var selected, enabled []*domain.RetentionPolicy
+	for _, p := range all {
+		if p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	if policyID == "" {
+		selected = enabled
+	} else {
+		p, err := d.policies.Get(ctx, policyID)
+		if err != nil {
+			return nil, err
+		}
+		selected = []*domain.RetentionPolicy{p}
+	}
+
+	images, layers, err := listImagesWithUsage(ctx, d.images, d.containers, d.layers, d.log)
+	if err != nil {
+		return nil, err
+	}
+	return buildRetentionPlan(images, layers, selected, enabled, now), nil
+}
+
+type retentionTag struct {
+	ref, repo, tag string
+	img            *domain.Image
+}
+
+func buildRetentionPlan(images []*domain.Image, layers map[string][]domain.LayerRef, selected, enabled []*domain.RetentionPolicy, now time.Time) *retentionPlanning {
+	byRepo := make(map[string][]retentionTag)
+	for _, img := range images {
+		for _, ref := range img.RepoTags {
+			if ref == "<none>:<none>" {
+				continue
+			}
+			repo, tag := domain.SplitImageRef(ref)
+			byRepo[repo] = append(byRepo[repo], retentionTag{ref: ref, repo: repo, tag: tag, img: img})
+		}
+	}
+
+	plan := &domain.RetentionPlan{Candidates: []domain.RetentionCandidate{}, Protected: []string{}}
+	pending := make(map[string]int)
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	for _, repo := range repos {
+		var sel, guards []*domain.RetentionPolicy
+		for _, p := range selected {
+			if p.MatchesRepository(repo) {
+				sel = append(sel, p)
+			}
+		}
+		if len(sel) == 0 {
+			continue
+		}
+		for _, p := range enabled {
+			if p.MatchesRepository(repo) {
+				guards = append(guards, p)
+			}
+		}
+		rules := append(append([]*domain.RetentionPolicy{}, sel...), guards...)
+
+		tags := byRepo[repo]
+		sort.Slice(tags, func(i, j int) bool {
+			if !tags[i].img.CreatedAt.Equal(tags[j].img.CreatedAt) {
+				return tags[i].img.CreatedAt.After(tags[j].img.CreatedAt)
+			}
+			return tags[i].tag > tags[j].tag
+		})
+		for rank, t := range tags {
+			retained := false
+			reason := ""
+			for _, p := range rules {
+				ok, why := p.Retains(t.tag, rank, t.img.CreatedAt, now)
+				if ok {
+					retained = true
+					break
+				}
+				if reason == "" {
+					reason = why
+				}
+			}
+			if retained {
+				continue
+			}
+			if len(t.img.Containers) > 0 {
+				plan.Protected = append(plan.Protected, t.ref)
+				continue
+			}
+			plan.Candidates = append(plan.Candidates, domain.RetentionCandidate{
+				PolicyID:  sel[0].ID,
+				Ref:       t.ref,
+				ImageID:   t.img.ID,
+				CreatedAt: t.img.CreatedAt,
+				Reason:    reason,
+			})
+			pending[t.img.ID]++
+		}
+	}
+
+	removed := make(map[string]bool)
+	for _, img := range images {
+		tagged := 0
+		for _, ref := range img.RepoTags {
+			if ref != "<none>:<none>" {
+				tagged++
+			}
+		}
+		if tagged > 0 && pending[img.ID] == tagged {
+			removed[img.ID] = true
+		}
+	}
+	for i := range plan.Candidates {
+		plan.Candidates[i].RemovesImage = removed[plan.Candidates[i].ImageID]
+	}
+	p := &retentionPlanning{plan: plan, images: images, layers: layers}
+	plan.FreedBytes = p.freed(removed)
+	return p
+}
+
+// freed is the space reclaimed by deleting the images in removed.
+func (p *retentionPlanning) freed(removed map[string]bool) int64 {
+	var imgs []*domain.Image
+	for _, img := range p.images {
+		if removed[img.ID] {
+			imgs = append(imgs, img)
+		}
+	}
+	return reclaimableBytes(imgs, p.images, p.layers)
+}
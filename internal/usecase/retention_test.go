@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockRetentionPolicyRepo struct {
+	list []*domain.RetentionPolicy
+}
+
+func (m *mockRetentionPolicyRepo) List(ctx context.Context) ([]*domain.RetentionPolicy, error) {
+	return m.list, nil
+}
+
+func (m *mockRetentionPolicyRepo) Get(ctx context.Context, id string) (*domain.RetentionPolicy, error) {
+	for _, p := range m.list {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockRetentionPolicyRepo) Save(ctx context.Context, p *domain.RetentionPolicy) error {
+	m.list = append(m.list, p)
+	return nil
+}
+
+func (m *mockRetentionPolicyRepo) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+type mockRetentionRunRepo struct {
+	runs []*domain.RetentionRun
+}
+
+func (m *mockRetentionRunRepo) Append(ctx context.Context, r *domain.RetentionRun) error {
+	m.runs = append(m.runs, r)
+	return nil
+}
+
+func (m *mockRetentionRunRepo) List(ctx context.Context, limit int) ([]*domain.RetentionRun, error) {
+	return m.runs, nil
+}
+
+// retentionFixture has five ci/app builds, a day apart, the newest first:
+// v5 and v4 share an image, v2 is in use, "release-1" matches the keep
+// pattern.
+func retentionFixture(now time.Time) (*mockImageRepo, *mockContainerRepo, *mockLayerIndex) {
+	day := 24 * time.Hour
+	images := &mockImageRepo{list: []*domain.Image{
+		{ID: "i5", RepoTags: []string{"ci/app:v5", "ci/app:v4"}, CreatedAt: now.Add(-1 * day), Size: 10},
+		{ID: "i3", RepoTags: []string{"ci/app:v3"}, CreatedAt: now.Add(-3 * day), Size: 20},
+		{ID: "i2", RepoTags: []string{"ci/app:v2"}, CreatedAt: now.Add(-4 * day), Size: 30},
+		{ID: "i1", RepoTags: []string{"ci/app:release-1", "ci/app:v1"}, CreatedAt: now.Add(-5 * day), Size: 40},
+		{ID: "o1", RepoTags: []string{"other:1"}, CreatedAt: now.Add(-90 * day), Size: 50},
+	}}
+	containers := &mockContainerRepo{list: []*domain.Container{{ID: "c1", ImageID: "i2"}}}
+	index := &mockLayerIndex{layers: map[string][]domain.LayerRef{
+		"i5": {{ChainID: "base", Size: 5}, {ChainID: "l5", Size: 5}},
+		"i3": {{ChainID: "base", Size: 5}, {ChainID: "l3", Size: 15}},
+		"i2": {{ChainID: "base", Size: 5}, {ChainID: "l2", Size: 25}},
+		"i1": {{ChainID: "base", Size: 5}, {ChainID: "l1", Size: 35}},
+		"o1": {{ChainID: "o", Size: 50}},
+	}}
+	return images, containers, index
+}
+
+func candidateRefs(plan *domain.RetentionPlan) []string {
+	var refs []string
+	for _, c := range plan.Candidates {
+		refs = append(refs, c.Ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func TestPreviewRetention_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	now := time.Now()
+	images, containers, index := retentionFixture(now)
+	policies := &mockRetentionPolicyRepo{list: []*domain.RetentionPolicy{
+		{ID: "p1", Repository: "ci/*", KeepLast: 2, KeepTagPattern: `^release-`, Enabled: true},
+	}}
+	uc := NewPreviewRetention(policies, images, containers, index, log)
+
+	plan, err := uc.Execute(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// v5, v4 are the newest two; v2 is in use; release-1 matches the pattern.
+	if got, want := candidateRefs(plan), []string{"ci/app:v1", "ci/app:v3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("candidates = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(plan.Protected, []string{"ci/app:v2"}) {
+		t.Errorf("protected = %v", plan.Protected)
+	}
+	for _, c := range plan.Candidates {
+		if c.RemovesImage != (c.Ref == "ci/app:v3") {
+			t.Errorf("%s: removes_image = %v", c.Ref, c.RemovesImage)
+		}
+	}
+	// Only i3 goes away entirely, and the base layer stays.
+	if plan.FreedBytes != 15 {
+		t.Errorf("freed = %d, want 15", plan.FreedBytes)
+	}
+
+	// An enabled overlapping policy that keeps more protects the extra tags
+	// even when only p2 is run.
+	policies.list = append(policies.list, &domain.RetentionPolicy{ID: "p2", Repository: "ci/app", MaxAgeDays: 2})
+	plan, err = uc.Execute(context.Background(), "p2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := candidateRefs(plan), []string{"ci/app:v1", "ci/app:v3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("p2 candidates = %v, want %v", got, want)
+	}
+
+	if _, err := uc.Execute(context.Background(), "missing"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRunRetention_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	now := time.Now()
+	images, containers, index := retentionFixture(now)
+	policies := &mockRetentionPolicyRepo{list: []*domain.RetentionPolicy{
+		{ID: "p1", Repository: "*", MaxAgeDays: 30, Schedule: "0 3 * * *", Enabled: true},
+	}}
+	manager := &mockImageManager{}
+	runs := &mockRetentionRunRepo{}
+	uc := NewRunRetention(policies, images, containers, index, manager, runs, log)
+
+	uc.runDue(context.Background(), time.Date(2026, 5, 1, 2, 0, 0, 0, time.Local))
+	if len(runs.runs) != 0 {
+		t.Fatalf("policy ran outside its schedule")
+	}
+	uc.runDue(context.Background(), time.Date(2026, 5, 1, 3, 0, 0, 0, time.Local))
+	if len(runs.runs) != 1 {
+		t.Fatalf("expected one scheduled run, got %d", len(runs.runs))
+	}
+	run := runs.runs[0]
+	if run.Trigger != domain.RetentionTriggerSchedule || run.PolicyID != "p1" {
+		t.Errorf("unexpected run: %+v", run)
+	}
+	if !reflect.DeepEqual(manager.untagged, []string{"other:1"}) || !reflect.DeepEqual(run.Deleted, []string{"other:1"}) {
+		t.Errorf("deleted %v, recorded %v", manager.untagged, run.Deleted)
+	}
+	if run.FreedBytes != 50 {
+		t.Errorf("freed = %d, want 50", run.FreedBytes)
+	}
+
+	manager.err = errors.New("conflict")
+	run, err := uc.Execute(context.Background(), "", domain.RetentionTriggerManual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(run.Failed) != 1 || run.FreedBytes != 0 {
+		t.Errorf("expected a recorded failure and nothing freed: %+v", run)
+	}
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// RunRetention deletes the tags retention policies no longer keep, either on
+// demand or from Run, which fires each enabled policy on its cron schedule.
+// Every run is recorded in the execution log.
+type RunRetention struct {
+	deps    retentionDeps
+	manager domain.ImageManager
+	runs    domain.RetentionRunRepository
+
+	now func() time.Time
+	// mu serializes runs so a scheduled and a manual run never race on the
+	// same tags.
+	mu sync.Mutex
+}
+
+func NewRunRetention(
+	policies domain.RetentionPolicyRepository,
+	images domain.ImageRepository,
+	containers domain.ContainerRepository,
+	layers domain.ImageLayerIndex,
+	manager domain.ImageManager,
+	runs domain.RetentionRunRepository,
+	log *slog.Logger,
+) *RunRetention {
+	return &RunRetention{
+		deps:    retentionDeps{policies: policies, images: images, containers: containers, layers: layers, log: log},
+		manager: manager,
+		runs:    runs,
+		now:     time.Now,
+	}
+}
+
+// Execute runs the policy with policyID, or all enabled policies when it is
+// empty. Failures to delete single tags are recorded in the run rather than
+// returned.
+func (uc *RunRetention) Execute(ctx context.Context, policyID, trigger string) (*domain.RetentionRun, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	run := &domain.RetentionRun{
+		ID:        newID(),
+		PolicyID:  policyID,
+		Trigger:   trigger,
+		StartedAt: uc.now(),
+		Deleted:   []string{},
+	}
+	p, err := uc.deps.plan(ctx, policyID, run.StartedAt)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		run.Error = err.Error()
+		run.FinishedAt = uc.now()
+		uc.record(ctx, run)
+		return run, nil
+	}
+
+	failedImages := make(map[string]bool)
+	for _, c := range p.plan.Candidates {
+		if _, err := uc.manager.Untag(ctx, c.ImageID, c.Ref); err != nil {
+			uc.deps.log.WarnContext(ctx, "retention delete failed", "ref", c.Ref, "error", err)
+			run.Failed = append(run.Failed, domain.RetentionFailure{Ref: c.Ref, Error: err.Error()})
+			failedImages[c.ImageID] = true
+			continue
+		}
+		run.Deleted = append(run.Deleted, c.Ref)
+	}
+	removed := make(map[string]bool)
+	for _, c := range p.plan.Candidates {
+		if c.RemovesImage && !failedImages[c.ImageID] {
+			removed[c.ImageID] = true
+		}
+	}
+	run.FreedBytes = p.freed(removed)
+	run.FinishedAt = uc.now()
+	uc.record(ctx, run)
+	uc.deps.log.InfoContext(ctx, "retention run finished", "run_id", run.ID, "policy_id", policyID, "trigger", trigger, "deleted", len(run.Deleted), "failed", len(run.Failed), "freed_bytes", run.FreedBytes)
+	return run, nil
+}
+
+func (uc *RunRetention) record(ctx context.Context, run *domain.RetentionRun) {
+	if err := uc.runs.Append(ctx, run); err != nil {
+		uc.deps.log.ErrorContext(ctx, "record retention run failed", "run_id", run.ID, "error", err)
+	}
+}
+
+// Run evaluates the policy schedules at the start of every minute until ctx
+// is done.
+func (uc *RunRetention) Run(ctx context.Context) {
+	for {
+		now := uc.now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+		uc.runDue(ctx, next)
+	}
+}
+
+func (uc *RunRetention) runDue(ctx context.Context, at time.Time) {
+	policies, err := uc.deps.policies.List(ctx)
+	if err != nil {
+		uc.deps.log.ErrorContext(ctx, "list retention policies failed", "error", err)
+		return
+	}
+	for _, p := range policies {
+		if !p.Enabled || p.Schedule == "" {
+			continue
+		}
+		sched, err := domain.ParseCron(p.Schedule)
+		if err != nil || !sched.Matches(at) {
+			continue
+		}
+		if _, err := uc.Execute(ctx, p.ID, domain.RetentionTriggerSchedule); err != nil {
+			uc.deps.log.ErrorContext(ctx, "scheduled retention run failed", "policy_id", p.ID, "error", err)
+		}
+	}
+}
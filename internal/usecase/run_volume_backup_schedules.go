@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// RunVolumeBackupSchedules takes the backup of a schedule and then prunes
+// the scheduled backups its retention no longer keeps, either on demand or
+// from Run, which fires each enabled schedule on its cron expression.
+type RunVolumeBackupSchedules struct {
+	schedules domain.VolumeBackupScheduleRepository
+	backups   domain.VolumeBackupRepository
+	backup    *BackupVolume
+	log       *slog.Logger
+
+	now func() time.Time
+	// mu serializes runs so that pruning never races a backup being taken.
+	mu sync.Mutex
+}
+
+func NewRunVolumeBackupSchedules(schedules domain.VolumeBackupScheduleRepository, backups domain.VolumeBackupRepository, backup *BackupVolume, log *slog.Logger) *RunVolumeBackupSchedules {
+	return &RunVolumeBackupSchedules{schedules: schedules, backups: backups, backup: backup, log: log, now: time.Now}
+}
+
+// Execute runs the schedule with id now. The backup is returned even when
+// pruning fails, which is only logged.
+func (uc *RunVolumeBackupSchedules) Execute(ctx context.Context, id string) (*domain.VolumeBackup, error) {
+	s, err := uc.schedules.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.run(ctx, s)
+}
+
+func (uc *RunVolumeBackupSchedules) run(ctx context.Context, s *domain.VolumeBackupSchedule) (*domain.VolumeBackup, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	b, err := uc.backup.Execute(ctx, BackupVolumeInput{
+		Volume:         s.Volume,
+		StopContainers: s.StopContainers,
+		Trigger:        domain.VolumeBackupTriggerSchedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list, err := uc.backups.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list volume backups for pruning failed", "schedule_id", s.ID, "error", err)
+		return b, nil
+	}
+	for _, old := range s.Expired(list) {
+		if err := uc.backups.Delete(ctx, old.ID); err != nil {
+			uc.log.WarnContext(ctx, "prune volume backup failed", "schedule_id", s.ID, "backup_id", old.ID, "error", err)
+			continue
+		}
+		uc.log.InfoContext(ctx, "volume backup pruned", "schedule_id", s.ID, "backup_id", old.ID)
+	}
+	return b, nil
+}
+
+// Run evaluates the schedules at the start of every minute until ctx is
+// done.
+func (uc *RunVolumeBackupSchedules) Run(ctx context.Context) {
+	for {
+		now := uc.now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+		uc.runDue(ctx, next)
+	}
+}
+
+func (uc *RunVolumeBackupSchedules) runDue(ctx context.Context, at time.Time) {
+	list, err := uc.schedules.List(ctx)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "list volume backup schedules failed", "error", err)
+		return
+	}
+	for _, s := range list {
+		if !s.Enabled {
+			continue
+		}
+		sched, err := domain.ParseCron(s.Schedule)
+		if err != nil || !sched.Matches(at) {
+			continue
+		}
+		if _, err := uc.run(ctx, s); err != nil {
+			uc.log.ErrorContext(ctx, "scheduled volume backup failed", "schedule_id", s.ID, "volume", s.Volume, "error", err)
+		}
+	}
+}
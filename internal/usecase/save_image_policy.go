@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SaveImagePolicy struct {
+	repo domain.ImagePolicyRepository
+	log  *slog.Logger
+}
+
+func NewSaveImagePolicy(repo domain.ImagePolicyRepository, log *slog.Logger) *SaveImagePolicy {
+	return &SaveImagePolicy{repo: repo, log: log}
+}
+
+func (uc *SaveImagePolicy) Execute(ctx context.Context, p domain.ImagePolicy) (*domain.ImagePolicy, error) {
+	if p.Mode == "" {
+		p.Mode = domain.ImagePolicyOff
+	}
+	switch p.Mode {
+	case domain.ImagePolicyOff, domain.ImagePolicyAudit, domain.ImagePolicyEnforce:
+	default:
+		return nil, invalidInput("mode must be one of off, audit, enforce")
+	}
+	var err error
+	if p.AllowedRegistries, err = cleanPatterns(p.AllowedRegistries, "allowed_registries"); err != nil {
+		return nil, err
+	}
+	if p.DeniedTags, err = cleanPatterns(p.DeniedTags, "denied_tags"); err != nil {
+		return nil, err
+	}
+	labels := []string{}
+	for _, l := range p.RequiredLabels {
+		l = strings.TrimSpace(l)
+		if key, _, _ := strings.Cut(l, "="); key == "" {
+			return nil, invalidInput("invalid required label %q, expected key or key=value", l)
+		}
+		labels = append(labels, l)
+	}
+	p.RequiredLabels = labels
+	if p.MaxAgeDays < 0 {
+		return nil, invalidInput("max_age_days must not be negative")
+	}
+
+	p.UpdatedAt = time.Now().UTC()
+	if err := uc.repo.Save(ctx, &p); err != nil {
+		uc.log.ErrorContext(ctx, "save image policy failed", "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "image policy saved", "mode", p.Mode)
+	return &p, nil
+}
+
+func cleanPatterns(patterns []string, field string) ([]string, error) {
+	out := []string{}
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, invalidInput("invalid %s pattern %q", field, p)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+const maxMaintenanceWindowMinutes = 7 * 24 * 60
+
+type SaveMaintenanceWindowInput struct {
+	// ID is empty when creating a window.
+	ID     string
+	Window domain.MaintenanceWindow
+}
+
+type SaveMaintenanceWindow struct {
+	repo domain.MaintenanceWindowRepository
+	log  *slog.Logger
+}
+
+func NewSaveMaintenanceWindow(repo domain.MaintenanceWindowRepository, log *slog.Logger) *SaveMaintenanceWindow {
+	return &SaveMaintenanceWindow{repo: repo, log: log}
+}
+
+func (uc *SaveMaintenanceWindow) Execute(ctx context.Context, input SaveMaintenanceWindowInput) (*domain.MaintenanceWindow, error) {
+	w := input.Window
+	if strings.TrimSpace(w.Name) == "" {
+		return nil, invalidInput("name is required")
+	}
+	if _, err := domain.ParseCron(w.Schedule); err != nil {
+		return nil, invalidInput("%v", err)
+	}
+	if w.DurationMinutes <= 0 || w.DurationMinutes > maxMaintenanceWindowMinutes {
+		return nil, invalidInput("duration_minutes must be between 1 and %d", maxMaintenanceWindowMinutes)
+	}
+	// A window without labels would silence every alert on its schedule.
+	if len(w.Labels) == 0 {
+		return nil, invalidInput("labels are required to scope the window")
+	}
+	for k := range w.Labels {
+		if strings.TrimSpace(k) == "" {
+			return nil, invalidInput("label names must not be empty")
+		}
+	}
+
+	if input.ID == "" {
+		w.ID = newID()
+		w.CreatedAt = time.Now().UTC()
+	} else {
+		existing, err := uc.repo.Get(ctx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		w.ID = existing.ID
+		w.CreatedAt = existing.CreatedAt
+	}
+	if err := uc.repo.Save(ctx, &w); err != nil {
+		uc.log.ErrorContext(ctx, "save maintenance window failed", "window_id", w.ID, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "maintenance window saved", "window_id", w.ID, "schedule", w.Schedule)
+	return &w, nil
+}
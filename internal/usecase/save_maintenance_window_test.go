@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestSaveMaintenanceWindow_RequiresLabels(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	uc := NewSaveMaintenanceWindow(&mockWindowRepo{}, log)
+	ctx := context.Background()
+
+	w := domain.MaintenanceWindow{Name: "nightly", Schedule: "0 2 * * *", DurationMinutes: 30, Enabled: true}
+	if _, err := uc.Execute(ctx, SaveMaintenanceWindowInput{Window: w}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("window without labels: expected invalid input, got %v", err)
+	}
+
+	w.Labels = map[string]string{"tier": "batch"}
+	out, err := uc.Execute(ctx, SaveMaintenanceWindowInput{Window: w})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID == "" {
+		t.Error("expected an ID")
+	}
+	if out.Covers(&domain.Alert{Labels: map[string]string{"tier": "front"}}) {
+		t.Error("window covers an alert outside its labels")
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SaveNotificationChannelInput struct {
+	// ID is empty when creating a channel.
+	ID      string
+	Channel domain.NotificationChannel
+}
+
+type SaveNotificationChannel struct {
+	repo domain.NotificationChannelRepository
+	log  *slog.Logger
+}
+
+func NewSaveNotificationChannel(repo domain.NotificationChannelRepository, log *slog.Logger) *SaveNotificationChannel {
+	return &SaveNotificationChannel{repo: repo, log: log}
+}
+
+func (uc *SaveNotificationChannel) Execute(ctx context.Context, input SaveNotificationChannelInput) (*domain.NotificationChannel, error) {
+	ch := input.Channel
+	now := time.Now().UTC()
+	if input.ID == "" {
+		ch.ID = newID()
+		ch.CreatedAt = now
+	} else {
+		existing, err := uc.repo.Get(ctx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		ch.ID = existing.ID
+		ch.CreatedAt = existing.CreatedAt
+		keepSecrets(&ch, existing)
+	}
+	ch.UpdatedAt = now
+
+	if err := validateChannel(&ch); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Save(ctx, &ch); err != nil {
+		uc.log.ErrorContext(ctx, "save notification channel failed", "channel_id", ch.ID, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "notification channel saved", "channel_id", ch.ID, "type", ch.Type)
+	return ch.Redacted(), nil
+}
+
+// keepSecrets restores secrets the client echoed back in redacted form.
+func keepSecrets(ch, existing *domain.NotificationChannel) {
+	if ch.Webhook != nil && ch.Webhook.Secret == domain.RedactedSecret {
+		ch.Webhook.Secret = ""
+		if existing.Webhook != nil {
+			ch.Webhook.Secret = existing.Webhook.Secret
+		}
+	}
+	if ch.Webhook != nil {
+		for k, v := range ch.Webhook.Headers {
+			if v != domain.RedactedSecret {
+				continue
+			}
+			if existing.Webhook != nil && existing.Webhook.Headers[k] != "" {
+				ch.Webhook.Headers[k] = existing.Webhook.Headers[k]
+			} else {
+				delete(ch.Webhook.Headers, k)
+			}
+		}
+	}
+	if ch.Chat != nil && existing.Chat != nil && ch.Chat.URL == domain.RedactURL(existing.Chat.URL) {
+		ch.Chat.URL = existing.Chat.URL
+	}
+	if ch.Email != nil && ch.Email.Password == domain.RedactedSecret {
+		ch.Email.Password = ""
+		if existing.Email != nil {
+			ch.Email.Password = existing.Email.Password
+		}
+	}
+}
+
+func validateChannel(ch *domain.NotificationChannel) error {
+	if strings.TrimSpace(ch.Name) == "" {
+		return invalidInput("name is required")
+	}
+	if ch.RateLimitPerMinute < 0 || ch.MaxRetries < 0 {
+		return invalidInput("rate_limit_per_minute and max_retries must not be negative")
+	}
+	switch ch.Type {
+	case domain.ChannelTypeWebhook:
+		if ch.Webhook == nil {
+			return invalidInput("webhook config is required")
+		}
+		return validateHTTPURL(ch.Webhook.URL)
+	case domain.ChannelTypeEmail:
+		if ch.Email == nil {
+			return invalidInput("email config is required")
+		}
+		if ch.Email.Host == "" || ch.Email.From == "" || len(ch.Email.To) == 0 {
+			return invalidInput("email host, from and to are required")
+		}
+		if ch.Email.Port < 0 || ch.Email.Port > 65535 {
+			return invalidInput("email port out of range")
+		}
+		return nil
+	case domain.ChannelTypeSlack, domain.ChannelTypeMattermost, domain.ChannelTypeDiscord:
+		if ch.Chat == nil {
+			return invalidInput("chat config is required")
+		}
+		return validateHTTPURL(ch.Chat.URL)
+	default:
+		return invalidInput("type must be one of webhook, email, slack, mattermost, discord")
+	}
+}
+
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return invalidInput("url must be an absolute http(s) URL")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockChannelRepo struct {
+	items map[string]*domain.NotificationChannel
+}
+
+func newMockChannelRepo(chs ...*domain.NotificationChannel) *mockChannelRepo {
+	m := &mockChannelRepo{items: make(map[string]*domain.NotificationChannel)}
+	for _, ch := range chs {
+		m.items[ch.ID] = ch
+	}
+	return m
+}
+
+func (m *mockChannelRepo) List(ctx context.Context) ([]*domain.NotificationChannel, error) {
+	out := make([]*domain.NotificationChannel, 0, len(m.items))
+	for _, ch := range m.items {
+		out = append(out, ch)
+	}
+	return out, nil
+}
+
+func (m *mockChannelRepo) Get(ctx context.Context, id string) (*domain.NotificationChannel, error) {
+	ch, ok := m.items[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return ch, nil
+}
+
+func (m *mockChannelRepo) Save(ctx context.Context, ch *domain.NotificationChannel) error {
+	m.items[ch.ID] = ch
+	return nil
+}
+
+func (m *mockChannelRepo) Delete(ctx context.Context, id string) error {
+	delete(m.items, id)
+	return nil
+}
+
+func TestSaveNotificationChannel_Create(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockChannelRepo()
+	uc := NewSaveNotificationChannel(repo, log)
+
+	out, err := uc.Execute(context.Background(), SaveNotificationChannelInput{Channel: domain.NotificationChannel{
+		Name:    "ops",
+		Type:    domain.ChannelTypeWebhook,
+		Webhook: &domain.WebhookConfig{URL: "https://hooks.example.com/x", Secret: "s3cret"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.ID == "" || out.CreatedAt.IsZero() {
+		t.Errorf("id/created_at not set: %+v", out)
+	}
+	if out.Webhook.Secret != domain.RedactedSecret {
+		t.Errorf("secret not redacted in output: %q", out.Webhook.Secret)
+	}
+	if repo.items[out.ID].Webhook.Secret != "s3cret" {
+		t.Errorf("stored secret: got %q", repo.items[out.ID].Webhook.Secret)
+	}
+}
+
+func TestSaveNotificationChannel_UpdateKeepsRedactedSecret(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockChannelRepo(&domain.NotificationChannel{
+		ID:    "c1",
+		Name:  "mail",
+		Type:  domain.ChannelTypeEmail,
+		Email: &domain.EmailConfig{Host: "smtp", From: "a@b", To: []string{"c@d"}, Password: "pw"},
+	})
+	uc := NewSaveNotificationChannel(repo, log)
+
+	_, err := uc.Execute(context.Background(), SaveNotificationChannelInput{ID: "c1", Channel: domain.NotificationChannel{
+		Name:  "mail renamed",
+		Type:  domain.ChannelTypeEmail,
+		Email: &domain.EmailConfig{Host: "smtp", From: "a@b", To: []string{"c@d"}, Password: domain.RedactedSecret},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["c1"]; got.Name != "mail renamed" || got.Email.Password != "pw" {
+		t.Errorf("got %+v / %+v", got, got.Email)
+	}
+}
+
+func TestSaveNotificationChannel_UpdateKeepsRedactedHeadersAndChatURL(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := newMockChannelRepo(
+		&domain.NotificationChannel{
+			ID:      "w1",
+			Name:    "hook",
+			Type:    domain.ChannelTypeWebhook,
+			Webhook: &domain.WebhookConfig{URL: "https://hooks.example.com/x", Headers: map[string]string{"Authorization": "Bearer t0k3n", "X-Env": "prod"}},
+		},
+		&domain.NotificationChannel{
+			ID:   "s1",
+			Name: "slack",
+			Type: domain.ChannelTypeSlack,
+			Chat: &domain.ChatConfig{URL: "https://hooks.slack.com/services/T000/B000/XXXX"},
+		},
+	)
+	uc := NewSaveNotificationChannel(repo, log)
+	ctx := context.Background()
+
+	// The client sends back what the list returned, with one header changed.
+	listed := repo.items["w1"].Redacted()
+	if listed.Webhook.Headers["Authorization"] != domain.RedactedSecret {
+		t.Fatalf("header not redacted: %v", listed.Webhook.Headers)
+	}
+	listed.Webhook.Headers["X-Env"] = "staging"
+	out, err := uc.Execute(ctx, SaveNotificationChannelInput{ID: "w1", Channel: *listed})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["w1"].Webhook.Headers; got["Authorization"] != "Bearer t0k3n" || got["X-Env"] != "staging" {
+		t.Errorf("stored headers = %v", got)
+	}
+	if out.Webhook.Headers["X-Env"] != domain.RedactedSecret {
+		t.Errorf("output headers = %v", out.Webhook.Headers)
+	}
+
+	listed = repo.items["s1"].Redacted()
+	if listed.Chat.URL != "https://hooks.slack.com/"+domain.RedactedSecret {
+		t.Fatalf("chat url = %q", listed.Chat.URL)
+	}
+	listed.Name = "slack renamed"
+	if _, err := uc.Execute(ctx, SaveNotificationChannelInput{ID: "s1", Channel: *listed}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := repo.items["s1"]; got.Name != "slack renamed" || got.Chat.URL != "https://hooks.slack.com/services/T000/B000/XXXX" {
+		t.Errorf("stored = %+v / %+v", got, got.Chat)
+	}
+}
+
+func TestSaveNotificationChannel_Validation(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	uc := NewSaveNotificationChannel(newMockChannelRepo(), log)
+	ctx := context.Background()
+
+	cases := map[string]domain.NotificationChannel{
+		"missing name":     {Type: domain.ChannelTypeSlack, Chat: &domain.ChatConfig{URL: "https://x"}},
+		"unknown type":     {Name: "x", Type: "pager"},
+		"missing config":   {Name: "x", Type: domain.ChannelTypeDiscord},
+		"relative url":     {Name: "x", Type: domain.ChannelTypeWebhook, Webhook: &domain.WebhookConfig{URL: "/hook"}},
+		"email without to": {Name: "x", Type: domain.ChannelTypeEmail, Email: &domain.EmailConfig{Host: "h", From: "a@b"}},
+	}
+	for name, ch := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, SaveNotificationChannelInput{Channel: ch})
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("expected ErrInvalidInput, got %v", err)
+			}
+		})
+	}
+
+	t.Run("update unknown id", func(t *testing.T) {
+		_, err := uc.Execute(ctx, SaveNotificationChannelInput{ID: "nope", Channel: domain.NotificationChannel{Name: "x"}})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
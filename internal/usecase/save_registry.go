@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+var registryNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+type SaveRegistryInput struct {
+	// Name is empty when creating a registry; it cannot be changed later.
+	Name     string
+	Registry domain.Registry
+}
+
+type SaveRegistry struct {
+	repo domain.RegistryRepository
+	log  *slog.Logger
+}
+
+func NewSaveRegistry(repo domain.RegistryRepository, log *slog.Logger) *SaveRegistry {
+	return &SaveRegistry{repo: repo, log: log}
+}
+
+func (uc *SaveRegistry) Execute(ctx context.Context, input SaveRegistryInput) (*domain.Registry, error) {
+	reg := input.Registry
+	now := time.Now().UTC()
+	if input.Name == "" {
+		if !registryNamePattern.MatchString(reg.Name) {
+			return nil, invalidInput("name must be 1-64 letters, digits, '.', '_' or '-'")
+		}
+		if _, err := uc.repo.Get(ctx, reg.Name); err == nil {
+			return nil, fmt.Errorf("registry %s already exists: %w", reg.Name, domain.ErrConflict)
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		reg.CreatedAt = now
+		if reg.Password == domain.RedactedSecret {
+			reg.Password = ""
+		}
+	} else {
+		existing, err := uc.repo.Get(ctx, input.Name)
+		if err != nil {
+			return nil, err
+		}
+		reg.Name = existing.Name
+		reg.CreatedAt = existing.CreatedAt
+		if reg.Password == domain.RedactedSecret {
+			reg.Password = existing.Password
+		}
+	}
+	reg.UpdatedAt = now
+	reg.URL = strings.TrimSuffix(strings.TrimSpace(reg.URL), "/")
+
+	if err := validateHTTPURL(reg.URL); err != nil {
+		return nil, err
+	}
+	if reg.Password != "" && reg.Username == "" {
+		return nil, invalidInput("password requires a username")
+	}
+	if err := uc.repo.Save(ctx, &reg); err != nil {
+		uc.log.ErrorContext(ctx, "save registry failed", "registry", reg.Name, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "registry saved", "registry", reg.Name, "url", reg.URL)
+	return reg.Redacted(), nil
+}
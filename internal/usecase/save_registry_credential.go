@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SaveRegistryCredential struct {
+	repo domain.RegistryCredentialRepository
+	log  *slog.Logger
+}
+
+func NewSaveRegistryCredential(repo domain.RegistryCredentialRepository, log *slog.Logger) *SaveRegistryCredential {
+	return &SaveRegistryCredential{repo: repo, log: log}
+}
+
+// Execute adds or replaces the credential for cred.Registry. Secrets sent
+// back redacted keep their stored value.
+func (uc *SaveRegistryCredential) Execute(ctx context.Context, cred domain.RegistryCredential) (*domain.StoredRegistryCredential, error) {
+	cred.Registry = domain.NormalizeRegistryHost(cred.Registry)
+	if cred.Registry == "" {
+		return nil, invalidInput("registry is required")
+	}
+	if cred.Password == domain.RedactedSecret || cred.IdentityToken == domain.RedactedSecret {
+		existing, err := uc.repo.Get(ctx, cred.Registry)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		if existing == nil {
+			existing = &domain.StoredRegistryCredential{}
+		}
+		if cred.Password == domain.RedactedSecret {
+			cred.Password = existing.Password
+		}
+		if cred.IdentityToken == domain.RedactedSecret {
+			cred.IdentityToken = existing.IdentityToken
+		}
+	}
+	if err := validateRegistryCredential(&cred); err != nil {
+		return nil, err
+	}
+
+	stored := &domain.StoredRegistryCredential{RegistryCredential: cred, UpdatedAt: time.Now().UTC()}
+	if err := uc.repo.Save(ctx, stored); err != nil {
+		uc.log.ErrorContext(ctx, "save registry credential failed", "registry", cred.Registry, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "registry credential saved", "registry", cred.Registry, "username", cred.Username)
+	return stored.Redacted(), nil
+}
+
+func validateRegistryCredential(c *domain.RegistryCredential) error {
+	if c.IdentityToken == "" && (c.Username == "" || c.Password == "") {
+		return invalidInput("username and password, or identity_token, are required")
+	}
+	return nil
+}
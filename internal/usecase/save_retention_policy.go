@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SaveRetentionPolicyInput struct {
+	// ID is empty when creating a policy.
+	ID     string
+	Policy domain.RetentionPolicy
+}
+
+type SaveRetentionPolicy struct {
+	repo domain.RetentionPolicyRepository
+	log  *slog.Logger
+}
+
+func NewSaveRetentionPolicy(repo domain.RetentionPolicyRepository, log *slog.Logger) *SaveRetentionPolicy {
+	return &SaveRetentionPolicy{repo: repo, log: log}
+}
+
+func (uc *SaveRetentionPolicy) Execute(ctx context.Context, input SaveRetentionPolicyInput) (*domain.RetentionPolicy, error) {
+	p := input.Policy
+	p.Repository = strings.TrimSpace(p.Repository)
+	if p.Repository == "" {
+		return nil, invalidInput("repository is required")
+	}
+	if _, err := path.Match(p.Repository, ""); err != nil {
+		return nil, invalidInput("invalid repository pattern %q", p.Repository)
+	}
+	if p.KeepLast < 0 || p.MaxAgeDays < 0 {
+		return nil, invalidInput("keep_last and max_age_days must not be negative")
+	}
+	// A policy with only a keep pattern would delete every other tag.
+	if p.KeepLast == 0 && p.MaxAgeDays == 0 {
+		return nil, invalidInput("set keep_last or max_age_days")
+	}
+	if p.KeepTagPattern != "" {
+		if _, err := regexp.Compile(p.KeepTagPattern); err != nil {
+			return nil, invalidInput("invalid keep_tag_pattern: %v", err)
+		}
+	}
+	if p.Schedule != "" {
+		if _, err := domain.ParseCron(p.Schedule); err != nil {
+			return nil, invalidInput("%v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	if input.ID == "" {
+		p.ID = newID()
+		p.CreatedAt = now
+	} else {
+		existing, err := uc.repo.Get(ctx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		p.ID = existing.ID
+		p.CreatedAt = existing.CreatedAt
+	}
+	p.UpdatedAt = now
+	if err := uc.repo.Save(ctx, &p); err != nil {
+		uc.log.ErrorContext(ctx, "save retention policy failed", "policy_id", p.ID, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "retention policy saved", "policy_id", p.ID, "repository", p.Repository)
+	return &p, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type SaveVolumeBackupScheduleInput struct {
+	// ID is empty when creating a schedule.
+	ID       string
+	Schedule domain.VolumeBackupSchedule
+}
+
+// SaveVolumeBackupSchedule allows one schedule per volume: scheduled backups
+// are not tagged with the schedule that took them, so two schedules on the
+// same volume would prune each other's backups.
+type SaveVolumeBackupSchedule struct {
+	repo domain.VolumeBackupScheduleRepository
+	log  *slog.Logger
+}
+
+func NewSaveVolumeBackupSchedule(repo domain.VolumeBackupScheduleRepository, log *slog.Logger) *SaveVolumeBackupSchedule {
+	return &SaveVolumeBackupSchedule{repo: repo, log: log}
+}
+
+func (uc *SaveVolumeBackupSchedule) Execute(ctx context.Context, input SaveVolumeBackupScheduleInput) (*domain.VolumeBackupSchedule, error) {
+	s := input.Schedule
+	s.Volume = strings.TrimSpace(s.Volume)
+	if !volumeNamePattern.MatchString(s.Volume) {
+		return nil, invalidInput("invalid volume name %q", s.Volume)
+	}
+	if _, err := domain.ParseCron(s.Schedule); err != nil {
+		return nil, invalidInput("%v", err)
+	}
+	if s.KeepDaily < 0 || s.KeepWeekly < 0 {
+		return nil, invalidInput("keep_daily and keep_weekly must not be negative")
+	}
+
+	all, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range all {
+		if other.Volume == s.Volume && other.ID != input.ID {
+			return nil, fmt.Errorf("volume %s already has backup schedule %s: %w", s.Volume, other.ID, domain.ErrConflict)
+		}
+	}
+
+	now := time.Now().UTC()
+	if input.ID == "" {
+		s.ID = newID()
+		s.CreatedAt = now
+	} else {
+		existing, err := uc.repo.Get(ctx, input.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.ID = existing.ID
+		s.CreatedAt = existing.CreatedAt
+	}
+	s.UpdatedAt = now
+	if err := uc.repo.Save(ctx, &s); err != nil {
+		uc.log.ErrorContext(ctx, "save volume backup schedule failed", "schedule_id", s.ID, "error", err)
+		return nil, err
+	}
+	uc.log.InfoContext(ctx, "volume backup schedule saved", "schedule_id", s.ID, "volume", s.Volume)
+	return &s, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type mockBackupScheduleRepo struct {
+	list []*domain.VolumeBackupSchedule
+}
+
+func (m *mockBackupScheduleRepo) List(ctx context.Context) ([]*domain.VolumeBackupSchedule, error) {
+	return m.list, nil
+}
+
+func (m *mockBackupScheduleRepo) Get(ctx context.Context, id string) (*domain.VolumeBackupSchedule, error) {
+	for _, s := range m.list {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockBackupScheduleRepo) Save(ctx context.Context, s *domain.VolumeBackupSchedule) error {
+	for i, old := range m.list {
+		if old.ID == s.ID {
+			m.list[i] = s
+			return nil
+		}
+	}
+	m.list = append(m.list, s)
+	return nil
+}
+
+func (m *mockBackupScheduleRepo) Delete(ctx context.Context, id string) error { return nil }
+
+func TestSaveVolumeBackupSchedule_OnePerVolume(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	repo := &mockBackupScheduleRepo{}
+	uc := NewSaveVolumeBackupSchedule(repo, log)
+	ctx := context.Background()
+
+	nightly := domain.VolumeBackupSchedule{Volume: "pgdata", Schedule: "0 2 * * *", KeepDaily: 7, Enabled: true}
+	first, err := uc.Execute(ctx, SaveVolumeBackupScheduleInput{Schedule: nightly})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hourly := nightly
+	hourly.Schedule = "0 * * * *"
+	if _, err := uc.Execute(ctx, SaveVolumeBackupScheduleInput{Schedule: hourly}); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second schedule for the volume: expected ErrConflict, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, SaveVolumeBackupScheduleInput{ID: first.ID, Schedule: hourly}); err != nil {
+		t.Errorf("updating the volume's own schedule: %v", err)
+	}
+
+	other := nightly
+	other.Volume = "app-data"
+	if _, err := uc.Execute(ctx, SaveVolumeBackupScheduleInput{Schedule: other}); err != nil {
+		t.Errorf("schedule for another volume: %v", err)
+	}
+	if len(repo.list) != 2 {
+		t.Errorf("expected 2 schedules, got %d", len(repo.list))
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type AffectedContainer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ImageVulnerabilityReport struct {
+	ImageID  string                        `json:"image_id"`
+	RepoTags []string                      `json:"repo_tags"`
+	Distro   *domain.Distro                `json:"distro,omitempty"`
+	Packages int                           `json:"packages"`
+	Counts   domain.SeverityCounts         `json:"counts"`
+	Findings []domain.VulnerabilityFinding `json:"findings"`
+	// Containers lists the running containers created from the image.
+	Containers []AffectedContainer `json:"containers"`
+	// Database is the state of the advisories the image was matched against.
+	Database *domain.VulnerabilityDBStatus `json:"database"`
+}
+
+// ScanImageVulnerabilities matches an image's package inventory (see
+// GenerateImageSBOM, whose cache it shares) against the local advisory
+// database.
+type ScanImageVulnerabilities struct {
+	sboms      *GenerateImageSBOM
+	db         domain.VulnerabilityDatabase
+	containers domain.ContainerRepository
+	log        *slog.Logger
+}
+
+func NewScanImageVulnerabilities(sboms *GenerateImageSBOM, db domain.VulnerabilityDatabase, containers domain.ContainerRepository, log *slog.Logger) *ScanImageVulnerabilities {
+	return &ScanImageVulnerabilities{sboms: sboms, db: db, containers: containers, log: log}
+}
+
+func (uc *ScanImageVulnerabilities) Execute(ctx context.Context, ref string) (*ImageVulnerabilityReport, error) {
+	if ref == "" {
+		return nil, invalidInput("missing image id")
+	}
+	sbom, err := uc.sboms.inventory(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	running, err := uc.containers.ListActive(ctx, false)
+	if err != nil {
+		uc.log.ErrorContext(ctx, "container list failed", "error", err)
+		return nil, err
+	}
+	report := vulnerabilityReport(sbom, uc.db)
+	for _, c := range running {
+		if c.ImageID == sbom.ImageID {
+			report.Containers = append(report.Containers, AffectedContainer{ID: c.ID, Name: containerDisplayName(c)})
+		}
+	}
+	return report, nil
+}
+
+func vulnerabilityReport(sbom *domain.SBOM, db domain.VulnerabilityDatabase) *ImageVulnerabilityReport {
+	report := &ImageVulnerabilityReport{
+		ImageID:    sbom.ImageID,
+		RepoTags:   sbom.RepoTags,
+		Distro:     sbom.Distro,
+		Packages:   len(sbom.Packages),
+		Findings:   db.Match(sbom.Distro, sbom.Packages),
+		Containers: []AffectedContainer{},
+		Database:   db.Status(),
+	}
+	for _, f := range report.Findings {
+		report.Counts.Add(f.Severity)
+	}
+	return report
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// mockVulnDB flags every package named in vulnerable with its severity.
+type mockVulnDB struct {
+	vulnerable map[string]string
+}
+
+func (m *mockVulnDB) Match(distro *domain.Distro, pkgs []domain.Package) []domain.VulnerabilityFinding {
+	var out []domain.VulnerabilityFinding
+	for _, p := range pkgs {
+		if sev, ok := m.vulnerable[p.Name]; ok {
+			out = append(out, domain.VulnerabilityFinding{ID: "VULN-" + p.Name, Severity: sev, Package: p.Name, Version: p.Version})
+		}
+	}
+	return out
+}
+
+func (m *mockVulnDB) Reload(ctx context.Context) (*domain.VulnerabilityDBStatus, error) {
+	return m.Status(), nil
+}
+
+func (m *mockVulnDB) Status() *domain.VulnerabilityDBStatus {
+	return &domain.VulnerabilityDBStatus{Advisories: len(m.vulnerable)}
+}
+
+func TestScanImageVulnerabilities(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	inspector := &mockImageInspector{details: map[string]*domain.ImageDetails{
+		"app:latest": {ID: "sha256:app", RepoTags: []string{"app:latest"}},
+		"sha256:app": {ID: "sha256:app", RepoTags: []string{"app:latest"}},
+		"sha256:db":  {ID: "sha256:db", RepoTags: []string{"db:16"}},
+	}}
+	scanner := &mockFileScanner{files: map[string]string{
+		"pkgs/openssl": "openssl 3.0.11",
+		"pkgs/zlib":    "zlib 1.2.13",
+	}}
+	sboms := NewGenerateImageSBOM(inspector, scanner, lineCataloger{}, formatEncoder{}, log)
+	db := &mockVulnDB{vulnerable: map[string]string{"openssl": domain.VulnSeverityCritical, "zlib": domain.VulnSeverityLow}}
+	containers := &mockContainerRepo{list: []*domain.Container{
+		{ID: "c1", Names: []string{"/web"}, Image: "app:latest", ImageID: "sha256:app"},
+		{ID: "c2", Names: []string{"/worker"}, Image: "app:latest", ImageID: "sha256:app"},
+		{ID: "c3", Names: []string{"/gone"}, Image: "old", ImageID: "sha256:gone"},
+	}}
+	ctx := context.Background()
+
+	report, err := NewScanImageVulnerabilities(sboms, db, containers, log).Execute(ctx, "app:latest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Counts.Critical != 1 || report.Counts.Low != 1 || report.Packages != 2 || len(report.Findings) != 2 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Containers) != 2 || report.Containers[0].Name != "web" {
+		t.Errorf("containers = %+v", report.Containers)
+	}
+
+	list, err := NewListVulnerableContainers(sboms, db, containers, log).Execute(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 3 || list[0].Counts.Critical != 1 || list[2].Name != "gone" || list[2].Error == "" {
+		t.Errorf("unexpected list: %+v", list)
+	}
+	// The two containers of sha256:app share one cached inventory.
+	if scanner.scans != 1 {
+		t.Errorf("image scanned %d times, want 1", scanner.scans)
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// StreamVolumeBackup archives a volume like BackupVolume but returns the
+// archive instead of storing it.
+type StreamVolumeBackup struct {
+	files      domain.VolumeFileAccess
+	containers domain.ContainerRepository
+	controller domain.ContainerController
+	log        *slog.Logger
+	now        func() time.Time
+}
+
+func NewStreamVolumeBackup(files domain.VolumeFileAccess, containers domain.ContainerRepository, controller domain.ContainerController, log *slog.Logger) *StreamVolumeBackup {
+	return &StreamVolumeBackup{files: files, containers: containers, controller: controller, log: log, now: time.Now}
+}
+
+// Execute returns the archive, which the caller must close. The backup's ID
+// is a suitable file name.
+func (uc *StreamVolumeBackup) Execute(ctx context.Context, input BackupVolumeInput) (*domain.VolumeBackup, io.ReadCloser, error) {
+	return archiveVolume(ctx, uc.files, uc.containers, uc.controller, uc.now(), input, uc.log)
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+var (
+	tagPattern  = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	repoPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+)
+
+type TagImageInput struct {
+	Ref  string
+	Repo string
+	Tag  string
+}
+
+type TagImage struct {
+	images domain.ImageManager
+	log    *slog.Logger
+}
+
+func NewTagImage(images domain.ImageManager, log *slog.Logger) *TagImage {
+	return &TagImage{images: images, log: log}
+}
+
+// Execute tags the image and returns the new reference.
+func (uc *TagImage) Execute(ctx context.Context, input TagImageInput) (string, error) {
+	if input.Ref == "" {
+		return "", invalidInput("missing image id")
+	}
+	tag := input.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	if !validRepository(input.Repo) {
+		return "", invalidInput("invalid repository %q", input.Repo)
+	}
+	if !tagPattern.MatchString(tag) {
+		return "", invalidInput("invalid tag %q", tag)
+	}
+	target := input.Repo + ":" + tag
+	if err := uc.images.Tag(ctx, input.Ref, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// validRepository accepts an optional registry host (with port) followed by
+// a lowercase path, e.g. "registry.local:5000/team/app".
+func validRepository(repo string) bool {
+	if repo == "" {
+		return false
+	}
+	if i := strings.Index(repo, "/"); i > 0 {
+		if host := repo[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			repo = repo[i+1:]
+		}
+	}
+	return repoPattern.MatchString(repo)
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestTagImage_Execute(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+
+	valid := map[string]TagImageInput{
+		"registry.local:5000/team/app:v1.2": {Ref: "sha256:1", Repo: "registry.local:5000/team/app", Tag: "v1.2"},
+		"app:latest":                        {Ref: "sha256:1", Repo: "app"},
+		"localhost/my_app:2026-01":          {Ref: "sha256:1", Repo: "localhost/my_app", Tag: "2026-01"},
+	}
+	for want, in := range valid {
+		images := &mockImageManager{}
+		got, err := NewTagImage(images, log).Execute(ctx, in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", want, err)
+			continue
+		}
+		if got != want || images.lastRef != want {
+			t.Errorf("got %q (manager saw %q), want %q", got, images.lastRef, want)
+		}
+	}
+
+	invalid := []TagImageInput{
+		{Repo: "app"},
+		{Ref: "sha256:1", Repo: ""},
+		{Ref: "sha256:1", Repo: "App"},
+		{Ref: "sha256:1", Repo: "app", Tag: ".bad"},
+		{Ref: "sha256:1", Repo: "app", Tag: "with space"},
+	}
+	for _, in := range invalid {
+		if _, err := NewTagImage(&mockImageManager{}, log).Execute(ctx, in); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v: expected ErrInvalidInput, got %v", in, err)
+		}
+	}
+}
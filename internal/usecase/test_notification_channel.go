@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type TestNotificationChannel struct {
+	repo     domain.NotificationChannelRepository
+	notifier domain.Notifier
+	log      *slog.Logger
+}
+
+func NewTestNotificationChannel(repo domain.NotificationChannelRepository, notifier domain.Notifier, log *slog.Logger) *TestNotificationChannel {
+	return &TestNotificationChannel{repo: repo, notifier: notifier, log: log}
+}
+
+// Execute sends a test message even when the channel is disabled, so it can
+// be verified before being switched on.
+func (uc *TestNotificationChannel) Execute(ctx context.Context, id string) error {
+	ch, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	n := &domain.Notification{
+		Title:     "DockScope test notification",
+		Message:   "If you can read this, the \"" + ch.Name + "\" channel is configured correctly.",
+		Severity:  domain.SeverityInfo,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := uc.notifier.Send(ctx, ch, n); err != nil {
+		uc.log.WarnContext(ctx, "test notification failed", "channel_id", id, "error", err)
+		return err
+	}
+	return nil
+}
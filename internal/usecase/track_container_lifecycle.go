@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// TrackContainerLifecycle records start/die/oom/restart events and health
+// transitions per container, and fires an alert when a container enters a
+// crash loop. The history of a container is dropped when it is destroyed.
+type TrackContainerLifecycle struct {
+	events    domain.ContainerEventSource
+	lifecycle domain.LifecycleRepository
+	fire      *FireAlert
+	policy    CrashLoopPolicy
+	log       *slog.Logger
+}
+
+func NewTrackContainerLifecycle(
+	events domain.ContainerEventSource,
+	lifecycle domain.LifecycleRepository,
+	fire *FireAlert,
+	policy CrashLoopPolicy,
+	log *slog.Logger,
+) *TrackContainerLifecycle {
+	return &TrackContainerLifecycle{events: events, lifecycle: lifecycle, fire: fire, policy: policy, log: log}
+}
+
+func (uc *TrackContainerLifecycle) Execute(ctx context.Context) error {
+	return consumeContainerEvents(ctx, uc.events, uc.log, func(ev domain.ContainerEvent) {
+		if err := uc.handle(ctx, ev); err != nil {
+			uc.log.WarnContext(ctx, "recording lifecycle event failed", "container_id", ev.ContainerID, "error", err)
+		}
+	})
+}
+
+func (uc *TrackContainerLifecycle) handle(ctx context.Context, ev domain.ContainerEvent) error {
+	switch ev.Action {
+	case domain.EventDestroy:
+		return uc.lifecycle.Forget(ctx, ev.ContainerID)
+	case domain.EventStart, domain.EventDie, domain.EventOOM, domain.EventRestart, domain.EventHealthStatus:
+	default:
+		return nil
+	}
+	at := ev.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	le := domain.LifecycleEvent{Action: ev.Action, Health: ev.Health, Time: at}
+
+	history, err := uc.lifecycle.History(ctx, ev.ContainerID)
+	if err != nil {
+		return err
+	}
+	if ev.Action == domain.EventDie {
+		le.ExitCode = ev.ExitCode
+		if n := len(history); n > 0 && history[n-1].Action == domain.EventOOM {
+			le.OOMKilled = true
+		}
+	}
+	if err := uc.lifecycle.Record(ctx, ev.ContainerID, le); err != nil {
+		return err
+	}
+	if ev.Action != domain.EventStart || uc.policy.Restarts <= 0 {
+		return nil
+	}
+
+	// Alert once, when the threshold is first reached within the window.
+	restarts := uc.policy.RestartsWithin(append(history, le), at)
+	if restarts != uc.policy.Restarts {
+		return nil
+	}
+	uc.log.WarnContext(ctx, "container crash loop detected", "container_id", ev.ContainerID, "restarts", restarts)
+	return uc.fire.Execute(ctx, &domain.Alert{
+		Rule:          domain.RuleCrashLoop,
+		Severity:      domain.SeverityCritical,
+		Title:         fmt.Sprintf("Container %s is crash-looping", ev.ContainerName),
+		Message:       fmt.Sprintf("%s restarted %d times in the last %s", ev.ContainerName, restarts, uc.policy.Window),
+		ContainerID:   ev.ContainerID,
+		ContainerName: ev.ContainerName,
+		Labels:        ev.Labels,
+	})
+}
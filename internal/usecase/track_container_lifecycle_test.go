@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+func TestTrackContainerLifecycle_CrashLoopAlertOnce(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	lifecycle := &mockLifecycleRepo{}
+	alerts := &mockAlertRepo{}
+	fire := NewFireAlert(alerts, &mockSilenceRepo{}, &mockWindowRepo{}, NewDispatchNotification(newMockChannelRepo(), &mockNotifier{}, log), log)
+	uc := NewTrackContainerLifecycle(nil, lifecycle, fire, CrashLoopPolicy{Restarts: 2, Window: time.Minute}, log)
+	ctx := context.Background()
+
+	base := time.Now()
+	events := []domain.ContainerEvent{
+		{Action: domain.EventStart, Time: base},
+		{Action: domain.EventOOM, Time: base.Add(1 * time.Second)},
+		{Action: domain.EventDie, ExitCode: 137, Time: base.Add(2 * time.Second)},
+		{Action: domain.EventStart, Time: base.Add(3 * time.Second)},
+		{Action: domain.EventDie, ExitCode: 1, Time: base.Add(4 * time.Second)},
+		{Action: domain.EventStart, Time: base.Add(5 * time.Second)},
+		{Action: domain.EventDie, ExitCode: 1, Time: base.Add(6 * time.Second)},
+		{Action: domain.EventStart, Time: base.Add(7 * time.Second)},
+		{Action: "exec_start", Time: base.Add(8 * time.Second)},
+	}
+	for _, ev := range events {
+		ev.ContainerID = "c1"
+		ev.ContainerName = "flaky"
+		if err := uc.handle(ctx, ev); err != nil {
+			t.Fatalf("handle %s: %v", ev.Action, err)
+		}
+	}
+
+	history := lifecycle.events["c1"]
+	if len(history) != 8 {
+		t.Fatalf("expected 8 recorded events, got %d", len(history))
+	}
+	if !history[2].OOMKilled || history[2].ExitCode != 137 {
+		t.Errorf("die after oom should be flagged: %+v", history[2])
+	}
+	if history[4].OOMKilled {
+		t.Errorf("plain die flagged as OOM: %+v", history[4])
+	}
+	if len(alerts.alerts) != 1 || alerts.alerts[0].Rule != domain.RuleCrashLoop {
+		t.Errorf("expected a single crash loop alert, got %+v", alerts.alerts)
+	}
+}
+
+func TestTrackContainerLifecycle_ForgetsDestroyedContainers(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	lifecycle := &mockLifecycleRepo{}
+	uc := NewTrackContainerLifecycle(nil, lifecycle, nil, CrashLoopPolicy{}, log)
+	ctx := context.Background()
+
+	for _, ev := range []domain.ContainerEvent{
+		{ContainerID: "ci-1", Action: domain.EventStart},
+		{ContainerID: "ci-1", Action: domain.EventDie},
+		{ContainerID: "web", Action: domain.EventStart},
+		{ContainerID: "ci-1", Action: domain.EventDestroy},
+	} {
+		if err := uc.handle(ctx, ev); err != nil {
+			t.Fatalf("handle %s: %v", ev.Action, err)
+		}
+	}
+	if _, ok := lifecycle.events["ci-1"]; ok {
+		t.Error("history of a destroyed container was kept")
+	}
+	if len(lifecycle.events["web"]) != 1 {
+		t.Errorf("history of other containers changed: %v", lifecycle.events)
+	}
+}
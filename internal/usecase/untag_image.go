@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type UntagImage struct {
+	images domain.ImageManager
+	log    *slog.Logger
+}
+
+func NewUntagImage(images domain.ImageManager, log *slog.Logger) *UntagImage {
+	return &UntagImage{images: images, log: log}
+}
+
+// Execute removes tag from the image. Removing the last tag deletes the
+// image, which the result reports under Deleted.
+func (uc *UntagImage) Execute(ctx context.Context, ref, tag string) (*domain.ImageDeleteResult, error) {
+	if ref == "" {
+		return nil, invalidInput("missing image id")
+	}
+	if tag == "" {
+		return nil, invalidInput("missing tag")
+	}
+	return uc.images.Untag(ctx, ref, tag)
+}
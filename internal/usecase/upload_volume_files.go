@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+type UploadVolumeFilesInput struct {
+	Volume string
+	// Dir is the existing directory the archive is extracted into.
+	Dir     string
+	Archive io.Reader
+}
+
+type UploadVolumeFiles struct {
+	files domain.VolumeFileAccess
+	log   *slog.Logger
+}
+
+func NewUploadVolumeFiles(files domain.VolumeFileAccess, log *slog.Logger) *UploadVolumeFiles {
+	return &UploadVolumeFiles{files: files, log: log}
+}
+
+func (uc *UploadVolumeFiles) Execute(ctx context.Context, input UploadVolumeFilesInput) error {
+	if input.Volume == "" {
+		return invalidInput("missing volume name")
+	}
+	dir := cleanVolumePath(input.Dir)
+	entry, err := uc.files.Stat(ctx, input.Volume, dir)
+	if err != nil {
+		return err
+	}
+	if entry.Type != domain.FileTypeDir {
+		return invalidInput("%s is not a directory", dir)
+	}
+	if err := uc.files.Write(ctx, input.Volume, dir, input.Archive); err != nil {
+		return err
+	}
+	uc.log.InfoContext(ctx, "files uploaded to volume", "volume", input.Volume, "dir", dir)
+	return nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// stopVolumeContainers stops the running containers that mount volume and
+// returns their IDs. On failure the ones already stopped are started again.
+func stopVolumeContainers(ctx context.Context, containers domain.ContainerRepository, controller domain.ContainerController, volume string, log *slog.Logger) ([]string, error) {
+	list, err := containers.ListActive(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var stopped []string
+	for _, c := range list {
+		if c.State != "running" || !mountsVolume(c, volume) {
+			continue
+		}
+		if err := controller.ExecuteAction(ctx, c.ID, domain.ActionStop); err != nil {
+			log.ErrorContext(ctx, "stop container for volume failed", "volume", volume, "container_id", c.ID, "error", err)
+			startVolumeContainers(ctx, controller, volume, stopped, log)
+			return nil, err
+		}
+		stopped = append(stopped, c.ID)
+	}
+	if len(stopped) > 0 {
+		log.InfoContext(ctx, "containers stopped for volume", "volume", volume, "containers", stopped)
+	}
+	return stopped, nil
+}
+
+// startVolumeContainers starts the containers stopVolumeContainers stopped,
+// even when ctx was cancelled in between.
+func startVolumeContainers(ctx context.Context, controller domain.ContainerController, volume string, ids []string, log *slog.Logger) {
+	ctx = context.WithoutCancel(ctx)
+	for _, id := range ids {
+		if err := controller.ExecuteAction(ctx, id, domain.ActionStart); err != nil {
+			log.ErrorContext(ctx, "restart container after volume operation failed", "volume", volume, "container_id", id, "error", err)
+		}
+	}
+}
+
+func mountsVolume(c *domain.Container, volume string) bool {
+	for _, m := range c.Mounts {
+		if m.Type == "volume" && m.Name == volume {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveVolume stops the volume's containers if asked, and returns the
+// backup metadata with the archive being written as it is read. The
+// containers are started again once the archive ends or fails.
+func archiveVolume(ctx context.Context, files domain.VolumeFileAccess, containers domain.ContainerRepository, controller domain.ContainerController, now time.Time, input BackupVolumeInput, log *slog.Logger) (*domain.VolumeBackup, io.ReadCloser, error) {
+	if input.Volume == "" {
+		return nil, nil, invalidInput("missing volume name")
+	}
+	trigger := input.Trigger
+	if trigger == "" {
+		trigger = domain.VolumeBackupTriggerManual
+	}
+	now = now.UTC().Truncate(time.Second)
+	b := &domain.VolumeBackup{
+		ID:        domain.VolumeBackupID(input.Volume, now, trigger),
+		Volume:    input.Volume,
+		Trigger:   trigger,
+		CreatedAt: now,
+	}
+
+	var stopped []string
+	if input.StopContainers {
+		var err error
+		if stopped, err = stopVolumeContainers(ctx, containers, controller, input.Volume, log); err != nil {
+			return nil, nil, err
+		}
+	}
+	rc, _, err := files.Read(ctx, input.Volume, "/")
+	if err != nil {
+		startVolumeContainers(ctx, controller, input.Volume, stopped, log)
+		return nil, nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		gz := gzip.NewWriter(pw)
+		err := retar(tar.NewReader(rc), gz, "", nil)
+		if err == nil {
+			err = gz.Close()
+		}
+		rc.Close()
+		startVolumeContainers(ctx, controller, input.Volume, stopped, log)
+		pw.CloseWithError(err)
+	}()
+	return b, pr, nil
+}
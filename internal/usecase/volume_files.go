@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"archive/tar"
+	"path"
+	"strings"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// cleanVolumePath makes p absolute from the volume root; ".." cannot climb
+// above it.
+func cleanVolumePath(p string) string {
+	return path.Clean("/" + p)
+}
+
+// archiveRelName returns the path of a tar entry below the archived file or
+// directory, "" for the archived path itself. Docker names the entries after
+// the base name of the copied path.
+func archiveRelName(name string) string {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	_, rest, _ := strings.Cut(name, "/")
+	return rest
+}
+
+func volumeFileEntry(hdr *tar.Header, p string) domain.VolumeFileEntry {
+	e := domain.VolumeFileEntry{
+		Name:    path.Base(p),
+		Path:    p,
+		Size:    hdr.Size,
+		Mode:    hdr.FileInfo().Mode().String(),
+		ModTime: hdr.ModTime.UTC(),
+	}
+	switch hdr.Typeflag {
+	case tar.TypeReg, tar.TypeRegA:
+		e.Type = domain.FileTypeFile
+	case tar.TypeDir:
+		e.Type, e.Size = domain.FileTypeDir, 0
+	case tar.TypeSymlink:
+		e.Type, e.LinkTarget = domain.FileTypeSymlink, hdr.Linkname
+	case tar.TypeLink:
+		e.Type, e.LinkTarget = domain.FileTypeHardlink, hdr.Linkname
+	default:
+		e.Type = domain.FileTypeOther
+	}
+	return e
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// listVolumesWithUsage lists volumes with the containers mounting each one
+// and, when withUsage is set, best effort, their disk usage.
+func listVolumesWithUsage(ctx context.Context, volumes domain.VolumeRepository, usage domain.VolumeUsageProvider, containers domain.ContainerRepository, withUsage bool, log *slog.Logger) ([]*domain.Volume, error) {
+	list, err := volumes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cs, err := containers.ListActive(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Volume, len(list))
+	for _, v := range list {
+		v.Containers = []string{}
+		byName[v.Name] = v
+	}
+	for _, c := range cs {
+		for _, m := range c.Mounts {
+			if v, ok := byName[m.Name]; ok && m.Type == "volume" {
+				v.Containers = append(v.Containers, c.ID)
+			}
+		}
+	}
+	if !withUsage {
+		return list, nil
+	}
+
+	sizes, err := usage.VolumeUsage(ctx)
+	if err != nil {
+		log.WarnContext(ctx, "volume disk usage failed, listing without sizes", "error", err)
+		return list, nil
+	}
+	for _, v := range list {
+		if u, ok := sizes[v.Name]; ok {
+			v.UsageData = &u
+		}
+	}
+	return list, nil
+}
+
+// isOrphanVolume reports whether no container uses v, by our own count of
+// mounts and, when the daemon knows it, its reference count.
+func isOrphanVolume(v *domain.Volume) bool {
+	if len(v.Containers) > 0 {
+		return false
+	}
+	return v.UsageData == nil || v.UsageData.RefCount <= 0
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dockscope/dockscope/internal/domain"
+)
+
+// WatchContainerAlerts turns Docker container events into alerts until the
+// context is cancelled.
+type WatchContainerAlerts struct {
+	events domain.ContainerEventSource
+	fire   *FireAlert
+	log    *slog.Logger
+}
+
+func NewWatchContainerAlerts(events domain.ContainerEventSource, fire *FireAlert, log *slog.Logger) *WatchContainerAlerts {
+	return &WatchContainerAlerts{events: events, fire: fire, log: log}
+}
+
+func (uc *WatchContainerAlerts) Execute(ctx context.Context) error {
+	return consumeContainerEvents(ctx, uc.events, uc.log, func(ev domain.ContainerEvent) {
+		if a := alertFromEvent(ev); a != nil {
+			if err := uc.fire.Execute(ctx, a); err != nil {
+				uc.log.WarnContext(ctx, "firing alert failed", "rule", a.Rule, "error", err)
+			}
+		}
+	})
+}
+
+func alertFromEvent(ev domain.ContainerEvent) *domain.Alert {
+	a := &domain.Alert{
+		ContainerID:   ev.ContainerID,
+		ContainerName: ev.ContainerName,
+		Labels:        ev.Labels,
+		FiredAt:       ev.Time,
+	}
+	switch ev.Action {
+	case domain.EventDie:
+		a.Rule = domain.RuleContainerDied
+		a.Severity = domain.SeverityWarning
+		if ev.ExitCode != 0 {
+			a.Severity = domain.SeverityCritical
+		}
+		a.Title = fmt.Sprintf("Container %s stopped", ev.ContainerName)
+		a.Message = fmt.Sprintf("%s (%s) exited with code %d", ev.ContainerName, ev.Image, ev.ExitCode)
+	case domain.EventOOM:
+		a.Rule = domain.RuleContainerOOM
+		a.Severity = domain.SeverityCritical
+		a.Title = fmt.Sprintf("Container %s ran out of memory", ev.ContainerName)
+		a.Message = fmt.Sprintf("%s (%s) was OOM-killed", ev.ContainerName, ev.Image)
+	case domain.EventHealthStatus:
+		if ev.Health != domain.HealthUnhealthy {
+			return nil
+		}
+		a.Rule = domain.RuleUnhealthy
+		a.Severity = domain.SeverityWarning
+		a.Title = fmt.Sprintf("Container %s is unhealthy", ev.ContainerName)
+		a.Message = fmt.Sprintf("%s (%s) failed its healthcheck", ev.ContainerName, ev.Image)
+	default:
+		return nil
+	}
+	return a
+}